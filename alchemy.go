@@ -0,0 +1,104 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// rebalancedPrefix starts the name of every face of an Alchemy rebalanced card: "A-Luminarch Aspirant".
+const rebalancedPrefix = "A-"
+
+// IsRebalanced reports whether the card is an Alchemy rebalanced version of a
+// paper card, like "A-Luminarch Aspirant". Rebalanced cards only exist on Arena.
+func (card *MagicCard) IsRebalanced() bool {
+	return card.Card != nil && IsRebalancedName(card.Name)
+}
+
+// PaperName returns the name of the paper card a rebalanced card is based on,
+// or the card's own name if it is not rebalanced. See PaperName.
+func (card *MagicCard) PaperName() string {
+	if card.Card == nil {
+		return ""
+	}
+	return PaperName(card.Name)
+}
+
+// IsRebalancedName reports whether name is the name of an Alchemy rebalanced card.
+func IsRebalancedName(name string) bool {
+	return strings.HasPrefix(name, rebalancedPrefix)
+}
+
+// PaperName removes the "A-" of every face of a rebalanced card's name:
+// "A-Fable of the Mirror-Breaker // A-Reflection of Kiki-Jiki" is
+// "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki". Other names are returned as is.
+func PaperName(name string) string {
+	if !IsRebalancedName(name) {
+		return name
+	}
+	faces := strings.Split(name, " // ")
+	for i, face := range faces {
+		faces[i] = strings.TrimPrefix(face, rebalancedPrefix)
+	}
+	return strings.Join(faces, " // ")
+}
+
+// RebalancedName adds "A-" to every face of a paper card's name, the name its
+// Alchemy rebalanced version would have. Rebalanced names are returned as is.
+//
+// Not every card has a rebalanced version, look the name up to find out.
+func RebalancedName(name string) string {
+	if IsRebalancedName(name) {
+		return name
+	}
+	faces := strings.Split(name, " // ")
+	for i, face := range faces {
+		faces[i] = rebalancedPrefix + face
+	}
+	return strings.Join(faces, " // ")
+}
+
+// ParseDecklistPaper parses a decklist like ParseDecklist, replacing Alchemy
+// rebalanced cards with their paper versions.
+//
+// Behavior:
+//   - "A-Luminarch Aspirant" becomes Luminarch Aspirant, so Arena exports
+//     can be played and validated in paper
+//   - Set codes and collector numbers of replaced cards are dropped,
+//     they name the Alchemy printing
+//   - Fails like ParseDecklist if a paper version cannot be found
+//
+// Returns:
+//   - *Decklist: Parsed deck with only paper cards
+//   - error: Context errors, parse errors, or card lookup failures
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ParseDecklistPaper(decklist string) (*Decklist, error) {
+	return ParseDecklistPaperWithContext(context.Background(), decklist)
+}
+
+// ParseDecklistPaperWithContext parses a decklist with paper versions of
+// rebalanced cards with context support. See ParseDecklistPaper.
+func ParseDecklistPaperWithContext(ctx context.Context, decklistString string) (*Decklist, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.ParseDecklistPaperWithContext(ctx, decklistString)
+}
+
+// ParseDecklistPaper parses a decklist using this Scryball instance, with paper
+// versions of rebalanced cards. See ParseDecklistPaper.
+func (s *Scryball) ParseDecklistPaper(decklistString string) (*Decklist, error) {
+	return s.ParseDecklistPaperWithContext(context.Background(), decklistString)
+}
+
+// ParseDecklistPaperWithContext parses a decklist using this Scryball instance with
+// context support, with paper versions of rebalanced cards. See ParseDecklistPaper.
+func (s *Scryball) ParseDecklistPaperWithContext(ctx context.Context, decklistString string) (*Decklist, error) {
+	result, err := s.parseDecklist(ctx, decklistString, parseOptions{paper: true})
+	if err != nil {
+		return nil, err
+	}
+	return result.decklist, nil
+}
@@ -0,0 +1,73 @@
+package scryball
+
+import (
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestRebalancedNames(t *testing.T) {
+	tests := []struct {
+		rebalanced, paper string
+	}{
+		{"A-Luminarch Aspirant", "Luminarch Aspirant"},
+		{"A-Fable of the Mirror-Breaker // A-Reflection of Kiki-Jiki", "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki"},
+	}
+	for _, tt := range tests {
+		if got := PaperName(tt.rebalanced); got != tt.paper {
+			t.Errorf("PaperName(%q) = %q, want %q", tt.rebalanced, got, tt.paper)
+		}
+		if got := RebalancedName(tt.paper); got != tt.rebalanced {
+			t.Errorf("RebalancedName(%q) = %q, want %q", tt.paper, got, tt.rebalanced)
+		}
+		if got := RebalancedName(tt.rebalanced); got != tt.rebalanced {
+			t.Errorf("RebalancedName(%q) = %q, want it unchanged", tt.rebalanced, got)
+		}
+		if got := PaperName(tt.paper); got != tt.paper {
+			t.Errorf("PaperName(%q) = %q, want it unchanged", tt.paper, got)
+		}
+	}
+
+	card := &MagicCard{Card: &client.Card{Name: "A-Luminarch Aspirant"}}
+	if !card.IsRebalanced() || card.PaperName() != "Luminarch Aspirant" {
+		t.Errorf("Expected %s to be rebalanced from Luminarch Aspirant, got %v %q", card.Name, card.IsRebalanced(), card.PaperName())
+	}
+	card = &MagicCard{Card: &client.Card{Name: "Lightning Bolt"}}
+	if card.IsRebalanced() || card.PaperName() != "Lightning Bolt" {
+		t.Errorf("Expected %s not to be rebalanced", card.Name)
+	}
+	if (&MagicCard{}).IsRebalanced() {
+		t.Error("Expected a card without data not to be rebalanced")
+	}
+}
+
+func TestParseDecklistPaper(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("aspirant", "aspirant-1", "Luminarch Aspirant", "Creature — Human Cleric"))
+	insertTestCard(t, sb, testAPICard("a-aspirant", "a-aspirant-1", "A-Luminarch Aspirant", "Creature — Human Cleric"))
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+
+	decklist := "4 A-Luminarch Aspirant (Y22) 12\n4 Lightning Bolt\n"
+
+	deck, err := sb.ParseDecklistPaper(decklist)
+	if err != nil {
+		t.Fatalf("ParseDecklistPaper failed: %v", err)
+	}
+	copies := deck.totalCopies()
+	if copies["Luminarch Aspirant"] != 4 || copies["A-Luminarch Aspirant"] != 0 {
+		t.Errorf("Expected the paper Luminarch Aspirant, got %v", copies)
+	}
+	if len(deck.Printings) != 0 {
+		t.Errorf("Expected the Alchemy printing to be dropped, got %v", deck.Printings)
+	}
+
+	deck, err = sb.ParseDecklist(decklist)
+	if err != nil {
+		t.Fatalf("ParseDecklist failed: %v", err)
+	}
+	if copies := deck.totalCopies(); copies["A-Luminarch Aspirant"] != 4 {
+		t.Errorf("Expected ParseDecklist to keep the rebalanced card, got %v", copies)
+	}
+}
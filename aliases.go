@@ -0,0 +1,82 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// CardAlias is a nickname for a card, like "Bob" for Dark Confidant.
+type CardAlias struct {
+	Alias    string // Nickname, matched ignoring case
+	CardName string // Name of the card the nickname stands for
+}
+
+// AddAlias stores a nickname for a card, consulted by QueryCard and decklist parsing.
+//
+// Behavior:
+//   - Aliases persist in the database until removed with RemoveAlias
+//   - Aliases are matched ignoring case, and take priority over card names
+//   - Adding an existing alias points it to the new card name
+//   - cardName is not looked up, so aliases can be added offline before the card is cached
+//
+// Returns:
+//   - error: Empty alias or card name, or database errors
+//
+// Example:
+//
+//	err := sb.AddAlias(ctx, "Bob", "Dark Confidant")
+//	card, err := sb.QueryCard("bob") // Dark Confidant
+func (s *Scryball) AddAlias(ctx context.Context, alias, cardName string) error {
+	alias, cardName = strings.TrimSpace(alias), normalizeCardName(cardName)
+	if alias == "" || cardName == "" {
+		return fmt.Errorf("alias and card name cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.queries.UpsertCardAlias(ctx, scryfall.UpsertCardAliasParams{Alias: alias, CardName: cardName})
+	if err != nil {
+		return fmt.Errorf("could not store alias %s: %v", alias, err)
+	}
+	return nil
+}
+
+// Aliases returns every stored alias, sorted by alias.
+func (s *Scryball) Aliases(ctx context.Context) ([]CardAlias, error) {
+	rows, err := s.queries.ListCardAliases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aliases: %v", err)
+	}
+
+	aliases := make([]CardAlias, 0, len(rows))
+	for _, row := range rows {
+		aliases = append(aliases, CardAlias{Alias: row.Alias, CardName: row.CardName})
+	}
+	return aliases, nil
+}
+
+// RemoveAlias deletes a stored alias, ignoring case. Returns an error if there is no such alias.
+func (s *Scryball) RemoveAlias(ctx context.Context, alias string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	removed, err := s.queries.DeleteCardAlias(ctx, strings.TrimSpace(alias))
+	if err != nil {
+		return fmt.Errorf("could not remove alias %s: %v", alias, err)
+	}
+	if removed == 0 {
+		return fmt.Errorf("no alias %s", alias)
+	}
+	return nil
+}
+
+// resolveAlias returns the card name name is an alias of, or name itself.
+func (s *Scryball) resolveAlias(ctx context.Context, name string) string {
+	cardName, err := s.queries.GetCardAlias(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return name
+	}
+	return cardName
+}
@@ -0,0 +1,62 @@
+package scryball
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCardAliases(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := t.Context()
+
+	insertTestCard(t, sb, testAPICard("bob", "bob-1", "Dark Confidant", "Creature — Human Wizard"))
+	insertTestCard(t, sb, testAPICard("goyf", "goyf-1", "Tarmogoyf", "Creature — Lhurgoyf"))
+
+	if err := sb.AddAlias(ctx, "Bob", "Dark Confidant"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+	if err := sb.AddAlias(ctx, "Goyf", "Dark Confidant"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+	// adding it again points it to the new card
+	if err := sb.AddAlias(ctx, "goyf", "Tarmogoyf"); err != nil {
+		t.Fatalf("AddAlias failed: %v", err)
+	}
+	if err := sb.AddAlias(ctx, " ", "Tarmogoyf"); err == nil {
+		t.Error("Expected an error for an empty alias")
+	}
+
+	aliases, err := sb.Aliases(ctx)
+	if err != nil {
+		t.Fatalf("Aliases failed: %v", err)
+	}
+	expected := []CardAlias{{"Bob", "Dark Confidant"}, {"Goyf", "Tarmogoyf"}}
+	if !reflect.DeepEqual(aliases, expected) {
+		t.Errorf("Aliases() = %v, want %v", aliases, expected)
+	}
+
+	card, err := sb.QueryCard("bob")
+	if err != nil || card.Name != "Dark Confidant" {
+		t.Errorf("Expected QueryCard(bob) to find Dark Confidant, got %v %v", card, err)
+	}
+
+	deck, unresolved, err := sb.ParseDecklistOffline("4 Bob\n4 GOYF\n")
+	if err != nil {
+		t.Fatalf("ParseDecklistOffline failed: %v", err)
+	}
+	copies := deck.totalCopies()
+	if len(unresolved) != 0 || copies["Dark Confidant"] != 4 || copies["Tarmogoyf"] != 4 {
+		t.Errorf("Expected the aliases to resolve, got %v unresolved %v", copies, unresolved)
+	}
+
+	if err := sb.RemoveAlias(ctx, "BOB"); err != nil {
+		t.Fatalf("RemoveAlias failed: %v", err)
+	}
+	if err := sb.RemoveAlias(ctx, "Bob"); err == nil {
+		t.Error("Expected an error removing an alias twice")
+	}
+	if _, unresolved, _ := sb.ParseDecklistOffline("4 Bob\n"); len(unresolved) != 1 {
+		t.Errorf("Expected a removed alias not to resolve, unresolved %v", unresolved)
+	}
+}
@@ -0,0 +1,208 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// arenaCSVColumns are the header names accepted for each column of an Arena
+// collection export, lower case. untapped.gg and MTGA Assistant name them differently.
+var arenaCSVColumns = map[string][]string{
+	"arena_id": {"id", "arena id", "arena_id", "arenaid", "grpid", "mtga id", "mtga_id"},
+	"name":     {"name", "card name", "card"},
+	"set":      {"set", "set code", "edition"},
+	"quantity": {"quantity", "count", "qty", "owned", "amount"},
+}
+
+// ImportArenaCollection reads an MTG Arena collection exported as CSV by
+// untapped.gg or MTGA Assistant, for finding the cards an Arena player is missing.
+//
+// Behavior:
+//   - The first row is a header naming the columns, in any order and case:
+//     an Arena ID ("Id", "Arena ID", "grpId"), "Name", "Set" and "Quantity" ("Count")
+//   - Rows are resolved by Arena ID when the export has one, looking up the
+//     printings cached with that ID and fetching unknown IDs from Scryfall
+//   - Rows without an Arena ID are resolved by name like decklists, and by set
+//     to the card's Arena printing in that set when cached
+//   - Rows with a quantity of 0 are skipped, other columns are ignored
+//
+// Returns:
+//   - *Collection: The owned printings, one entry per printing
+//   - error: Malformed CSV, a header without quantity and card columns, or the
+//     first row that could not be resolved, with its line number
+//
+// Example:
+//
+//	file, _ := os.Open("untapped-collection.csv")
+//	collection, err := sb.ImportArenaCollection(ctx, file)
+//	missing := collection.Missing(deck)
+func (s *Scryball) ImportArenaCollection(ctx context.Context, r io.Reader) (*Collection, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read collection header: %v", err)
+	}
+	columns := arenaCSVHeader(header)
+	if _, ok := columns["quantity"]; !ok {
+		return nil, errors.New("collection has no quantity column")
+	}
+	_, hasID := columns["arena_id"]
+	_, hasName := columns["name"]
+	if !hasID && !hasName {
+		return nil, errors.New("collection has no Arena ID or name column")
+	}
+
+	collection := NewCollection()
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return collection, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read collection: %v", err)
+		}
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		quantity, err := strconv.Atoi(field("quantity"))
+		if err != nil || quantity < 0 {
+			return nil, fmt.Errorf("line %d: invalid quantity %q", line, field("quantity"))
+		}
+		if quantity == 0 {
+			continue
+		}
+
+		card, printing, err := s.resolveArenaRow(ctx, field("arena_id"), field("name"), field("set"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		collection.Add(card, printing, quantity)
+	}
+}
+
+// arenaCSVHeader maps the recognized columns of a header to their index.
+func arenaCSVHeader(header []string) map[string]int {
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		for column, names := range arenaCSVColumns {
+			if _, seen := columns[column]; !seen && slices.Contains(names, name) {
+				columns[column] = i
+			}
+		}
+	}
+	return columns
+}
+
+// resolveArenaRow finds the card and printing of one row of an Arena collection export.
+func (s *Scryball) resolveArenaRow(ctx context.Context, arenaID, name, setCode string) (*MagicCard, Printing, error) {
+	if arenaID != "" {
+		id, err := strconv.Atoi(arenaID)
+		if err != nil {
+			return nil, Printing{}, fmt.Errorf("invalid Arena ID %q", arenaID)
+		}
+		card, err := s.findCardByArenaID(ctx, id)
+		if err != nil {
+			return nil, Printing{}, err
+		}
+		for _, printing := range card.Printings {
+			if printing.ArenaID == id {
+				return card, printing, nil
+			}
+		}
+		return card, Printing{}, nil
+	}
+
+	if name == "" {
+		return nil, Printing{}, errors.New("row without an Arena ID or name")
+	}
+	card, err := s.resolveDecklistCard(ctx, name)
+	if err != nil {
+		return nil, Printing{}, err
+	}
+	for _, printing := range card.Printings {
+		if setCode != "" && strings.EqualFold(printing.SetCode, setCode) && printing.ArenaID != 0 {
+			return card, printing, nil
+		}
+	}
+	return card, Printing{}, nil
+}
+
+// findCardByArenaID looks for a card within the database by the Arena ID of one of its printings,
+// if not found will fetch from the scryfall API
+func (s *Scryball) findCardByArenaID(ctx context.Context, arenaID int) (*MagicCard, error) {
+	oracleID, err := s.queries.GetOracleIDByArenaID(ctx, sql.NullInt64{Int64: int64(arenaID), Valid: true})
+	if err == nil {
+		return s.FetchCardByExactOracleID(ctx, oracleID)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("database error searching for Arena card %d: %v", arenaID, err)
+	}
+
+	apiCard, err := s.client.GetCardByArenaID(arenaID)
+	if err != nil {
+		return nil, err
+	}
+	return s.InsertCardFromAPI(ctx, apiCard)
+}
+
+// Missing returns the cards of a decklist the collection doesn't own enough
+// copies of, with the number of copies missing, for crafting decks on Arena.
+//
+// Behavior:
+//   - Counts every zone of the decklist: maindeck, sideboard, commanders and companion
+//   - Cards are matched by name, so any owned printing counts
+//   - Basic lands are never missing, like on Arena where they are free
+//
+// Returns:
+//   - map[*MagicCard]int: Missing copies, keyed by the decklist's cards (empty if none)
+func (c *Collection) Missing(d *Decklist) map[*MagicCard]int {
+	owned := map[string]int{}
+	for _, entry := range c.Entries {
+		owned[entry.Card.Name] += entry.Quantity
+	}
+
+	needed := map[string]int{}
+	cards := map[string]*MagicCard{}
+	need := func(card *MagicCard, qty int) {
+		if card == nil || isBasicLand(card) {
+			return
+		}
+		needed[card.Name] += qty
+		if _, ok := cards[card.Name]; !ok {
+			cards[card.Name] = card
+		}
+	}
+	for card, qty := range d.Maindeck {
+		need(card, qty)
+	}
+	for card, qty := range d.Sideboard {
+		need(card, qty)
+	}
+	for _, card := range d.Commanders {
+		need(card, 1)
+	}
+	need(d.Companion, 1)
+
+	missing := map[*MagicCard]int{}
+	for name, qty := range needed {
+		if short := qty - owned[name]; short > 0 {
+			missing[cards[name]] = short
+		}
+	}
+	return missing
+}
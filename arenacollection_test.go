@@ -0,0 +1,86 @@
+package scryball
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestImportArenaCollection(t *testing.T) {
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/arena/999" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"object": "card", "id": "opt-1", "oracle_id": "opt-oracle", "arena_id": 999,
+			"name": "Opt", "lang": "en", "type_line": "Instant", "set": "dom", "rarity": "common"}`))
+	})
+
+	arenaID := func(id int) *int { return &id }
+	bolt := testAPICard("bolt-oracle", "bolt-sta", "Lightning Bolt", "Instant")
+	bolt.Set, bolt.ArenaID = "sta", arenaID(100)
+	paper := testAPICard("bolt-oracle", "bolt-m10", "Lightning Bolt", "Instant")
+	paper.Set = "m10"
+	insertTestCard(t, sb, bolt, paper)
+	shock := testAPICard("shock-oracle", "shock-m19", "Shock", "Instant")
+	shock.Set, shock.ArenaID = "m19", arenaID(200)
+	insertTestCard(t, sb, shock)
+	ctx := context.Background()
+
+	// untapped.gg style, by Arena ID
+	collection, err := sb.ImportArenaCollection(ctx, strings.NewReader(
+		"\ufeffId,Name,Set,Rarity,Quantity\n100,Lightning Bolt,STA,Uncommon,3\n999,Opt,DOM,Common,4\n200,Shock,M19,Common,0\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(collection.Entries) != 2 || collection.NumberOfCards() != 7 {
+		t.Fatalf("Expected 7 cards in 2 entries, got %d in %d", collection.NumberOfCards(), len(collection.Entries))
+	}
+	if entry := collection.Entries[0]; entry.Card.Name != "Lightning Bolt" || entry.Printing.ID != "bolt-sta" {
+		t.Errorf("Expected 3 Lightning Bolt from STA, got %s from %q", entry.Card.Name, entry.Printing.ID)
+	}
+	if entry := collection.Entries[1]; entry.Card.Name != "Opt" || entry.Printing.ArenaID != 999 {
+		t.Errorf("Expected Opt fetched by Arena ID, got %s (%d)", entry.Card.Name, entry.Printing.ArenaID)
+	}
+
+	// MTGA Assistant style, by name and set
+	collection, err = sb.ImportArenaCollection(ctx, strings.NewReader("Card Name,Set Code,Count\nShock,m19,2\nLightning Bolt,,1\n"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if entry := collection.Entries[0]; entry.Card.Name != "Shock" || entry.Printing.ArenaID != 200 {
+		t.Errorf("Expected Shock's M19 Arena printing, got %s (%d)", entry.Card.Name, entry.Printing.ArenaID)
+	}
+	if entry := collection.Entries[1]; entry.Printing.ID != "" {
+		t.Errorf("Expected no printing without a set, got %q", entry.Printing.ID)
+	}
+
+	for _, invalid := range []string{"Name,Rarity\nShock,Common\n", "Rarity,Count\nCommon,2\n", "Name,Count\nShock,two\n"} {
+		if _, err := sb.ImportArenaCollection(ctx, strings.NewReader(invalid)); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
+
+func TestCollectionMissing(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	shock := &MagicCard{Card: testAPICard("shock-oracle", "shock-1", "Shock", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(shock, 2)
+	deck.AddCard(mountain, 20)
+	deck.AddSideboardCard(shock, 2)
+
+	collection := NewCollection()
+	collection.Add(bolt, Printing{ID: "bolt-1"}, 2)
+	collection.Add(bolt, Printing{ID: "bolt-2"}, 1)
+	collection.Add(shock, Printing{}, 4)
+
+	missing := collection.Missing(deck)
+	if len(missing) != 1 || missing[bolt] != 1 {
+		t.Errorf("Expected only 1 Lightning Bolt missing, got %v", missing)
+	}
+}
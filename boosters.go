@@ -0,0 +1,154 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// PackKind is the kind of booster pack GeneratePack opens.
+type PackKind string
+
+const (
+	// PlayBooster has 14 cards: 7 commons, 3 uncommons, 1 rare or mythic,
+	// 2 wildcards of any rarity and 1 basic land.
+	PlayBooster PackKind = "play"
+	// DraftBooster has 15 cards: 10 commons, 3 uncommons, 1 rare or mythic and 1 basic land.
+	DraftBooster PackKind = "draft"
+)
+
+// mythicChance is the chance of the rare slot holding a mythic rare instead, 1 in 8 packs.
+const mythicChance = 1.0 / 8
+
+// PackOptions configures GeneratePack.
+type PackOptions struct {
+	Kind PackKind   // Default: PlayBooster
+	Rand *rand.Rand // Default: nil, the default random source. Pass a seeded rng for reproducible packs
+}
+
+// PackCard is a card opened by GeneratePack.
+type PackCard struct {
+	Card     *MagicCard // The card, with all its printings loaded
+	Printing Printing   // The booster printing of the card in the pack's set
+}
+
+// GeneratePack opens a booster pack of a set from the cached booster
+// printings of its cards, for limited simulators and draft practice.
+//
+// Behavior:
+//   - Uses printings Scryfall marks as found in boosters of the set
+//   - The first pack of a set runs and caches the query "set:<code> is:booster",
+//     which also fetches the printings of each card not cached yet, one API call per card
+//   - Cards within a rarity are picked without repeats, like a pack from print sheets
+//   - The rare slot holds a mythic rare in 1 of 8 packs, if the set has mythics
+//   - The land slot holds a basic land printing of the set, or a common if none
+//     is found in boosters
+//   - Foils, bonus sheets and collation rules beyond rarity are not simulated
+//
+// Returns:
+//   - []PackCard: The pack in slot order: commons, uncommons, rare, wildcards, land.
+//     Slots a set has too few cards for are left out
+//   - error: Unknown kind, a set without booster cards, network or database errors
+//
+// Example:
+//
+//	pack, err := sb.GeneratePack(ctx, "neo", scryball.PackOptions{Kind: scryball.DraftBooster})
+//	for _, card := range pack {
+//	    fmt.Printf("%s (%s)\n", card.Card.Name, card.Printing.Rarity)
+//	}
+func (s *Scryball) GeneratePack(ctx context.Context, setCode string, opts PackOptions) ([]PackCard, error) {
+	commons, uncommons, wildcards := 7, 3, 2
+	switch opts.Kind {
+	case "", PlayBooster:
+	case DraftBooster:
+		commons, wildcards = 10, 0
+	default:
+		return nil, fmt.Errorf("unknown pack kind %q", opts.Kind)
+	}
+
+	pool, err := s.boosterPool(ctx, strings.ToLower(setCode))
+	if err != nil {
+		return nil, err
+	}
+
+	byRarity := map[Rarity][]PackCard{}
+	lands := []PackCard{}
+	nonLands := []PackCard{}
+	for _, card := range pool {
+		if isBasicLand(card.Card) {
+			lands = append(lands, card)
+			continue
+		}
+		byRarity[card.Printing.Rarity] = append(byRarity[card.Printing.Rarity], card)
+		nonLands = append(nonLands, card)
+	}
+
+	intN, float := rand.IntN, rand.Float64
+	if opts.Rand != nil {
+		intN, float = opts.Rand.IntN, opts.Rand.Float64
+	}
+
+	landSlot := drawPackCards(lands, 1, intN)
+	if len(landSlot) == 0 {
+		commons++
+	}
+	rareSlot := byRarity[RarityRare]
+	if mythics := byRarity[RarityMythic]; len(mythics) > 0 && (len(rareSlot) == 0 || float() < mythicChance) {
+		rareSlot = mythics
+	}
+
+	pack := drawPackCards(byRarity[RarityCommon], commons, intN)
+	pack = append(pack, drawPackCards(byRarity[RarityUncommon], uncommons, intN)...)
+	pack = append(pack, drawPackCards(rareSlot, 1, intN)...)
+	pack = append(pack, drawPackCards(nonLands, wildcards, intN)...)
+	return append(pack, landSlot...), nil
+}
+
+// boosterPool returns every cached booster printing of a set, one entry per
+// printing, querying Scryfall for the set's booster cards unless that query is cached.
+func (s *Scryball) boosterPool(ctx context.Context, setCode string) ([]PackCard, error) {
+	// cards cached by other queries, like "e:neo t:dragon", are only part of the set
+	query := "set:" + setCode + " is:booster"
+	if _, err := s.queries.GetCachedQuery(ctx, query); err == sql.ErrNoRows {
+		if _, err := s.QueryWithContext(ctx, query); err != nil {
+			return nil, fmt.Errorf("failed to find booster cards of set '%s': %w", setCode, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get cached query: %v", err)
+	}
+
+	oracleIDs, err := s.queries.ListBoosterOracleIDsBySet(ctx, setCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list booster cards of set '%s': %v", setCode, err)
+	}
+	if len(oracleIDs) == 0 {
+		return nil, fmt.Errorf("no booster cards found for set '%s'", setCode)
+	}
+
+	pool := []PackCard{}
+	for _, oracleID := range oracleIDs {
+		card, err := s.FetchCardByExactOracleID(ctx, oracleID)
+		if err != nil {
+			return nil, err
+		}
+		for _, printing := range card.Printings {
+			if printing.Booster && printing.SetCode == setCode {
+				pool = append(pool, PackCard{Card: card, Printing: printing})
+			}
+		}
+	}
+	return pool, nil
+}
+
+// drawPackCards picks n different cards from pool, or all of them in random order if it has fewer.
+func drawPackCards(pool []PackCard, n int, intN func(int) int) []PackCard {
+	pool = append([]PackCard(nil), pool...)
+	n = min(n, len(pool))
+	for i := range n {
+		j := i + intN(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+	}
+	return pool[:n]
+}
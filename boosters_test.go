@@ -0,0 +1,135 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGeneratePack(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insert := func(name, rarity, typeLine string, booster bool) {
+		card := testAPICard("oracle-"+name, "print-"+name, name, typeLine)
+		card.Rarity, card.Booster = rarity, booster
+		insertTestCard(t, sb, card)
+	}
+	for i := range 12 {
+		insert(fmt.Sprintf("Common %d", i), "common", "Creature", true)
+	}
+	for i := range 4 {
+		insert(fmt.Sprintf("Uncommon %d", i), "uncommon", "Instant", true)
+	}
+	insert("Rare", "rare", "Sorcery", true)
+	insert("Mythic", "mythic", "Planeswalker", true)
+	insert("Forest", "common", "Basic Land — Forest", true)
+	insert("Promo", "rare", "Artifact", false)
+
+	ctx := context.Background()
+	if err := sb.cacheQuery(ctx, "set:tst is:booster", nil); err != nil {
+		t.Fatalf("Failed to cache booster query: %v", err)
+	}
+	for _, tt := range []struct {
+		kind      PackKind
+		size      int
+		commons   int
+		uncommons int
+	}{
+		{PlayBooster, 14, 7, 3},
+		{DraftBooster, 15, 10, 3},
+	} {
+		pack, err := sb.GeneratePack(ctx, "TST", PackOptions{Kind: tt.kind, Rand: rand.New(rand.NewPCG(1, 2))})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.kind, err)
+		}
+		if len(pack) != tt.size {
+			t.Errorf("%s: expected %d cards, got %d", tt.kind, tt.size, len(pack))
+		}
+
+		seen := map[string]bool{}
+		for _, card := range pack[:tt.commons] {
+			if card.Printing.Rarity != RarityCommon || seen[card.Card.Name] || card.Card.Name == "Forest" {
+				t.Errorf("%s: expected different non-land commons first, got %s", tt.kind, card.Card.Name)
+			}
+			seen[card.Card.Name] = true
+		}
+		for _, card := range pack[tt.commons : tt.commons+tt.uncommons] {
+			if card.Printing.Rarity != RarityUncommon {
+				t.Errorf("%s: expected uncommons after the commons, got %s", tt.kind, card.Card.Name)
+			}
+		}
+		if rare := pack[tt.commons+tt.uncommons]; rare.Card.Name != "Rare" && rare.Card.Name != "Mythic" {
+			t.Errorf("%s: expected the rare slot to hold Rare or Mythic, got %s", tt.kind, rare.Card.Name)
+		}
+		if land := pack[len(pack)-1]; land.Card.Name != "Forest" {
+			t.Errorf("%s: expected a basic land last, got %s", tt.kind, land.Card.Name)
+		}
+		for _, card := range pack {
+			if card.Card.Name == "Promo" {
+				t.Errorf("%s: expected no printings outside boosters, got Promo", tt.kind)
+			}
+		}
+	}
+
+	if _, err := sb.GeneratePack(ctx, "tst", PackOptions{Kind: "jumpstart"}); err == nil {
+		t.Error("Expected an error for an unknown pack kind")
+	}
+}
+
+func TestGeneratePackQueriesWholeSet(t *testing.T) {
+	card := func(name, rarity string) string {
+		return fmt.Sprintf(`{"object": "card", "id": "print-%[1]s", "oracle_id": "oracle-%[1]s", "name": %[1]q, "lang": "en",
+			"type_line": "Creature", "set": "tst", "set_type": "expansion", "rarity": %[2]q, "booster": true,
+			"released_at": "2020-01-01", "prints_search_uri": "https://api.scryfall.com/cards/search?q=oracleid%%3A%[1]s&unique=prints"}`, name, rarity)
+	}
+	var cards []string
+	byName := map[string]string{"Rare": card("Rare", "rare")}
+	for i := range 10 {
+		byName[fmt.Sprintf("Common%d", i)] = card(fmt.Sprintf("Common%d", i), "common")
+	}
+	for i := range 3 {
+		byName[fmt.Sprintf("Uncommon%d", i)] = card(fmt.Sprintf("Uncommon%d", i), "uncommon")
+	}
+	for _, card := range byName {
+		cards = append(cards, card)
+	}
+
+	searches := 0
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query().Get("q")
+		switch {
+		case q == "set:tst is:booster":
+			searches++
+			fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s]}`, strings.Join(cards, ", "))
+		case strings.HasPrefix(q, "oracleid:"):
+			fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s]}`, byName[strings.TrimPrefix(q, "oracleid:")])
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	// a card cached by an earlier query is only part of the set
+	rare := testAPICard("oracle-Rare", "print-Rare", "Rare", "Creature")
+	rare.Rarity, rare.Booster = "rare", true
+	insertTestCard(t, sb, rare)
+
+	ctx := context.Background()
+	for range 2 {
+		pack, err := sb.GeneratePack(ctx, "tst", PackOptions{Kind: DraftBooster})
+		if err != nil {
+			t.Fatalf("GeneratePack failed: %v", err)
+		}
+		// 11 commons with no basic land, 3 uncommons and the rare, as far as the set has them
+		if len(pack) != 14 {
+			t.Errorf("Expected a pack of every card in the set, got %d cards", len(pack))
+		}
+	}
+	if searches != 1 {
+		t.Errorf("Expected the booster query to run once, got %d", searches)
+	}
+}
@@ -0,0 +1,106 @@
+package scryball
+
+import "strings"
+
+// IsGameChanger reports whether the card is on the Commander Game Changers list,
+// the cards that push a deck into a higher Commander bracket.
+func (card *MagicCard) IsGameChanger() bool {
+	return card.Card != nil && card.GameChanger != nil && *card.GameChanger
+}
+
+// GameChangers returns the Game Changers in the deck, commanders included, sorted by name.
+//
+// Example:
+//
+//	for _, card := range deck.GameChangers() {
+//	    fmt.Println(card.Name)
+//	}
+func (d *Decklist) GameChangers() []*MagicCard {
+	return d.cardsWhere((*MagicCard).IsGameChanger)
+}
+
+// BracketEstimate is a rough guess of a Commander deck's bracket, see Decklist.EstimateBracket.
+type BracketEstimate struct {
+	// Estimated bracket: 2 (Core), 3 (Upgraded) or 4 (Optimized). Bracket 1
+	// (Exhibition) and 5 (cEDH) depend on the deck's intent and are never estimated.
+	Bracket int
+
+	GameChangers   []*MagicCard // Cards on the Game Changers list
+	Tutors         []*MagicCard // Cards that search the library for any card
+	ExtraTurns     []*MagicCard // Cards that take extra turns
+	MassLandDenial []*MagicCard // Cards that destroy, exile or sacrifice every land
+}
+
+// EstimateBracket guesses the deck's Commander bracket from its cards, to help
+// playgroups talk about power level before a game.
+//
+// Behavior:
+//   - Bracket 4 with more than 3 Game Changers or any mass land denial
+//   - Bracket 3 with 1 to 3 Game Changers, more than 3 tutors or more than 1 extra turn card
+//   - Bracket 2 otherwise
+//   - Tutors, extra turns and mass land denial are found by their Oracle text, so
+//     unusual wordings are missed. Treat the result as a starting point, not a ruling
+//
+// Example:
+//
+//	estimate := deck.EstimateBracket()
+//	fmt.Printf("Bracket %d: %d game changers, %d tutors\n",
+//	    estimate.Bracket, len(estimate.GameChangers), len(estimate.Tutors))
+func (d *Decklist) EstimateBracket() BracketEstimate {
+	estimate := BracketEstimate{
+		GameChangers:   d.GameChangers(),
+		Tutors:         d.cardsWhere(isTutor),
+		ExtraTurns:     d.cardsWhere(takesExtraTurn),
+		MassLandDenial: d.cardsWhere(isMassLandDenial),
+	}
+
+	switch {
+	case len(estimate.GameChangers) > 3 || len(estimate.MassLandDenial) > 0:
+		estimate.Bracket = 4
+	case len(estimate.GameChangers) > 0 || len(estimate.Tutors) > 3 || len(estimate.ExtraTurns) > 1:
+		estimate.Bracket = 3
+	default:
+		estimate.Bracket = 2
+	}
+	return estimate
+}
+
+// cardsWhere returns the cards in every zone of the deck that match, sorted by name.
+func (d *Decklist) cardsWhere(match func(card *MagicCard) bool) []*MagicCard {
+	matched := []*MagicCard{}
+	for _, card := range d.allCards() {
+		if match(card) {
+			matched = append(matched, card)
+		}
+	}
+	return matched
+}
+
+// isTutor reports whether the card searches its owner's library for any card,
+// not only a land or a creature: "search your library for a card".
+func isTutor(card *MagicCard) bool {
+	return oracleTextContains(card, "search your library for a card")
+}
+
+// takesExtraTurn reports whether the card gives an extra turn.
+func takesExtraTurn(card *MagicCard) bool {
+	return oracleTextContains(card, "extra turn")
+}
+
+// isMassLandDenial reports whether the card destroys, exiles or sacrifices every land.
+func isMassLandDenial(card *MagicCard) bool {
+	return oracleTextContains(card, "destroy all lands") ||
+		oracleTextContains(card, "exile all lands") ||
+		oracleTextContains(card, "sacrifices all lands")
+}
+
+// oracleTextContains reports whether a line of the card's rules text contains text, ignoring case.
+func oracleTextContains(card *MagicCard, text string) bool {
+	text = strings.ToLower(text)
+	for _, line := range oracleLines(card) {
+		if strings.Contains(strings.ToLower(line), text) {
+			return true
+		}
+	}
+	return false
+}
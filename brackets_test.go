@@ -0,0 +1,51 @@
+package scryball
+
+import "testing"
+
+func TestEstimateBracket(t *testing.T) {
+	atraxa := testCard("atraxa", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror", withOracleText("Flying"), withColorIdentity("W", "U", "B", "G"))
+	forest := testCard("forest", "Forest", "Basic Land — Forest", withOracleText("({T}: Add {G}.)"))
+	cultivate := testCard("Cultivate", "Cultivate", "Sorcery", withOracleText("Search your library for up to two basic land cards, reveal those cards, put one onto the battlefield tapped and the other into your hand, then shuffle."))
+
+	deck := NewDecklist()
+	deck.Commanders = []*MagicCard{atraxa}
+	deck.AddCard(forest, 97)
+	deck.AddCard(cultivate, 1)
+	deck.AddCard(testCard("Time Warp", "Time Warp", "Sorcery", withOracleText("Target player takes an extra turn after this one.")), 1)
+
+	if estimate := deck.EstimateBracket(); estimate.Bracket != 2 || len(estimate.ExtraTurns) != 1 || len(estimate.Tutors) != 0 {
+		t.Errorf("Expected bracket 2 with one extra turn card and no tutors, got %+v", estimate)
+	}
+
+	rhystic := testCard("Rhystic Study", "Rhystic Study", "Sorcery", withOracleText("Whenever an opponent casts a spell, you may draw a card unless that player pays {1}."), withGameChanger())
+	deck.AddCard(rhystic, 1)
+	deck.AddCard(testCard("Demonic Tutor", "Demonic Tutor", "Sorcery", withOracleText("Search your library for a card, put that card into your hand, then shuffle."), withGameChanger()), 1)
+	deck.RemoveCard(forest, 2)
+
+	estimate := deck.EstimateBracket()
+	if estimate.Bracket != 3 || len(estimate.GameChangers) != 2 || len(estimate.Tutors) != 1 {
+		t.Errorf("Expected bracket 3 with two game changers and a tutor, got %+v", estimate)
+	}
+	if gameChangers := deck.GameChangers(); gameChangers[0].Name != "Demonic Tutor" || gameChangers[1] != rhystic {
+		t.Errorf("Expected game changers sorted by name, got %v", gameChangers)
+	}
+
+	deck.AddCard(testCard("Armageddon", "Armageddon", "Sorcery", withOracleText("Destroy all lands.")), 1)
+	deck.RemoveCard(forest, 1)
+	if estimate := deck.EstimateBracket(); estimate.Bracket != 4 || len(estimate.MassLandDenial) != 1 {
+		t.Errorf("Expected bracket 4 with mass land denial, got %+v", estimate)
+	}
+}
+
+func TestGameChangerLoadedFromDB(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	card := insertTestCard(t, sb, testCard("Rhystic Study", "Rhystic Study", "Sorcery", withOracleText("Draw a card."), withGameChanger()).Card)
+	if !card.IsGameChanger() {
+		t.Error("Expected the game changer flag to be loaded from the cache")
+	}
+	if bolt := insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant")); bolt.IsGameChanger() {
+		t.Error("Expected Lightning Bolt not to be a game changer")
+	}
+}
@@ -0,0 +1,139 @@
+package scryball
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// Scryfall bulk data types, see https://scryfall.com/docs/api/bulk-data
+const (
+	BulkOracleCards   = "oracle_cards"   // One printing of every card, about 160 MB
+	BulkUniqueArtwork = "unique_artwork" // One printing of every artwork
+	BulkDefaultCards  = "default_cards"  // Every English printing, about 500 MB
+	BulkAllCards      = "all_cards"      // Every printing in every language, over 2 GB
+)
+
+// bulkImportBatch is how many printings ImportBulkData writes per transaction.
+const bulkImportBatch = 1000
+
+// ImportBulkData caches every card of a Scryfall bulk data file, so the whole
+// card pool can be searched offline with QueryLocal and SearchText.
+//
+// Behavior:
+//   - Reads a JSON array of cards, the format of every Scryfall bulk data file,
+//     streaming it so the file is never fully in memory
+//   - Only writes the database, never queries API: printings missing from the
+//     file are not fetched, so import default_cards for every printing
+//   - Cards and printings already cached are updated, with the file's prices
+//   - Cards without an Oracle ID, like reversible card faces, are skipped
+//   - Writes in batches, so a canceled import keeps the cards written so far
+//
+// Returns:
+//   - int: Number of printings imported
+//   - error: JSON errors, context errors, or database errors
+//
+// Example:
+//
+//	file, _ := os.Open("default-cards-20250101.json")
+//	defer file.Close()
+//	n, err := sb.ImportBulkData(ctx, file)
+func (s *Scryball) ImportBulkData(ctx context.Context, r io.Reader) (int, error) {
+	decoder := json.NewDecoder(r)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+		return 0, fmt.Errorf("bulk data is not a JSON array of cards")
+	}
+
+	imported := 0
+	batch := make([]*client.Card, 0, bulkImportBatch)
+	for decoder.More() {
+		if err := ctx.Err(); err != nil {
+			return imported, err
+		}
+
+		var card client.Card
+		if err := decoder.Decode(&card); err != nil {
+			return imported, fmt.Errorf("failed to decode card %d: %v", imported+len(batch)+1, err)
+		}
+		if card.OracleID == nil {
+			continue
+		}
+
+		batch = append(batch, &card)
+		if len(batch) == bulkImportBatch {
+			if err := s.upsertAPICards(ctx, batch); err != nil {
+				return imported, err
+			}
+			imported += len(batch)
+			batch = batch[:0]
+		}
+	}
+
+	if err := s.upsertAPICards(ctx, batch); err != nil {
+		return imported, err
+	}
+	return imported + len(batch), nil
+}
+
+// DownloadBulkData downloads the Scryfall bulk data file of bulkType
+// (BulkOracleCards, BulkDefaultCards, ...) and caches every card with ImportBulkData.
+//
+// Behavior:
+//   - Makes one API call to find the file, then streams it from Scryfall's CDN
+//   - Files are large: default_cards is about 500 MB, and importing it takes minutes
+//
+// Returns:
+//   - int: Number of printings imported
+//   - error: Network errors, API errors, JSON errors, or database errors
+func (s *Scryball) DownloadBulkData(ctx context.Context, bulkType string) (int, error) {
+	_, body, err := s.client.OpenBulkData(ctx, bulkType)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	return s.ImportBulkData(ctx, body)
+}
+
+// upsertAPICards upserts cards and their printings in a single transaction.
+func (s *Scryball) upsertAPICards(ctx context.Context, cards []*client.Card) error {
+	if len(cards) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin import: %v", err)
+	}
+	defer tx.Rollback()
+	queries := s.queries.WithTx(tx)
+
+	for _, card := range cards {
+		cardParams, printingParams, err := convertAPICardToDBParams(card)
+		if err != nil {
+			return fmt.Errorf("could not convert %s to DB params: %v", card.Name, err)
+		}
+		if err := queries.UpsertCard(ctx, cardParams); err != nil {
+			return fmt.Errorf("could not upsert card %s: %v", card.Name, err)
+		}
+		if err := upsertFoldedNames(ctx, queries, cardParams.OracleID, cardParams.Name); err != nil {
+			return fmt.Errorf("could not upsert folded names of %s: %v", card.Name, err)
+		}
+		if err := queries.UpsertPrinting(ctx, printingParams); err != nil {
+			return fmt.Errorf("could not upsert printing for %s: %v", card.Name, err)
+		}
+		if err := upsertPrintingFaces(ctx, queries, card); err != nil {
+			return fmt.Errorf("could not upsert face images for %s: %v", card.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %v", err)
+	}
+	return nil
+}
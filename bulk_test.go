@@ -0,0 +1,79 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testBulkData = `[
+	{"object": "card", "id": "bolt-1", "oracle_id": "bolt", "name": "Lightning Bolt", "lang": "en",
+		"type_line": "Instant", "oracle_text": "Lightning Bolt deals 3 damage to any target.",
+		"set": "lea", "rarity": "common", "released_at": "1993-08-05", "prices": {"usd": "350.00"}},
+	{"object": "card", "id": "bolt-2", "oracle_id": "bolt", "name": "Lightning Bolt", "lang": "en",
+		"type_line": "Instant", "oracle_text": "Lightning Bolt deals 3 damage to any target.",
+		"set": "m10", "rarity": "common", "released_at": "2009-07-17", "prices": {"usd": "1.00"}},
+	{"object": "card", "id": "reversible-1", "name": "Zndrsplt // Zndrsplt", "layout": "reversible_card", "set": "sld"},
+	{"object": "card", "id": "ring-1", "oracle_id": "ring", "name": "Sol Ring", "lang": "en",
+		"type_line": "Artifact", "oracle_text": "{T}: Add {C}{C}.",
+		"set": "lea", "rarity": "uncommon", "released_at": "1993-08-05"}
+]`
+
+func TestImportBulkData(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	imported, err := sb.ImportBulkData(ctx, strings.NewReader(testBulkData))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if imported != 3 {
+		t.Errorf("Expected 3 printings imported, got %d", imported)
+	}
+
+	bolt, err := sb.FetchCardByExactName(ctx, "Lightning Bolt")
+	if err != nil {
+		t.Fatalf("Expected Lightning Bolt to be cached: %v", err)
+	}
+	if len(bolt.Printings) != 2 {
+		t.Errorf("Expected 2 printings of Lightning Bolt, got %d", len(bolt.Printings))
+	}
+
+	cards, err := sb.SearchTextWithContext(ctx, "damage")
+	if err != nil || len(cards) != 1 {
+		t.Errorf("Expected bulk imported cards to be searchable, got %d cards, %v", len(cards), err)
+	}
+
+	if _, err := sb.ImportBulkData(ctx, strings.NewReader(`{"object": "error"}`)); err == nil {
+		t.Error("Expected an error for a file that is not an array")
+	}
+}
+
+func TestDownloadBulkData(t *testing.T) {
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bulk-data/oracle_cards":
+			fmt.Fprint(w, `{"object": "bulk_data", "type": "oracle_cards",
+				"download_uri": "https://data.scryfall.io/oracle-cards/oracle-cards.json"}`)
+		case "/oracle-cards/oracle-cards.json":
+			fmt.Fprint(w, testBulkData)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	imported, err := sb.DownloadBulkData(context.Background(), BulkOracleCards)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if imported != 3 {
+		t.Errorf("Expected 3 printings imported, got %d", imported)
+	}
+
+	if _, err := sb.DownloadBulkData(context.Background(), "no_such_type"); err == nil {
+		t.Error("Expected an error for an unknown bulk data type")
+	}
+}
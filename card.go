@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/ninesl/scryball/internal/client"
+	"github.com/ninesl/scryball/internal/scryfall"
 )
 
 // MagicCard represents a Magic: The Gathering card with all its printings.
@@ -19,19 +22,30 @@ import (
 // Oracle ID uniquely identifies the card across all printings.
 type MagicCard struct {
 	*client.Card
-	Printings []Printing
+	Printings Printings
 }
 
 // Printing represents a single printing of a card in a specific set.
 // Each MagicCard may have multiple printings across different sets.
 type Printing struct {
-	SetCode     string   `json:"set_code"`
-	SetName     string   `json:"set_name"`
-	Rarity      string   `json:"rarity"`
-	ImageURI    string   `json:"image_uri"`
-	ScryfallURI string   `json:"scryfall_uri"`
-	Games       []string `json:"games"`
-	ReleasedAt  string   `json:"released_at"`
+	ID              string              `json:"id"`
+	SetCode         string              `json:"set_code"`
+	SetName         string              `json:"set_name"`
+	CollectorNumber string              `json:"collector_number"`
+	Rarity          Rarity              `json:"rarity"`
+	ImageURI        string              `json:"image_uri"`                 // "normal" image of the front face, see ImageURIForSize for others
+	ImageURIs       map[string]string   `json:"image_uris,omitempty"`      // Every image size Scryfall lists, empty for double-faced printings
+	FaceImageURIs   []map[string]string `json:"face_image_uris,omitempty"` // Image sizes of each face of double-sided printings, front first
+	CardBackID      string              `json:"card_back_id,omitempty"`    // Scryfall ID of the card back, see CardBackImageURI
+	ScryfallURI     string              `json:"scryfall_uri"`
+	Games           []string            `json:"games"`
+	ReleasedAt      string              `json:"released_at"`
+	SetType         SetType             `json:"set_type,omitempty"`        // Type of the printing's set, "expansion", "promo", ...
+	MTGOID          int                 `json:"mtgo_id,omitempty"`         // Magic Online catalog ID, 0 if not on MTGO
+	ArenaID         int                 `json:"arena_id,omitempty"`        // MTG Arena card ID, 0 if not on Arena
+	Prices          map[string]string   `json:"prices,omitempty"`          // Cached prices by currency (usd, usd_foil, eur, tix, ...), missing prices left out
+	Booster         bool                `json:"booster,omitempty"`         // Whether the printing is found in boosters of its set
+	PreviousPrices  map[string]string   `json:"previous_prices,omitempty"` // Cached prices before they last changed, empty without price history
 }
 
 // FetchCardsByQuery retrieves cards from a previously cached query.
@@ -79,13 +93,14 @@ func (s *Scryball) FetchCardsByQuery(ctx context.Context, query string) ([]*Magi
 //   - Only checks database cache, never queries API
 //   - Requires ALL names to exist in cache
 //   - Stops and returns error on first missing card
-//   - Names must match exactly (case-sensitive)
+//   - Names are matched like FetchCardByExactName, ignoring case
 //
 // Returns:
 //   - []*MagicCard: Array of cards in same order as input names
 //   - error: sql.ErrNoRows if any card not cached, or database errors
 //
-// Note: Use Query() with name queries for automatic API fallback.
+// Note: Use Query() with name queries for automatic API fallback, or
+// FetchCardsByExactNamesPartial to get the cached cards and the missing names.
 func (s *Scryball) FetchCardsByExactNames(ctx context.Context, names []string) ([]*MagicCard, error) {
 	var (
 		cards = make([]*MagicCard, len(names))
@@ -101,11 +116,53 @@ func (s *Scryball) FetchCardsByExactNames(ctx context.Context, names []string) (
 	return cards, nil
 }
 
+// FetchCardsByExactNamesPartial retrieves multiple cards by exact name from the
+// database, like FetchCardsByExactNames but without stopping at missing cards.
+//
+// Behavior:
+//   - Only checks database cache, never queries API
+//   - Names are matched like FetchCardByExactName
+//   - Missing names are collected instead of returning an error, so callers
+//     can fetch only what's absent
+//
+// Returns:
+//   - []*MagicCard: Cached cards, in the order of their names in the input
+//   - []string: Names not cached, in input order (empty if all were found)
+//   - error: Database errors
+//
+// Example:
+//
+//	cards, missing, err := sb.FetchCardsByExactNamesPartial(ctx, names)
+//	for _, name := range missing {
+//		card, err := sb.QueryCardWithContext(ctx, name)
+//		...
+//	}
+func (s *Scryball) FetchCardsByExactNamesPartial(ctx context.Context, names []string) ([]*MagicCard, []string, error) {
+	cards := make([]*MagicCard, 0, len(names))
+	missing := []string{}
+	for _, name := range names {
+		card, err := s.FetchCardByExactName(ctx, name)
+		if err == sql.ErrNoRows {
+			missing = append(missing, name)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, missing, nil
+}
+
 // FetchCardByExactName retrieves a single card by exact name from the database.
 //
 // Behavior:
 //   - Only checks database cache, never queries API
-//   - Name must match exactly (case-sensitive)
+//   - Name must match exactly, ignoring case
+//   - Split and double-faced cards are also found by the name of a single face,
+//     "Fable of the Mirror-Breaker", or with single slashes, "Fire/Ice"
+//   - Accents, quotes and punctuation are ignored when nothing matches exactly:
+//     "Lim-Dul's Vault" finds "Lim-Dûl's Vault"
 //   - Returns the card with all printings populated
 //
 // Returns:
@@ -114,7 +171,19 @@ func (s *Scryball) FetchCardsByExactNames(ctx context.Context, names []string) (
 //
 // Note: Use QueryCard() for automatic API fallback with case-insensitive matching.
 func (s *Scryball) FetchCardByExactName(ctx context.Context, name string) (*MagicCard, error) {
+	name = normalizeCardName(name)
 	dbCard, err := s.queries.GetCardByName(ctx, name)
+	if err == sql.ErrNoRows && !strings.Contains(name, " // ") {
+		var faceCard scryfall.GetCardByFaceNameRow
+		faceCard, err = s.queries.GetCardByFaceName(ctx, escapeLike(name))
+		dbCard = scryfall.GetCardByNameRow(faceCard)
+	}
+	if err == sql.ErrNoRows {
+		var cachedName string
+		if cachedName, err = s.matchFoldedName(ctx, name); err == nil {
+			dbCard, err = s.queries.GetCardByName(ctx, cachedName)
+		}
+	}
 	if err == sql.ErrNoRows {
 		return nil, err
 	}
@@ -229,26 +298,122 @@ func (s *Scryball) buildMagicCardFromDB(ctx context.Context, oracleID, name, lay
 		return nil, fmt.Errorf("error fetching printings for oracle_id %s: %v", oracleID, err)
 	}
 
+	if err := s.loadCardDetailsFromDB(ctx, oracleID, card); err != nil {
+		return nil, fmt.Errorf("error fetching details for oracle_id %s: %v", oracleID, err)
+	}
+
 	return &MagicCard{
 		Card:      card,
 		Printings: printings,
 	}, nil
 }
 
+// storedRelatedCard is a RelatedCard as stored in the cards.all_parts column.
+// Older rows store the uri as an encoded url.URL rather than a string, so it is
+// decoded separately and skipped when it is not a string.
+type storedRelatedCard struct {
+	ID        string          `json:"id"`
+	Object    string          `json:"object"`
+	Component string          `json:"component"`
+	Name      string          `json:"name"`
+	TypeLine  string          `json:"type_line"`
+	URI       json.RawMessage `json:"uri"`
+}
+
+// loadCardDetailsFromDB fills in the oracle-level fields of card that the card lookup queries leave out.
+func (s *Scryball) loadCardDetailsFromDB(ctx context.Context, oracleID string, card *client.Card) error {
+	details, err := s.queries.GetCardDetailsByOracleID(ctx, oracleID)
+	if err != nil {
+		return err
+	}
+
+	if details.AllParts.Valid && details.AllParts.String != "" {
+		var parts []storedRelatedCard
+		if err := json.Unmarshal([]byte(details.AllParts.String), &parts); err == nil {
+			for _, part := range parts {
+				related := client.RelatedCard{
+					ID:        part.ID,
+					Object:    part.Object,
+					Component: part.Component,
+					Name:      part.Name,
+					TypeLine:  part.TypeLine,
+				}
+				var uri string
+				if json.Unmarshal(part.URI, &uri) == nil {
+					if parsed, err := url.Parse(uri); err == nil {
+						related.URI = *parsed
+					}
+				}
+				card.AllParts = append(card.AllParts, related)
+			}
+		}
+	}
+
+	if details.CardFaces.Valid && details.CardFaces.String != "" {
+		var faces []client.CardFace
+		if err := json.Unmarshal([]byte(details.CardFaces.String), &faces); err == nil {
+			card.CardFaces = faces
+		}
+	}
+
+	if details.EdhrecRank.Valid {
+		rank := int(details.EdhrecRank.Int64)
+		card.EDHRecRank = &rank
+	}
+
+	if details.GameChanger.Valid {
+		card.GameChanger = &details.GameChanger.Bool
+	}
+
+	if details.Keywords != "" {
+		var keywords []string
+		if err := json.Unmarshal([]byte(details.Keywords), &keywords); err == nil {
+			card.Keywords = keywords
+		}
+	}
+
+	if details.PennyRank.Valid {
+		rank := int(details.PennyRank.Int64)
+		card.PennyRank = &rank
+	}
+
+	card.Reserved = details.Reserved
+
+	if details.Legalities != "" {
+		var legalities map[string]string
+		if err := json.Unmarshal([]byte(details.Legalities), &legalities); err == nil {
+			card.Legalities = legalities
+		}
+	}
+
+	return nil
+}
+
 func (s *Scryball) getPrintingsFromDB(ctx context.Context, oracleID string) ([]Printing, error) {
 	dbPrintings, err := s.queries.GetPrintingsByOracleID(ctx, oracleID)
 	if err != nil {
 		return nil, err
 	}
+	faceImages, err := s.getPrintingFacesFromDB(ctx, oracleID)
+	if err != nil {
+		return nil, err
+	}
 
 	printings := make([]Printing, 0, len(dbPrintings))
 	for _, dbPrinting := range dbPrintings {
 		printing := Printing{
-			SetCode:     dbPrinting.SetCode,
-			SetName:     dbPrinting.SetName,
-			Rarity:      dbPrinting.Rarity,
-			ScryfallURI: dbPrinting.ScryfallUri,
-			ReleasedAt:  dbPrinting.ReleasedAt,
+			ID:              dbPrinting.ID,
+			SetCode:         dbPrinting.SetCode,
+			SetName:         dbPrinting.SetName,
+			CollectorNumber: dbPrinting.CollectorNumber,
+			Rarity:          Rarity(dbPrinting.Rarity),
+			ScryfallURI:     dbPrinting.ScryfallUri,
+			ReleasedAt:      dbPrinting.ReleasedAt,
+			MTGOID:          int(dbPrinting.MtgoID.Int64),
+			ArenaID:         int(dbPrinting.ArenaID.Int64),
+			CardBackID:      dbPrinting.CardBackID,
+			Booster:         dbPrinting.Booster,
+			SetType:         SetType(dbPrinting.SetType),
 		}
 
 		// Parse games JSON field
@@ -259,10 +424,16 @@ func (s *Scryball) getPrintingsFromDB(ctx context.Context, oracleID string) ([]P
 			}
 		}
 
+		printing.Prices = parsePrintingPrices(dbPrinting.Prices)
+		if dbPrinting.PreviousPrices.Valid {
+			printing.PreviousPrices = parsePrintingPrices(dbPrinting.PreviousPrices.String)
+		}
+
 		// Parse image URIs JSON field
 		if dbPrinting.ImageUris.Valid && dbPrinting.ImageUris.String != "" {
 			var imageUris map[string]string
 			if err := json.Unmarshal([]byte(dbPrinting.ImageUris.String), &imageUris); err == nil {
+				printing.ImageURIs = imageUris
 				// Use normal image URI if available, fallback to small or large
 				if uri, ok := imageUris["normal"]; ok {
 					printing.ImageURI = uri
@@ -274,8 +445,39 @@ func (s *Scryball) getPrintingsFromDB(ctx context.Context, oracleID string) ([]P
 			}
 		}
 
+		// Double-faced printings list their images per face, not per printing
+		if faces, ok := faceImages[printing.ID]; ok {
+			printing.FaceImageURIs = faces
+			if printing.ImageURI == "" && len(faces) > 0 {
+				printing.ImageURI = preferredImageURI(faces[0])
+			}
+		}
+
 		printings = append(printings, printing)
 	}
 
 	return printings, nil
 }
+
+// parsePrintingPrices parses the prices JSON field of a printing, dropping
+// prices Scryfall has no value for. nil if there are none.
+func parsePrintingPrices(pricesJSON string) map[string]string {
+	if pricesJSON == "" {
+		return nil
+	}
+	var prices map[string]*string
+	if err := json.Unmarshal([]byte(pricesJSON), &prices); err != nil {
+		return nil
+	}
+	var parsed map[string]string
+	for currency, price := range prices {
+		if price == nil {
+			continue
+		}
+		if parsed == nil {
+			parsed = make(map[string]string)
+		}
+		parsed[currency] = *price
+	}
+	return parsed
+}
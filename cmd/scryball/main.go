@@ -0,0 +1,330 @@
+// Command scryball searches Scryfall from the command line through a local
+// scryball cache, so repeated lookups make no API calls.
+//
+// Usage:
+//
+//	scryball [-db path] <command> [arguments]
+//
+// Commands:
+//
+//	query <scryfall query>           list the cards matching a query
+//	card <name>                      show a card
+//	deck validate [-format f] <file> list the formats a deck is legal in, or its violations of one
+//	deck price [-currency c] <file>  price a deck with cached prices
+//	cache stats                      show what is cached
+//	cache import-bulk [-type t] [file]
+//	                                 cache every card of a Scryfall bulk data file,
+//	                                 downloading it if no file is given ("-" reads stdin)
+//	serve [-addr a]                  serve Scryfall's card search, named and autocomplete
+//	                                 endpoints from the cache, for other local apps
+//
+// The cache is kept in the user cache directory unless -db or SCRYBALL_DB is set.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/ninesl/scryball"
+)
+
+const usage = `usage: scryball [-db path] [-api url] <command> [arguments]
+
+commands:
+  query <scryfall query>             list the cards matching a query
+  card <name>                        show a card
+  deck validate [-format f] <file>   list the formats a deck is legal in, or its violations of one
+  deck price [-currency c] <file>    price a deck with cached prices
+  cache stats                        show what is cached
+  cache import-bulk [-type t] [file] cache every card of a Scryfall bulk data file
+  serve [-addr a]                    serve Scryfall API endpoints from the cache
+`
+
+// errUsage is returned for command lines that don't parse, after printing usage.
+var errUsage = errors.New("invalid arguments")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "scryball:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("scryball", flag.ContinueOnError)
+	flags.Usage = func() { fmt.Fprint(flags.Output(), usage) }
+	dbPath := flags.String("db", os.Getenv("SCRYBALL_DB"), "cache database `path`")
+	apiURL := flags.String("api", os.Getenv("SCRYBALL_API"), "Scryfall API base `url`")
+	if err := flags.Parse(args); err != nil {
+		return errUsage
+	}
+	args = flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	if *dbPath == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return fmt.Errorf("no cache directory, use -db: %v", err)
+		}
+		*dbPath = filepath.Join(dir, "scryball", "cards.db")
+	}
+	sb, err := scryball.NewWithConfig(scryball.ScryballConfig{
+		DBPath:       *dbPath,
+		AppUserAgent: "ScryballCLI/1.0",
+		APIBaseURL:   *apiURL,
+	})
+	if err != nil {
+		return err
+	}
+	defer sb.Close()
+
+	command, args := args[0], args[1:]
+	switch command {
+	case "query":
+		return runQuery(ctx, sb, args, out)
+	case "card":
+		return runCard(ctx, sb, args, out)
+	case "deck":
+		return runDeck(ctx, sb, args, out)
+	case "cache":
+		return runCache(ctx, sb, args, out)
+	case "serve":
+		return runServe(ctx, sb, args, out)
+	}
+	flags.Usage()
+	return errUsage
+}
+
+func runQuery(ctx context.Context, sb *scryball.Scryball, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return usageError("query <scryfall query>")
+	}
+	cards, err := sb.QueryWithContext(ctx, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	for _, card := range cards {
+		fmt.Fprintf(out, "%s\t%s\t%s\n", card.Name, manaCost(card), card.TypeLine)
+	}
+	return nil
+}
+
+func runCard(ctx context.Context, sb *scryball.Scryball, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return usageError("card <name>")
+	}
+	card, err := sb.QueryCardWithContext(ctx, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	faces := card.Faces()
+	for i, face := range faces {
+		if i > 0 {
+			fmt.Fprintln(out, "//")
+		}
+		fmt.Fprintf(out, "%s %s\n", face.Name, face.ManaCost)
+		if face.TypeLine != nil {
+			fmt.Fprintln(out, *face.TypeLine)
+		}
+		if face.OracleText != nil {
+			fmt.Fprintln(out, *face.OracleText)
+		}
+		if face.Power != nil && face.Toughness != nil {
+			fmt.Fprintf(out, "%s/%s\n", *face.Power, *face.Toughness)
+		}
+		if face.Loyalty != nil {
+			fmt.Fprintf(out, "Loyalty: %s\n", *face.Loyalty)
+		}
+	}
+
+	var legal []string
+	for _, format := range card.LegalFormats() {
+		legal = append(legal, string(format))
+	}
+	fmt.Fprintf(out, "\nLegal in: %s\n", strings.Join(legal, ", "))
+	if price, ok := card.Price("usd"); ok {
+		fmt.Fprintf(out, "Price: $%s\n", price)
+	}
+	fmt.Fprintf(out, "Printings: %d\n", len(card.Printings))
+	return nil
+}
+
+func runDeck(ctx context.Context, sb *scryball.Scryball, args []string, out io.Writer) error {
+	if len(args) == 0 || (args[0] != "validate" && args[0] != "price") {
+		return usageError("deck validate|price ...")
+	}
+
+	flags := flag.NewFlagSet("deck "+args[0], flag.ContinueOnError)
+	format := flags.String("format", "", "format to validate against, like modern or commander")
+	currency := flags.String("currency", "usd", "currency to price in: usd, eur or tix")
+	if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 {
+		return usageError("deck validate [-format f] <file> | deck price [-currency c] <file>")
+	}
+
+	text, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	deck, _, err := sb.ParseAnyDecklistWithContext(ctx, string(text))
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "validate" {
+		return validateDeck(deck, *format, out)
+	}
+	return priceDeck(deck, *currency, out)
+}
+
+func validateDeck(deck *scryball.Decklist, format string, out io.Writer) error {
+	if format == "" {
+		legal := deck.LegalFormats()
+		if len(legal) == 0 {
+			return errors.New("deck is not legal in any format")
+		}
+		fmt.Fprintf(out, "Legal in: %s\n", strings.Join(legal, ", "))
+		return nil
+	}
+	if err := deck.ValidateFormat(format); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Legal in %s\n", format)
+	return nil
+}
+
+func priceDeck(deck *scryball.Decklist, currency string, out io.Writer) error {
+	var total scryball.Price
+	var unpriced []string
+	section := func(name string, cards map[*scryball.MagicCard]int) {
+		if len(cards) == 0 {
+			return
+		}
+		fmt.Fprintln(out, name)
+		for _, card := range sortedCards(cards) {
+			qty := cards[card]
+			price, ok := card.Price(currency)
+			if !ok {
+				unpriced = append(unpriced, card.Name)
+				fmt.Fprintf(out, "  %d %s\t-\n", qty, card.Name)
+				continue
+			}
+			subtotal := price * scryball.Price(qty)
+			total += subtotal
+			fmt.Fprintf(out, "  %d %s\t%s\n", qty, card.Name, subtotal)
+		}
+	}
+
+	commanders := map[*scryball.MagicCard]int{}
+	for _, commander := range deck.Commanders {
+		commanders[commander]++
+	}
+	if deck.Companion != nil {
+		commanders[deck.Companion]++
+	}
+	section("Commander", commanders)
+	section("Deck", deck.Maindeck)
+	section("Sideboard", deck.Sideboard)
+
+	fmt.Fprintf(out, "Total: %s %s\n", total, currency)
+	if len(unpriced) > 0 {
+		fmt.Fprintf(out, "No %s price: %s\n", currency, strings.Join(unpriced, ", "))
+	}
+	return nil
+}
+
+func runCache(ctx context.Context, sb *scryball.Scryball, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return usageError("cache stats|import-bulk ...")
+	}
+
+	switch args[0] {
+	case "stats":
+		info, err := sb.DBInfo(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Cards:          %d\n", info.Cards)
+		fmt.Fprintf(out, "Printings:      %d\n", info.Printings)
+		fmt.Fprintf(out, "Queries:        %d\n", info.Queries)
+		fmt.Fprintf(out, "Images:         %d\n", info.Images)
+		fmt.Fprintf(out, "Size:           %.1f MB\n", float64(info.SizeBytes)/1e6)
+		fmt.Fprintf(out, "Schema version: %d\n", info.SchemaVersion)
+		return nil
+
+	case "import-bulk":
+		flags := flag.NewFlagSet("cache import-bulk", flag.ContinueOnError)
+		bulkType := flags.String("type", scryball.BulkDefaultCards, "bulk data `type` to download: oracle_cards, default_cards, ...")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() > 1 {
+			return usageError("cache import-bulk [-type t] [file]")
+		}
+
+		var imported int
+		var err error
+		switch file := flags.Arg(0); file {
+		case "":
+			fmt.Fprintf(out, "Downloading %s...\n", *bulkType)
+			imported, err = sb.DownloadBulkData(ctx, *bulkType)
+		case "-":
+			imported, err = sb.ImportBulkData(ctx, os.Stdin)
+		default:
+			var f *os.File
+			if f, err = os.Open(file); err != nil {
+				return err
+			}
+			defer f.Close()
+			imported, err = sb.ImportBulkData(ctx, f)
+		}
+		fmt.Fprintf(out, "Imported %d printings\n", imported)
+		return err
+	}
+	return usageError("cache stats|import-bulk ...")
+}
+
+func runServe(ctx context.Context, sb *scryball.Scryball, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8080", "`address` to listen on")
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return usageError("serve [-addr a]")
+	}
+	fmt.Fprintf(out, "Serving on http://%s\n", *addr)
+	return sb.ServeWithContext(ctx, *addr)
+}
+
+// usageError prints the usage of a command and returns errUsage.
+func usageError(command string) error {
+	fmt.Fprintf(os.Stderr, "usage: scryball %s\n", command)
+	return errUsage
+}
+
+func manaCost(card *scryball.MagicCard) string {
+	if card.ManaCost != nil {
+		return *card.ManaCost
+	}
+	return ""
+}
+
+// sortedCards returns the cards of a deck section sorted by name.
+func sortedCards(cards map[*scryball.MagicCard]int) []*scryball.MagicCard {
+	sorted := make([]*scryball.MagicCard, 0, len(cards))
+	for card := range cards {
+		sorted = append(sorted, card)
+	}
+	scryball.SortByName(sorted)
+	return sorted
+}
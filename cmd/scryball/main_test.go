@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testCards are the cards the fake Scryfall API knows, by lower case name.
+var testCards = map[string]string{
+	"lightning bolt": `{"object": "card", "id": "bolt-1", "oracle_id": "bolt", "name": "Lightning Bolt", "lang": "en",
+		"layout": "normal", "mana_cost": "{R}", "cmc": 1, "type_line": "Instant", "colors": ["R"], "color_identity": ["R"],
+		"oracle_text": "Lightning Bolt deals 3 damage to any target.", "set": "m10", "set_name": "Magic 2010",
+		"collector_number": "146", "rarity": "common", "released_at": "2009-07-17", "games": ["paper"],
+		"legalities": {"modern": "legal", "legacy": "legal", "standard": "not_legal"}, "prices": {"usd": "1.50"},
+		"prints_search_uri": "https://api.scryfall.com/cards/search?q=oracleid%3Abolt&unique=prints"}`,
+	"mountain": `{"object": "card", "id": "mountain-1", "oracle_id": "mountain", "name": "Mountain", "lang": "en",
+		"layout": "normal", "cmc": 0, "type_line": "Basic Land — Mountain", "color_identity": ["R"],
+		"set": "m10", "set_name": "Magic 2010", "collector_number": "242", "rarity": "common", "released_at": "2009-07-17",
+		"games": ["paper"], "legalities": {"modern": "legal", "legacy": "legal", "standard": "legal"}, "prices": {"usd": "0.10"},
+		"prints_search_uri": "https://api.scryfall.com/cards/search?q=oracleid%3Amountain&unique=prints"}`,
+}
+
+// testScryfall starts a fake Scryfall API knowing testCards and returns its URL.
+func testScryfall(t *testing.T) string {
+	t.Helper()
+	list := func(cards ...string) string {
+		return fmt.Sprintf(`{"object": "list", "has_more": false, "data": [%s]}`, strings.Join(cards, ", "))
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch {
+		case r.URL.Path == "/cards/named" && testCards[strings.ToLower(q.Get("exact"))] != "":
+			fmt.Fprint(w, testCards[strings.ToLower(q.Get("exact"))])
+		case r.URL.Path == "/cards/search" && strings.HasPrefix(q.Get("q"), "oracleid:"):
+			name := map[string]string{"oracleid:bolt": "lightning bolt", "oracleid:mountain": "mountain"}[q.Get("q")]
+			fmt.Fprint(w, list(testCards[name]))
+		case r.URL.Path == "/cards/search" && testCards[strings.ToLower(strings.Trim(q.Get("q"), `!"`))] != "":
+			fmt.Fprint(w, list(testCards[strings.ToLower(strings.Trim(q.Get("q"), `!"`))]))
+		case r.URL.Path == "/cards/search" && q.Get("q") == "t:instant":
+			fmt.Fprint(w, list(testCards["lightning bolt"]))
+		case r.URL.Path == "/cards/autocomplete":
+			fmt.Fprint(w, `{"object": "catalog", "total_values": 0, "data": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"object": "error", "status": 404, "code": "not_found", "details": "No cards found."}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// runTest runs the command line args against a fake Scryfall API and the cache at dbPath.
+func runTest(t *testing.T, api, dbPath string, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	err := run(context.Background(), append([]string{"-db", dbPath, "-api", api}, args...), &out)
+	return out.String(), err
+}
+
+func TestRunCard(t *testing.T) {
+	api, dbPath := testScryfall(t), filepath.Join(t.TempDir(), "cards.db")
+
+	out, err := runTest(t, api, dbPath, "card", "lightning", "bolt")
+	if err != nil {
+		t.Fatalf("card failed: %v", err)
+	}
+	for _, want := range []string{"Lightning Bolt {R}\nInstant\n", "Legal in: ", "modern", "Price: $1.50", "Printings: 1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected card output to contain %q, got:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "standard") {
+		t.Errorf("Expected Lightning Bolt not to be listed as legal in standard, got:\n%s", out)
+	}
+
+	if _, err := runTest(t, api, dbPath, "card", "Lightning Bolk"); err == nil || errors.Is(err, errUsage) {
+		t.Errorf("Expected a not found error for an unknown card, got %v", err)
+	}
+}
+
+func TestRunQuery(t *testing.T) {
+	api, dbPath := testScryfall(t), filepath.Join(t.TempDir(), "cards.db")
+
+	out, err := runTest(t, api, dbPath, "query", "t:instant")
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if out != "Lightning Bolt\t{R}\tInstant\n" {
+		t.Errorf("Unexpected query output %q", out)
+	}
+}
+
+func TestRunDeckValidate(t *testing.T) {
+	api, dir := testScryfall(t), t.TempDir()
+	dbPath, deckPath := filepath.Join(dir, "cards.db"), filepath.Join(dir, "deck.txt")
+	if err := os.WriteFile(deckPath, []byte("4 Lightning Bolt\n56 Mountain\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write deck: %v", err)
+	}
+
+	out, err := runTest(t, api, dbPath, "deck", "validate", "-format", "modern", deckPath)
+	if err != nil {
+		t.Fatalf("deck validate failed: %v", err)
+	}
+	if out != "Legal in modern\n" {
+		t.Errorf("Unexpected validate output %q", out)
+	}
+
+	if _, err := runTest(t, api, dbPath, "deck", "validate", "-format", "standard", deckPath); err == nil {
+		t.Error("Expected Lightning Bolt to make the deck illegal in standard")
+	}
+
+	out, err = runTest(t, api, dbPath, "deck", "validate", deckPath)
+	if err != nil {
+		t.Fatalf("deck validate without a format failed: %v", err)
+	}
+	if !strings.HasPrefix(out, "Legal in: ") || !strings.Contains(out, "modern") || strings.Contains(out, "standard") {
+		t.Errorf("Expected the deck to be legal in modern but not standard, got %q", out)
+	}
+
+	if _, err := runTest(t, api, dbPath, "deck", "validate", filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("Expected an error for a missing deck file")
+	}
+}
+
+func TestRunCacheStats(t *testing.T) {
+	api, dbPath := testScryfall(t), filepath.Join(t.TempDir(), "cards.db")
+	if _, err := runTest(t, api, dbPath, "card", "Lightning Bolt"); err != nil {
+		t.Fatalf("card failed: %v", err)
+	}
+
+	// the cache is kept in the database file between runs
+	out, err := runTest(t, api, dbPath, "cache", "stats")
+	if err != nil {
+		t.Fatalf("cache stats failed: %v", err)
+	}
+	for _, want := range []string{"Cards:          1\n", "Printings:      1\n", "Schema version: "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected cache stats to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	api, dbPath := testScryfall(t), filepath.Join(t.TempDir(), "cards.db")
+
+	for _, args := range [][]string{
+		{},
+		{"bogus"},
+		{"query"},
+		{"card"},
+		{"deck"},
+		{"deck", "validate"},
+		{"deck", "shuffle", "deck.txt"},
+		{"cache"},
+		{"cache", "clear"},
+		{"serve", "extra"},
+	} {
+		if _, err := runTest(t, api, dbPath, args...); !errors.Is(err, errUsage) {
+			t.Errorf("run %q: expected errUsage, got %v", args, err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := run(context.Background(), []string{"-unknown"}, &out); !errors.Is(err, errUsage) {
+		t.Errorf("Expected errUsage for an unknown flag, got %v", err)
+	}
+}
@@ -0,0 +1,238 @@
+package scryball
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cockatriceDeck mirrors Cockatrice's .cod XML deck format.
+//
+//	<cockatrice_deck version="1">
+//	    <deckname>Burn</deckname>
+//	    <comments>notes</comments>
+//	    <zone name="main">
+//	        <card number="4" name="Lightning Bolt"/>
+//	    </zone>
+//	    <zone name="side">
+//	        <card number="3" name="Pyroblast"/>
+//	    </zone>
+//	</cockatrice_deck>
+type cockatriceDeck struct {
+	XMLName  xml.Name         `xml:"cockatrice_deck"`
+	Version  string           `xml:"version,attr"`
+	DeckName string           `xml:"deckname"`
+	Comments string           `xml:"comments"`
+	Zones    []cockatriceZone `xml:"zone"`
+}
+
+type cockatriceZone struct {
+	Name  string           `xml:"name,attr"`
+	Cards []cockatriceCard `xml:"card"`
+}
+
+type cockatriceCard struct {
+	Number int    `xml:"number,attr"`
+	Name   string `xml:"name,attr"`
+}
+
+// Cockatrice zone names
+const (
+	cockatriceMainZone  = "main"
+	cockatriceSideZone  = "side"
+	cockatriceTokenZone = "tokens"
+)
+
+// shared Cockatrice parsing implementation
+func (sb *Scryball) parseCockatriceDecklist(ctx context.Context, cod string) (*Decklist, error) {
+	var deck cockatriceDeck
+	if err := xml.Unmarshal([]byte(cod), &deck); err != nil {
+		return nil, fmt.Errorf("invalid Cockatrice deck: %v", err)
+	}
+
+	decklist := &Decklist{
+		Name:      strings.TrimSpace(deck.DeckName),
+		Comments:  strings.TrimSpace(deck.Comments),
+		Maindeck:  make(map[*MagicCard]int),
+		Sideboard: make(map[*MagicCard]int),
+	}
+
+	var sideboardTotal int
+	for _, zone := range deck.Zones {
+		var list map[*MagicCard]int
+		switch strings.ToLower(zone.Name) {
+		case cockatriceMainZone:
+			list = decklist.Maindeck
+		case cockatriceSideZone:
+			list = decklist.Sideboard
+		case cockatriceTokenZone:
+			continue // tokens are not part of the deck
+		default:
+			return nil, fmt.Errorf("unknown Cockatrice zone %q", zone.Name)
+		}
+
+		for _, card := range zone.Cards {
+			cardName := strings.TrimSpace(card.Name)
+			if cardName == "" {
+				return nil, fmt.Errorf("card without a name in zone %q", zone.Name)
+			}
+			if card.Number < 1 {
+				return nil, fmt.Errorf("invalid quantity %d for %s", card.Number, cardName)
+			}
+
+			magicCard, err := sb.resolveDecklistCard(ctx, cardName)
+			if err != nil {
+				return nil, err
+			}
+
+			if strings.EqualFold(zone.Name, cockatriceSideZone) {
+				sideboardTotal += card.Number
+				if sideboardTotal > 15 {
+					return nil, fmt.Errorf("sideboard exceeds 15 cards (has %d)", sideboardTotal)
+				}
+			}
+			addCardToMap(magicCard, card.Number, list)
+		}
+	}
+
+	return decklist, nil
+}
+
+// ParseCockatriceDecklist parses a Cockatrice .cod XML deck and returns a Decklist.
+//
+// Format expected:
+//
+//	<?xml version="1.0" encoding="UTF-8"?>
+//	<cockatrice_deck version="1">
+//	    <deckname>Burn</deckname>
+//	    <comments>Fast and cheap</comments>
+//	    <zone name="main">
+//	        <card number="4" name="Lightning Bolt"/>
+//	        <card number="20" name="Mountain"/>
+//	    </zone>
+//	    <zone name="side">
+//	        <card number="3" name="Pyroblast"/>
+//	    </zone>
+//	</cockatrice_deck>
+//
+// Behavior:
+//   - "main" zone becomes Maindeck, "side" zone becomes Sideboard
+//   - "tokens" zone is ignored, any other zone is an error
+//   - deckname and comments populate Decklist.Name and Decklist.Comments
+//   - Cards are resolved the same way as ParseDecklist
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ParseCockatriceDecklist(cod string) (*Decklist, error) {
+	ctx := context.Background()
+	return ParseCockatriceDecklistWithContext(ctx, cod)
+}
+
+// ParseCockatriceDecklistWithContext parses a Cockatrice .cod XML deck with context support.
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ParseCockatriceDecklistWithContext(ctx context.Context, cod string) (*Decklist, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.parseCockatriceDecklist(ctx, cod)
+}
+
+// ParseCockatriceDecklist parses a Cockatrice .cod XML deck using this Scryball instance's client and database.
+//
+// See the package-level ParseCockatriceDecklist for the format.
+func (s *Scryball) ParseCockatriceDecklist(cod string) (*Decklist, error) {
+	ctx := context.Background()
+	return s.ParseCockatriceDecklistWithContext(ctx, cod)
+}
+
+// ParseCockatriceDecklistWithContext parses a Cockatrice .cod XML deck using this Scryball instance with context support.
+func (s *Scryball) ParseCockatriceDecklistWithContext(ctx context.Context, cod string) (*Decklist, error) {
+	return s.parseCockatriceDecklist(ctx, cod)
+}
+
+// ExportCod writes the decklist in Cockatrice's .cod XML format.
+//
+// Behavior:
+//   - Maindeck is written to the "main" zone, sideboard to the "side" zone
+//   - Commanders and Companion are written to the "side" zone, where Cockatrice
+//     players keep them, so they are read back as sideboard cards
+//   - The "side" zone is always present, even when the sideboard is empty
+//   - Decklist.Name and Decklist.Comments become deckname and comments
+//   - Cards within each zone are sorted by name
+//
+// The output can be passed back to ParseCockatriceDecklist() to recreate the same deck.
+func (d *Decklist) ExportCod(w io.Writer) error {
+	deck := cockatriceDeck{
+		Version:  "1",
+		DeckName: d.Name,
+		Comments: d.Comments,
+		Zones: []cockatriceZone{
+			{Name: cockatriceMainZone, Cards: cockatriceCards(d.Maindeck)},
+			{Name: cockatriceSideZone, Cards: cockatriceCards(d.sideboardWithCommanders())},
+		},
+	}
+
+	out, err := xml.MarshalIndent(deck, "", "    ")
+	if err != nil {
+		return fmt.Errorf("failed to encode .cod: %v", err)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if _, err := w.Write(out); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func cockatriceCards(list map[*MagicCard]int) []cockatriceCard {
+	cards := make([]cockatriceCard, 0, len(list))
+	for _, card := range sortedCards(list) {
+		cards = append(cards, cockatriceCard{Number: list[card], Name: card.Name})
+	}
+	return cards
+}
+
+// Hash returns the deck hash Cockatrice shows for this decklist, like "8p6mb3r5".
+//
+// Tournament and league tools use it to check that a submitted list matches
+// the one played. Only the maindeck and sideboard are hashed, using the same
+// card names ExportCod writes, so commanders and companion count as sideboard cards.
+//
+// Algorithm (Cockatrice's DeckList::getDeckHash):
+//   - One entry per copy: the lower cased card name, prefixed "SB:" for sideboard cards
+//   - Entries sorted and joined with ";", then hashed with SHA-1
+//   - The first 5 bytes of the digest as a number, written in base 32 and padded to 8 characters
+func (d *Decklist) Hash() string {
+	var entries []string
+	for card, qty := range d.Maindeck {
+		name := strings.ToLower(card.Name)
+		for range qty {
+			entries = append(entries, name)
+		}
+	}
+	for card, qty := range d.sideboardWithCommanders() {
+		name := "SB:" + strings.ToLower(card.Name)
+		for range qty {
+			entries = append(entries, name)
+		}
+	}
+	sort.Strings(entries)
+
+	sum := sha1.Sum([]byte(strings.Join(entries, ";")))
+	var number uint64
+	for _, b := range sum[:5] {
+		number = number<<8 | uint64(b)
+	}
+
+	hash := strconv.FormatUint(number, 32)
+	return strings.Repeat("0", max(0, 8-len(hash))) + hash
+}
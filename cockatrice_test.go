@@ -0,0 +1,177 @@
+package scryball
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCockatriceDecklist(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"))
+	insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+	insertTestCard(t, sb, testAPICard("goblin-oracle", "goblin-1", "Goblin", "Token Creature — Goblin"))
+
+	cod := `<?xml version="1.0" encoding="UTF-8"?>
+<cockatrice_deck version="1">
+    <deckname>Burn</deckname>
+    <comments>Fast and cheap</comments>
+    <zone name="main">
+        <card number="4" name="Lightning Bolt"/>
+        <card number="20" name="Mountain"/>
+        <card number="1" name="Mountain"/>
+    </zone>
+    <zone name="side">
+        <card number="3" name="Pyroblast"/>
+    </zone>
+    <zone name="tokens">
+        <card number="1" name="Goblin"/>
+    </zone>
+</cockatrice_deck>`
+
+	deck, err := sb.ParseCockatriceDecklist(cod)
+	if err != nil {
+		t.Fatalf("ParseCockatriceDecklist failed: %v", err)
+	}
+
+	if deck.Name != "Burn" {
+		t.Errorf("Expected deck name Burn, got %q", deck.Name)
+	}
+	if deck.Comments != "Fast and cheap" {
+		t.Errorf("Expected comments to be parsed, got %q", deck.Comments)
+	}
+	if deck.NumberOfCards() != 25 {
+		t.Errorf("Expected 25 maindeck cards, got %d", deck.NumberOfCards())
+	}
+	if len(deck.Maindeck) != 2 {
+		t.Errorf("Expected duplicate Mountain entries to merge, got %d unique cards", len(deck.Maindeck))
+	}
+	if deck.NumberOfSideboardCards() != 3 {
+		t.Errorf("Expected 3 sideboard cards, got %d", deck.NumberOfSideboardCards())
+	}
+
+	t.Run("unknown_zone", func(t *testing.T) {
+		_, err := sb.ParseCockatriceDecklist(`<cockatrice_deck version="1"><zone name="maybe"><card number="1" name="Mountain"/></zone></cockatrice_deck>`)
+		if err == nil || !strings.Contains(err.Error(), "unknown Cockatrice zone") {
+			t.Errorf("Expected unknown zone error, got: %v", err)
+		}
+	})
+
+	t.Run("invalid_xml", func(t *testing.T) {
+		if _, err := sb.ParseCockatriceDecklist("4 Lightning Bolt"); err == nil {
+			t.Error("Expected error for non-XML input")
+		}
+	})
+}
+
+func TestExportCod(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	bolt := insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+	pyro := insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+
+	deck := &Decklist{
+		Name:      "Burn",
+		Comments:  "Fast & cheap",
+		Maindeck:  map[*MagicCard]int{bolt: 4},
+		Sideboard: map[*MagicCard]int{pyro: 3},
+	}
+
+	var out strings.Builder
+	if err := deck.ExportCod(&out); err != nil {
+		t.Fatalf("ExportCod failed: %v", err)
+	}
+
+	cod := out.String()
+	for _, want := range []string{
+		`<cockatrice_deck version="1">`,
+		`<deckname>Burn</deckname>`,
+		`<comments>Fast &amp; cheap</comments>`,
+		`<zone name="main">`,
+		`<card number="4" name="Lightning Bolt"></card>`,
+		`<zone name="side">`,
+		`<card number="3" name="Pyroblast"></card>`,
+	} {
+		if !strings.Contains(cod, want) {
+			t.Errorf("Expected .cod to contain %s, got:\n%s", want, cod)
+		}
+	}
+
+	// round trip
+	parsed, err := sb.ParseCockatriceDecklist(cod)
+	if err != nil {
+		t.Fatalf("Failed to parse exported .cod: %v", err)
+	}
+	if parsed.Name != deck.Name || parsed.Comments != deck.Comments {
+		t.Errorf("Round trip lost name/comments: %q %q", parsed.Name, parsed.Comments)
+	}
+	if parsed.NumberOfCards() != 4 || parsed.NumberOfSideboardCards() != 3 {
+		t.Errorf("Round trip changed card counts: %d main, %d side", parsed.NumberOfCards(), parsed.NumberOfSideboardCards())
+	}
+}
+
+func TestExportCodCommanderRoundTrip(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	atraxa := insertTestCard(t, sb, testAPICard("atraxa", "atraxa-1", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror"))
+	lurrus := insertTestCard(t, sb, testAPICard("lurrus", "lurrus-1", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare"))
+	ring := insertTestCard(t, sb, testAPICard("ring", "ring-1", "Sol Ring", "Artifact"))
+
+	deck := &Decklist{
+		Maindeck:   map[*MagicCard]int{ring: 1},
+		Sideboard:  map[*MagicCard]int{},
+		Commanders: []*MagicCard{atraxa},
+		Companion:  lurrus,
+	}
+
+	var out strings.Builder
+	if err := deck.ExportCod(&out); err != nil {
+		t.Fatalf("ExportCod failed: %v", err)
+	}
+	parsed, err := sb.ParseCockatriceDecklist(out.String())
+	if err != nil {
+		t.Fatalf("Failed to parse exported .cod: %v", err)
+	}
+	if got := describeZone(parsed.Maindeck); got != "1 Sol Ring" {
+		t.Errorf("Round trip changed the maindeck: %s", got)
+	}
+	if got := describeZone(parsed.Sideboard); got != "1 Atraxa, Praetors' Voice, 1 Lurrus of the Dream-Den" {
+		t.Errorf("Expected the commander and companion in the side zone, got %s", got)
+	}
+	if parsed.Hash() != deck.Hash() {
+		t.Errorf("Expected the same hash after the round trip, got %s and %s", parsed.Hash(), deck.Hash())
+	}
+}
+
+func TestDecklistHash(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 20)
+
+	if hash := deck.Hash(); hash != "eok6qedh" {
+		t.Errorf("Expected hash eok6qedh, got %s", hash)
+	}
+
+	deck.AddSideboardCard(pyro, 3)
+	if hash := deck.Hash(); hash != "3u6mihd9" {
+		t.Errorf("Expected hash 3u6mihd9 with sideboard, got %s", hash)
+	}
+
+	// moving a card between zones changes the hash
+	deck.MoveToMaindeck(pyro, 1)
+	if hash := deck.Hash(); hash == "3u6mihd9" {
+		t.Error("Expected hash to change when a card moves to the maindeck")
+	}
+
+	if hash := NewDecklist().Hash(); len(hash) != 8 {
+		t.Errorf("Expected 8 character hash for empty deck, got %q", hash)
+	}
+}
@@ -0,0 +1,157 @@
+package scryball
+
+import "fmt"
+
+// CollectionEntry is a number of owned copies of one printing of a card.
+type CollectionEntry struct {
+	Card     *MagicCard
+	Printing Printing // The owned printing, zero if the printing is unknown
+	Quantity int
+}
+
+// Collection is the cards a player owns, by printing.
+type Collection struct {
+	Entries []CollectionEntry
+}
+
+// NewCollection creates an empty collection.
+func NewCollection() *Collection {
+	return &Collection{}
+}
+
+// Add adds qty copies of a printing of card, adding to the entry of the same
+// printing if there is one. Pass a zero Printing if the printing is unknown.
+func (c *Collection) Add(card *MagicCard, printing Printing, qty int) error {
+	if qty <= 0 {
+		return fmt.Errorf("quantity must be positive, got %d", qty)
+	}
+	for i, entry := range c.Entries {
+		samePrinting := printing.ID != "" && entry.Printing.ID == printing.ID
+		if samePrinting || (printing.ID == "" && entry.Printing.ID == "" && entry.Card.Name == card.Name) {
+			c.Entries[i].Quantity += qty
+			return nil
+		}
+	}
+	c.Entries = append(c.Entries, CollectionEntry{Card: card, Printing: printing, Quantity: qty})
+	return nil
+}
+
+// NumberOfCards returns the number of owned cards, counting every copy.
+func (c *Collection) NumberOfCards() int {
+	total := 0
+	for _, entry := range c.Entries {
+		total += entry.Quantity
+	}
+	return total
+}
+
+// ValueStrategy chooses which printing's price a collection entry is valued at.
+type ValueStrategy string
+
+const (
+	// ValueOwned values entries at the price of the owned printing, or of the
+	// card's most recent printing with a price if the printing is unknown.
+	ValueOwned ValueStrategy = "owned"
+	// ValueLowest values entries at the cheapest printing of the card, like selling to a buylist.
+	ValueLowest ValueStrategy = "lowest"
+	// ValueHighest values entries at the most expensive printing of the card.
+	ValueHighest ValueStrategy = "highest"
+)
+
+// CollectionValue is the value of a collection at cached prices.
+type CollectionValue struct {
+	Currency string
+	Total    Price
+	BySet    map[string]Price // By set code of the printing each entry was valued at
+	ByRarity map[Rarity]Price // By rarity of the printing each entry was valued at
+	Unpriced []CollectionEntry
+
+	// Change is Total minus the value at the prices before they last changed
+	// in the cache. Entries without price history count as unchanged.
+	Change Price
+	// HasHistory reports whether any valued printing has price history, so Change means something.
+	HasHistory bool
+}
+
+// Value totals the collection at cached prices in currency ("usd", "usd_foil",
+// "eur", "tix", ...), choosing the printing each entry is valued at with strategy.
+//
+// Behavior:
+//   - Only uses prices already loaded on the cards, never queries API
+//   - Prices are cached when cards are fetched; refreshing cards records the
+//     prices they replace, which Change compares against
+//   - Entries without a price in currency are left out of the totals and listed in Unpriced
+//
+// Returns:
+//   - CollectionValue: Totals, breakdowns and change since the last price refresh
+//   - error: Unknown strategy
+//
+// Example:
+//
+//	value, _ := collection.Value("usd", scryball.ValueOwned)
+//	fmt.Printf("$%s (%+.2f since last refresh)\n", value.Total, value.Change.Float64())
+func (c *Collection) Value(currency string, strategy ValueStrategy) (CollectionValue, error) {
+	switch strategy {
+	case ValueOwned, ValueLowest, ValueHighest:
+	default:
+		return CollectionValue{}, fmt.Errorf("unknown value strategy %q", strategy)
+	}
+
+	value := CollectionValue{
+		Currency: currency,
+		BySet:    map[string]Price{},
+		ByRarity: map[Rarity]Price{},
+		Unpriced: []CollectionEntry{},
+	}
+	for _, entry := range c.Entries {
+		printing, price, ok := valuedPrinting(entry, currency, strategy)
+		if !ok {
+			value.Unpriced = append(value.Unpriced, entry)
+			continue
+		}
+		subtotal := price * Price(entry.Quantity)
+		value.Total += subtotal
+		value.BySet[printing.SetCode] += subtotal
+		value.ByRarity[printing.Rarity] += subtotal
+
+		if raw, ok := printing.PreviousPrices[currency]; ok {
+			if previous, err := ParsePrice(raw); err == nil {
+				value.Change += (price - previous) * Price(entry.Quantity)
+				value.HasHistory = true
+			}
+		}
+	}
+	return value, nil
+}
+
+// valuedPrinting returns the printing an entry is valued at with strategy and its price.
+// Returns false if no printing considered has a price in currency.
+func valuedPrinting(entry CollectionEntry, currency string, strategy ValueStrategy) (Printing, Price, bool) {
+	if strategy == ValueOwned {
+		if entry.Printing.ID != "" {
+			price, ok := entry.Printing.Price(currency)
+			return entry.Printing, price, ok
+		}
+		// Printings are sorted most recent first
+		for _, printing := range entry.Card.Printings {
+			if price, ok := printing.Price(currency); ok {
+				return printing, price, true
+			}
+		}
+		return Printing{}, 0, false
+	}
+
+	var best Printing
+	var bestPrice Price
+	found := false
+	for _, printing := range entry.Card.Printings {
+		price, ok := printing.Price(currency)
+		if !ok {
+			continue
+		}
+		if !found || (strategy == ValueLowest && price < bestPrice) || (strategy == ValueHighest && price > bestPrice) {
+			best, bestPrice, found = printing, price, true
+		}
+	}
+	return best, bestPrice, found
+}
@@ -0,0 +1,73 @@
+package scryball
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCollectionValue(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	price := func(s string) *string { return &s }
+	bolt := testAPICard("bolt-oracle", "bolt-m10", "Lightning Bolt", "Instant")
+	bolt.Set, bolt.ReleasedAt, bolt.Prices = "m10", "2009-07-17", map[string]*string{"usd": price("2.00")}
+	reprint := testAPICard("bolt-oracle", "bolt-sta", "Lightning Bolt", "Instant")
+	reprint.Set, reprint.Rarity, reprint.ReleasedAt = "sta", "uncommon", "2021-04-23"
+	reprint.Prices = map[string]*string{"usd": price("1.00")}
+	insertTestCard(t, sb, bolt, reprint)
+
+	// Refreshing the printing records the price it replaces
+	bolt.Prices = map[string]*string{"usd": price("2.50")}
+	card := insertTestCard(t, sb, bolt)
+	unpriced := insertTestCard(t, sb, testAPICard("ooze-oracle", "ooze-1", "Ooze", "Creature"))
+
+	collection := NewCollection()
+	for _, printing := range card.Printings {
+		collection.Add(card, printing, 2)
+	}
+	collection.Add(card, card.Printings[0], 1)
+	collection.Add(unpriced, Printing{}, 1)
+	if collection.NumberOfCards() != 6 {
+		t.Errorf("Expected 6 cards, got %d", collection.NumberOfCards())
+	}
+
+	value, err := collection.Value("usd", ValueOwned)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// 3 × 1.00 (sta) + 2 × 2.50 (m10)
+	if value.Total != 800 {
+		t.Errorf("Expected a total of 8.00, got %s", value.Total)
+	}
+	if value.BySet["m10"] != 500 || value.BySet["sta"] != 300 {
+		t.Errorf("Expected 5.00 for m10 and 3.00 for sta, got %v", value.BySet)
+	}
+	if value.ByRarity[RarityCommon] != 500 || value.ByRarity[RarityUncommon] != 300 {
+		t.Errorf("Expected 5.00 of commons and 3.00 of uncommons, got %v", value.ByRarity)
+	}
+	if !value.HasHistory || value.Change != 100 {
+		t.Errorf("Expected a change of 1.00 since the last refresh, got %s (history %v)", value.Change, value.HasHistory)
+	}
+	if len(value.Unpriced) != 1 || value.Unpriced[0].Card.Name != "Ooze" {
+		t.Errorf("Expected Ooze to be unpriced, got %v", value.Unpriced)
+	}
+
+	if lowest, _ := collection.Value("usd", ValueLowest); lowest.Total != 500 {
+		t.Errorf("Expected 5 copies at 1.00 with the lowest strategy, got %s", lowest.Total)
+	}
+	if highest, _ := collection.Value("usd", ValueHighest); highest.Total != 1250 {
+		t.Errorf("Expected 5 copies at 2.50 with the highest strategy, got %s", highest.Total)
+	}
+	if _, err := collection.Value("usd", "average"); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+
+	if _, err := sb.db.ExecContext(context.Background(), "DELETE FROM price_history"); err != nil {
+		t.Fatal(err)
+	}
+	fresh, _ := sb.FetchCardByExactOracleID(context.Background(), "bolt-oracle")
+	if fresh.Printings[1].PreviousPrices != nil {
+		t.Errorf("Expected no previous prices without history, got %v", fresh.Printings[1].PreviousPrices)
+	}
+}
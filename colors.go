@@ -0,0 +1,146 @@
+package scryball
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Color is one of the five colors of Magic, or colorless, as Scryfall abbreviates them.
+type Color string
+
+const (
+	ColorWhite     Color = "W"
+	ColorBlue      Color = "U"
+	ColorBlack     Color = "B"
+	ColorRed       Color = "R"
+	ColorGreen     Color = "G"
+	ColorColorless Color = "C"
+)
+
+// colorOrder is WUBRG, the order colors and color identities are reported in.
+var colorOrder = []Color{ColorWhite, ColorBlue, ColorBlack, ColorRed, ColorGreen, ColorColorless}
+
+// colorNames are the full names ParseColor accepts.
+var colorNames = map[string]Color{
+	"white":     ColorWhite,
+	"blue":      ColorBlue,
+	"black":     ColorBlack,
+	"red":       ColorRed,
+	"green":     ColorGreen,
+	"colorless": ColorColorless,
+}
+
+// ParseColor parses a color abbreviation ("W", "u") or name ("White", "blue").
+func ParseColor(s string) (Color, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if color, ok := colorNames[s]; ok {
+		return color, nil
+	}
+	color := Color(strings.ToUpper(s))
+	if slices.Contains(colorOrder, color) {
+		return color, nil
+	}
+	return "", fmt.Errorf("unknown color %q", s)
+}
+
+// Colors is a set of colors, like a card's colors or color identity.
+type Colors []Color
+
+// ParseColors parses a string of color abbreviations like "WUG" or "{W}{U}".
+// Returns the colors in WUBRG order, each once.
+//
+// Example:
+//
+//	colors, _ := scryball.ParseColors("gw") // Colors{ColorWhite, ColorGreen}
+func ParseColors(s string) (Colors, error) {
+	var colors Colors
+	for _, r := range s {
+		switch r {
+		case '{', '}', ' ':
+			continue
+		}
+		color, err := ParseColor(string(r))
+		if err != nil {
+			return nil, fmt.Errorf("invalid colors %q: %v", s, err)
+		}
+		colors = append(colors, color)
+	}
+	return colors.Sorted(), nil
+}
+
+// toColors converts the color strings of a Scryfall card to Colors in WUBRG order.
+// Unknown strings are kept, after the known colors.
+func toColors(strs []string) Colors {
+	colors := make(Colors, len(strs))
+	for i, s := range strs {
+		colors[i] = Color(s)
+	}
+	return colors.Sorted()
+}
+
+// Sorted returns a copy of the colors in WUBRG order, colorless last, each once.
+func (c Colors) Sorted() Colors {
+	sorted := slices.Clone(c)
+	slices.SortFunc(sorted, func(a, b Color) int {
+		i, j := slices.Index(colorOrder, a), slices.Index(colorOrder, b)
+		if i == -1 {
+			i = len(colorOrder)
+		}
+		if j == -1 {
+			j = len(colorOrder)
+		}
+		if i != j {
+			return i - j
+		}
+		return strings.Compare(string(a), string(b))
+	})
+	return slices.Compact(sorted)
+}
+
+// Contains reports whether color is one of the colors.
+func (c Colors) Contains(color Color) bool {
+	return slices.Contains(c, color)
+}
+
+// ContainsAll reports whether every one of colors is in c. It is how color
+// identity is checked: a card fits a commander when the commander's identity
+// contains all of the card's identity.
+func (c Colors) ContainsAll(colors Colors) bool {
+	for _, color := range colors {
+		if !c.Contains(color) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny reports whether any one of colors is in c.
+func (c Colors) ContainsAny(colors Colors) bool {
+	return slices.ContainsFunc(colors, c.Contains)
+}
+
+// String returns the colors as abbreviations in WUBRG order: "WUG".
+func (c Colors) String() string {
+	var b strings.Builder
+	for _, color := range c.Sorted() {
+		b.WriteString(string(color))
+	}
+	return b.String()
+}
+
+// ColorSet returns the card's colors as Colors in WUBRG order, empty for colorless cards.
+func (card *MagicCard) ColorSet() Colors {
+	if card.Card == nil {
+		return Colors{}
+	}
+	return toColors(card.Colors)
+}
+
+// ColorIdentitySet returns the card's color identity as Colors in WUBRG order.
+func (card *MagicCard) ColorIdentitySet() Colors {
+	if card.Card == nil {
+		return Colors{}
+	}
+	return toColors(card.ColorIdentity)
+}
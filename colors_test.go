@@ -0,0 +1,45 @@
+package scryball
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseColors(t *testing.T) {
+	colors, err := ParseColors("{G}rWg")
+	if err != nil {
+		t.Fatalf("ParseColors failed: %v", err)
+	}
+	if expected := (Colors{ColorWhite, ColorRed, ColorGreen}); !reflect.DeepEqual(colors, expected) {
+		t.Errorf("Expected %v, got %v", expected, colors)
+	}
+	if colors.String() != "WRG" {
+		t.Errorf("Expected WRG, got %s", colors)
+	}
+
+	if color, err := ParseColor("Blue"); err != nil || color != ColorBlue {
+		t.Errorf("Expected blue to parse as U, got %q, %v", color, err)
+	}
+	if _, err := ParseColors("WX"); err == nil {
+		t.Error("Expected an error for an unknown color")
+	}
+}
+
+func TestColorsContain(t *testing.T) {
+	esper := Colors{ColorWhite, ColorBlue, ColorBlack}
+	if !esper.ContainsAll(Colors{ColorBlack, ColorWhite}) || !esper.ContainsAll(Colors{}) {
+		t.Error("Expected Esper to contain white-black and colorless")
+	}
+	if esper.ContainsAll(Colors{ColorWhite, ColorRed}) {
+		t.Error("Expected Esper not to contain red")
+	}
+	if !esper.ContainsAny(Colors{ColorRed, ColorBlue}) || esper.ContainsAny(Colors{ColorRed, ColorGreen}) {
+		t.Error("Expected ContainsAny to match any one color")
+	}
+
+	card := testCard("helix", "Lightning Helix", "Instant", withManaCost("{R}{W}"), withCMC(2), withColors("R", "W"))
+	card.ColorIdentity = []string{"R", "W"}
+	if card.ColorSet().String() != "WR" || card.ColorIdentitySet().String() != "WR" {
+		t.Errorf("Expected WR, got %s and %s", card.ColorSet(), card.ColorIdentitySet())
+	}
+}
@@ -0,0 +1,224 @@
+package scryball
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CommanderColorIdentity returns the combined color identity of the deck's commanders in WUBRG order.
+//
+// Every other card in a Commander deck must be within this identity.
+func (d *Decklist) CommanderColorIdentity() Colors {
+	identity := Colors{}
+	for _, commander := range d.Commanders {
+		identity = append(identity, commander.ColorIdentitySet()...)
+	}
+	return identity.Sorted()
+}
+
+// FitsColorIdentity reports whether every color in the card's color identity is
+// in identity, so the card can be played in a Commander deck of that identity.
+// Colorless cards fit every identity.
+//
+// Example:
+//
+//	card.FitsColorIdentity(deck.CommanderColorIdentity())
+//	card.FitsColorIdentity(scryball.Colors{scryball.ColorBlue, scryball.ColorRed})
+func (card *MagicCard) FitsColorIdentity(identity Colors) bool {
+	return identity.ContainsAll(card.ColorIdentitySet())
+}
+
+// WithinIdentity returns the cards that fit the combined color identity of
+// the commanders, in their original order. Pass both partners to filter for a pair.
+//
+// Example:
+//
+//	candidates, _ := scryball.Query("otag:ramp f:commander")
+//	for _, card := range scryball.WithinIdentity(candidates, commander) {
+//	    fmt.Println(card.Name)
+//	}
+func WithinIdentity(cards []*MagicCard, commanders ...*MagicCard) []*MagicCard {
+	identity := Colors{}
+	for _, commander := range commanders {
+		identity = append(identity, commander.ColorIdentitySet()...)
+	}
+
+	within := []*MagicCard{}
+	for _, card := range cards {
+		if card.FitsColorIdentity(identity) {
+			within = append(within, card)
+		}
+	}
+	return within
+}
+
+// ValidateCommander validates the deck for Commander, returns nil if legal.
+//
+// Behavior:
+//   - One commander, or two that can be paired: both with Partner, Partner with each
+//     other, Friends forever, Choose a Background with a Background, or Doctor's
+//     companion with a Time Lord Doctor
+//   - Every commander is a legendary creature or says it can be your commander,
+//     see CanBeCommander. A Background can be the second commander
+//   - Exactly 100 cards including commanders, at most one copy of each card
+//     (except basic lands and special cards ie. Relentless Rats)
+//   - Every card within the commanders' combined color identity
+//   - No sideboard other than the companion
+//   - Card legality is not checked, see LegalFormats()
+func (d *Decklist) ValidateCommander() error {
+	return CommanderRules.Validate(d)
+}
+
+// CommanderRules are the rules of ValidateCommander.
+var CommanderRules = ValidationRules{CommanderRule(), DeckSizeRule(100, 100), CopyLimitRule(1)}
+
+// CanBeCommander reports whether the card can be a Commander deck's commander:
+// its front face is a legendary creature, or its rules text says it
+// "can be your commander" (Grist, the Hunger Tide and other planeswalkers).
+//
+// Backgrounds are only commanders alongside a Choose a Background creature,
+// so they are not reported.
+//
+// Example:
+//
+//	candidates, _ := scryball.Query("is:commander id:esper")
+//	card.CanBeCommander() // true for every candidate
+func (card *MagicCard) CanBeCommander() bool {
+	if card.Card == nil {
+		return false
+	}
+	if isLegendaryCreature(derefString(card.Front().TypeLine)) {
+		return true
+	}
+	return oracleTextContains(card, "can be your commander")
+}
+
+// isLegendaryCreature reports whether a type line is a legendary creature's,
+// "Legendary Artifact Creature — Golem" included.
+func isLegendaryCreature(typeLine string) bool {
+	types, _, _ := strings.Cut(typeLine, " — ")
+	fields := strings.Fields(types)
+	return slices.Contains(fields, "Legendary") && slices.Contains(fields, "Creature")
+}
+
+// canBeBrawlCommander reports whether the card can be a Brawl or Oathbreaker
+// deck's commander, which also allows any legendary planeswalker.
+func canBeBrawlCommander(card *MagicCard) bool {
+	if card.CanBeCommander() {
+		return true
+	}
+	types, _, _ := strings.Cut(derefString(card.Front().TypeLine), " — ")
+	fields := strings.Fields(types)
+	return slices.Contains(fields, "Legendary") && slices.Contains(fields, "Planeswalker")
+}
+
+// validateCommanders checks there are one or two commanders that canLead
+// accepts, and that two can be paired.
+func (d *Decklist) validateCommanders(canLead func(card *MagicCard) bool) error {
+	for _, commander := range d.Commanders {
+		// a Background leads alongside its Choose a Background creature, checked by the pairing
+		if !canLead(commander) && !(len(d.Commanders) == 2 && isBackground(commander)) {
+			return fmt.Errorf("%s cannot be your commander", commander.Name)
+		}
+	}
+
+	switch len(d.Commanders) {
+	case 0:
+		return fmt.Errorf("deck has no commander")
+	case 1:
+		return nil
+	case 2:
+		if !canPairCommanders(d.Commanders[0], d.Commanders[1]) {
+			return fmt.Errorf("%s and %s cannot be commanders together", d.Commanders[0].Name, d.Commanders[1].Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("deck has %d commanders, maximum is 2", len(d.Commanders))
+	}
+}
+
+// colorIdentityViolations returns a violation for every maindeck card outside the commanders' color identity.
+func (d *Decklist) colorIdentityViolations() violations {
+	var found violations
+	identity := d.CommanderColorIdentity()
+	for _, card := range sortedCards(d.Maindeck) {
+		if !card.FitsColorIdentity(identity) {
+			found.add(Violation{Rule: RuleColorIdentity, Card: card}, "%s is outside the commander color identity {%s}", card.Name, identity)
+		}
+	}
+	return found
+}
+
+// canPairCommanders reports whether a and b can be commanders of the same deck.
+func canPairCommanders(a, b *MagicCard) bool {
+	if hasAbility(a, "Partner") && hasAbility(b, "Partner") {
+		return true
+	}
+	if partnerWith(a) == b.Name && partnerWith(b) == a.Name {
+		return true
+	}
+	if hasAbility(a, "Friends forever") && hasAbility(b, "Friends forever") {
+		return true
+	}
+	if (hasAbility(a, "Choose a Background") && isBackground(b)) || (hasAbility(b, "Choose a Background") && isBackground(a)) {
+		return true
+	}
+	if (hasAbility(a, "Doctor's companion") && isTimeLordDoctor(b)) || (hasAbility(b, "Doctor's companion") && isTimeLordDoctor(a)) {
+		return true
+	}
+	return false
+}
+
+// oracleLines returns the lines of rules text on every face of the card.
+func oracleLines(card *MagicCard) []string {
+	var text []string
+	if card.OracleText != nil {
+		text = append(text, *card.OracleText)
+	}
+	for _, face := range card.CardFaces {
+		if face.OracleText != nil {
+			text = append(text, *face.OracleText)
+		}
+	}
+	return strings.Split(strings.Join(text, "\n"), "\n")
+}
+
+// hasAbility reports whether a line of the card's rules text is the keyword
+// ability, alone or followed by reminder text: "Partner (You can have two ...)".
+func hasAbility(card *MagicCard, ability string) bool {
+	for _, line := range oracleLines(card) {
+		line = strings.TrimSpace(line)
+		if strings.EqualFold(line, ability) || strings.HasPrefix(strings.ToLower(line), strings.ToLower(ability)+" (") {
+			return true
+		}
+	}
+	return false
+}
+
+// partnerWith returns the card named by a "Partner with" ability, empty if there is none.
+func partnerWith(card *MagicCard) string {
+	const prefix = "Partner with "
+	for _, line := range oracleLines(card) {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(line, prefix)
+		if i := strings.Index(name, " ("); i != -1 {
+			name = name[:i]
+		}
+		return strings.TrimSpace(name)
+	}
+	return ""
+}
+
+func isBackground(card *MagicCard) bool {
+	return strings.Contains(card.TypeLine, "Legendary Enchantment") && strings.Contains(card.TypeLine, "Background")
+}
+
+func isTimeLordDoctor(card *MagicCard) bool {
+	_, subtypes, _ := strings.Cut(card.TypeLine, " — ")
+	fields := strings.Fields(subtypes)
+	return slices.Contains(fields, "Time") && slices.Contains(fields, "Lord") && slices.Contains(fields, "Doctor")
+}
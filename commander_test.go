@@ -0,0 +1,173 @@
+package scryball
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestCanPairCommanders(t *testing.T) {
+	thrasios := testCard("thrasios", "Thrasios, Triton Hero", "Legendary Creature — Merfolk Wizard", withOracleText("{4}: Scry 1, then reveal the top card of your library.\nPartner (You can have two commanders if both have partner.)"), withColorIdentity("G", "U"))
+	tymna := testCard("tymna", "Tymna the Weaver", "Legendary Creature — Human Cleric", withOracleText("Lifelink\nPartner (You can have two commanders if both have partner.)"), withColorIdentity("W", "B"))
+	pir := testCard("pir", "Pir, Imaginative Rascal", "Legendary Creature — Human", withOracleText("Partner with Toothy, Imaginary Friend (When this creature enters, target player may put Toothy into their hand from their library, then shuffle.)"), withColorIdentity("G"))
+	toothy := testCard("toothy", "Toothy, Imaginary Friend", "Legendary Creature — Illusion", withOracleText("Partner with Pir, Imaginative Rascal (When this creature enters, target player may put Pir into their hand from their library, then shuffle.)"), withColorIdentity("U"))
+	wilson := testCard("wilson", "Wilson, Refined Grizzly", "Legendary Creature — Bear Warrior", withOracleText("Choose a Background (You can have a Background as a second commander.)\nReach, trample, ward {2}"), withColorIdentity("G"))
+	cult := testCard("cult", "Cult of Asmodeus", "Legendary Enchantment — Background", withOracleText("Commander creatures you own have \"Protection from white.\""), withColorIdentity("B"))
+	will := testCard("will", "Will the Wise", "Legendary Creature — Human Wizard", withOracleText("Friends forever (You can have two commanders if both have friends forever.)"), withColorIdentity("U"))
+	eleven := testCard("eleven", "Eleven, the Mage", "Legendary Creature — Human Wizard", withOracleText("Friends forever (You can have two commanders if both have friends forever.)"), withColorIdentity("U"))
+	doctor := testCard("doctor", "The Tenth Doctor", "Legendary Creature — Time Lord Doctor", withOracleText("Allons-y!"), withColorIdentity("U", "R", "G"))
+	rose := testCard("rose", "Rose Tyler", "Legendary Creature — Human", withOracleText("Doctor's companion (You can have two commanders if the other is the Doctor.)"), withColorIdentity("W"))
+	atraxa := testCard("atraxa", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror", withOracleText("Flying, vigilance, deathtouch, lifelink"), withColorIdentity("W", "U", "B", "G"))
+
+	tests := []struct {
+		a, b     *MagicCard
+		expected bool
+	}{
+		{thrasios, tymna, true},
+		{pir, toothy, true},
+		{pir, thrasios, false},
+		{wilson, cult, true},
+		{cult, wilson, true},
+		{thrasios, cult, false},
+		{will, eleven, true},
+		{will, thrasios, false},
+		{doctor, rose, true},
+		{atraxa, thrasios, false},
+	}
+
+	for _, tt := range tests {
+		if got := canPairCommanders(tt.a, tt.b); got != tt.expected {
+			t.Errorf("canPairCommanders(%s, %s) = %v, expected %v", tt.a.Name, tt.b.Name, got, tt.expected)
+		}
+	}
+}
+
+func TestDecklistValidateCommander(t *testing.T) {
+	thrasios := testCard("thrasios", "Thrasios, Triton Hero", "Legendary Creature — Merfolk Wizard", withOracleText("Partner (You can have two commanders if both have partner.)"), withColorIdentity("G", "U"))
+	tymna := testCard("tymna", "Tymna the Weaver", "Legendary Creature — Human Cleric", withOracleText("Lifelink\nPartner (You can have two commanders if both have partner.)"), withColorIdentity("W", "B"))
+	atraxa := testCard("atraxa", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror", withOracleText("Flying"), withColorIdentity("W", "U", "B", "G"))
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withOracleText("Lightning Bolt deals 3 damage to any target."), withColorIdentity("R"))
+	swords := testCard("swords", "Swords to Plowshares", "Instant", withOracleText("Exile target creature."), withColorIdentity("W"))
+	forest := testCard("forest", "Forest", "Basic Land — Forest", withOracleText("({T}: Add {G}.)"))
+
+	deck := NewDecklist()
+	deck.Commanders = []*MagicCard{thrasios, tymna}
+	deck.AddCard(swords, 1)
+	deck.AddCard(forest, 97)
+
+	if err := deck.ValidateCommander(); err != nil {
+		t.Errorf("Expected partner deck to be valid, got %v", err)
+	}
+	if got := deck.CommanderColorIdentity(); !reflect.DeepEqual(got, Colors{ColorWhite, ColorBlue, ColorBlack, ColorGreen}) {
+		t.Errorf("Expected combined identity WUBG, got %v", got)
+	}
+
+	deck.SetQuantity(swords, 0)
+	deck.AddCard(bolt, 1)
+	if err := deck.ValidateCommander(); err == nil || !strings.Contains(err.Error(), "Lightning Bolt") {
+		t.Errorf("Expected Lightning Bolt outside color identity, got %v", err)
+	}
+
+	deck.SetQuantity(bolt, 0)
+	deck.AddCard(swords, 1)
+	deck.Commanders = []*MagicCard{atraxa, thrasios}
+	if err := deck.ValidateCommander(); err == nil {
+		t.Error("Expected Atraxa and Thrasios to be rejected as a pair")
+	}
+
+	deck.Commanders = []*MagicCard{atraxa}
+	if err := deck.ValidateCommander(); err == nil {
+		t.Error("Expected 99 card deck to be rejected")
+	}
+	deck.AddCard(forest, 1)
+	if err := deck.ValidateCommander(); err != nil {
+		t.Errorf("Expected single commander deck to be valid, got %v", err)
+	}
+}
+
+func TestWithinIdentity(t *testing.T) {
+	identityCard := func(name string, identity ...string) *MagicCard {
+		card := testAPICard(name, name+"-1", name, "Creature")
+		card.ColorIdentity = identity
+		return &MagicCard{Card: card}
+	}
+	thrasios := identityCard("Thrasios, Triton Hero", "G", "U")
+	tymna := identityCard("Tymna the Weaver", "W", "B")
+
+	sol := identityCard("Sol Ring")
+	growth := identityCard("Sylvan Library", "G")
+	helix := identityCard("Lightning Helix", "R", "W")
+	vindicate := identityCard("Vindicate", "W", "B")
+
+	if !growth.FitsColorIdentity(Colors{ColorGreen, ColorBlue}) || helix.FitsColorIdentity(Colors{ColorWhite}) {
+		t.Error("Expected FitsColorIdentity to check every color of the card")
+	}
+	if !sol.FitsColorIdentity(Colors{}) {
+		t.Error("Expected a colorless card to fit a colorless identity")
+	}
+
+	cards := []*MagicCard{sol, growth, helix, vindicate}
+	var names []string
+	for _, card := range WithinIdentity(cards, thrasios, tymna) {
+		names = append(names, card.Name)
+	}
+	if expected := []string{"Sol Ring", "Sylvan Library", "Vindicate"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+	if got := WithinIdentity(cards, thrasios); len(got) != 2 {
+		t.Errorf("Expected Sol Ring and Sylvan Library for Thrasios alone, got %d cards", len(got))
+	}
+}
+
+func TestCanBeCommander(t *testing.T) {
+	atraxa := testCard("atraxa", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror", withOracleText("Flying"), withColorIdentity("W", "U", "B", "G"))
+	golos := testCard("golos", "Golos, Tireless Pilgrim", "Legendary Artifact Creature — Scout", withOracleText("When Golos enters, you may search your library for a land card."))
+	grist := testCard("grist", "Grist, the Hunger Tide", "Legendary Planeswalker — Grist", withOracleText("As long as Grist isn't on the battlefield, it's a 1/1 Insect creature in addition to its other types.\nGrist, the Hunger Tide can be your commander."), withColorIdentity("B", "G"))
+	teferi := testCard("teferi", "Teferi, Hero of Dominaria", "Legendary Planeswalker — Teferi", withOracleText("+1: Draw a card."), withColorIdentity("W", "U"))
+	cult := testCard("cult", "Cult of Asmodeus", "Legendary Enchantment — Background", withOracleText("Commander creatures you own have \"Protection from white.\""), withColorIdentity("B"))
+	goyf := testCard("goyf", "Tarmogoyf", "Creature — Lhurgoyf", withOracleText(""), withColorIdentity("G"))
+
+	delver := testCard("delver", "Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect", withOracleText(""), withColorIdentity("U"))
+	front, back := "Creature — Human Wizard", "Legendary Creature — Human Insect"
+	delver.CardFaces = []client.CardFace{{Name: "Delver of Secrets", TypeLine: &front}, {Name: "Insectile Aberration", TypeLine: &back}}
+
+	for _, tt := range []struct {
+		card     *MagicCard
+		expected bool
+	}{
+		{atraxa, true},
+		{golos, true},
+		{grist, true},
+		{teferi, false},
+		{cult, false},
+		{goyf, false},
+		{delver, false},
+	} {
+		if got := tt.card.CanBeCommander(); got != tt.expected {
+			t.Errorf("CanBeCommander(%s) = %v, expected %v", tt.card.Name, got, tt.expected)
+		}
+	}
+
+	forest := testCard("forest", "Forest", "Basic Land — Forest", withOracleText("({T}: Add {G}.)"))
+	deck := NewDecklist()
+	deck.Commanders = []*MagicCard{goyf}
+	deck.AddCard(forest, 99)
+	if err := deck.ValidateCommander(); err == nil || !strings.Contains(err.Error(), "Tarmogoyf cannot be your commander") {
+		t.Errorf("Expected a non-legendary commander to be rejected, got %v", err)
+	}
+
+	deck.Commanders = []*MagicCard{grist}
+	if err := deck.ValidateCommander(); err != nil {
+		t.Errorf("Expected Grist to be a valid commander, got %v", err)
+	}
+
+	deck.Commanders = []*MagicCard{teferi}
+	if err := deck.ValidateCommander(); err == nil {
+		t.Error("Expected a planeswalker commander to be rejected in Commander")
+	}
+	if err := deck.Validate(brawlFormat("brawl", 100)...); err != nil {
+		t.Errorf("Expected a planeswalker commander to be valid in Brawl, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package scryball
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// anyNumberText matches the oracle text letting decks ignore the copy limit for a card:
+// "A deck can have any number of cards named Relentless Rats." or
+// "A deck can have up to seven cards named Seven Dwarves."
+var anyNumberText = regexp.MustCompile(`(?i)(?:a deck can|you may) have (any number of|up to (\w+)) cards named`)
+
+// numberWords are the counts oracle text spells out.
+var numberWords = map[string]int{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7,
+	"eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+var (
+	anyNumberMu    sync.RWMutex
+	anyNumberCards []string // set by SetAnyNumberCards
+)
+
+// SetAnyNumberCards names cards decks may include any number of copies of, on top
+// of the cards whose oracle text says so ("A deck can have any number of cards
+// named ..."). For new cards not cached yet, or house rules. Names ignore case.
+//
+// Decklists are validated without a Scryball instance, so the list applies to
+// every validation in the program. Each call replaces the previous list; call it
+// without names, or ResetToDefaults, to clear it.
+//
+// Example:
+//
+//	scryball.SetAnyNumberCards("Hare Apparent")
+func SetAnyNumberCards(names ...string) {
+	anyNumberMu.Lock()
+	defer anyNumberMu.Unlock()
+	anyNumberCards = slices.Clone(names)
+}
+
+// copyLimitException returns how many copies of card a deck can have when its
+// oracle text or the configuration overrides the format's copy limit.
+//
+// Returns 0, true for any number of copies, and false if the format's limit applies.
+func copyLimitException(card *MagicCard) (int, bool) {
+	anyNumberMu.RLock()
+	configured := slices.ContainsFunc(anyNumberCards, func(name string) bool {
+		return strings.EqualFold(name, card.Name)
+	})
+	anyNumberMu.RUnlock()
+	if configured {
+		return 0, true
+	}
+
+	if card.OracleText == nil {
+		// Without oracle text, like cards built from a name only
+		return specialCardLimit(card.Name)
+	}
+	match := anyNumberText.FindStringSubmatch(*card.OracleText)
+	if match == nil {
+		return 0, false
+	}
+	if match[2] == "" {
+		return 0, true
+	}
+	if n, ok := numberWords[strings.ToLower(match[2])]; ok {
+		return n, true
+	}
+	if n, err := strconv.Atoi(match[2]); err == nil {
+		return n, true
+	}
+	return 0, true
+}
+
+// exceedsCopyLimit reports whether qty copies of card break a format's copy limit of max,
+// and the limit that applies to card. Basic lands have no limit.
+func exceedsCopyLimit(card *MagicCard, qty, max int) (bool, int) {
+	if isBasicLand(card) {
+		return false, 0
+	}
+	if limit, ok := copyLimitException(card); ok {
+		return limit > 0 && qty > limit, limit
+	}
+	return qty > max, max
+}
+
+// specialCards are the cards decks can have more copies of than formats allow,
+// with their limit (0 for any number), for cards without oracle text to read it from.
+var specialCards = map[string]int{
+	"Relentless Rats":        0,
+	"Shadowborn Apostle":     0,
+	"Rat Colony":             0,
+	"Persistent Petitioners": 0,
+	"Dragon's Approach":      0,
+	"Slime Against Humanity": 0,
+	"Templar Knight":         0,
+	"Hare Apparent":          0,
+	"Seven Dwarves":          7,
+	"Nazgûl":                 9,
+}
+
+// specialCardLimit looks name up in specialCards, ignoring case.
+func specialCardLimit(name string) (int, bool) {
+	for special, limit := range specialCards {
+		if strings.EqualFold(name, special) {
+			return limit, true
+		}
+	}
+	return 0, false
+}
+
+// isSpecialCardName reports whether name is one of specialCards.
+func isSpecialCardName(name string) bool {
+	_, ok := specialCardLimit(name)
+	return ok
+}
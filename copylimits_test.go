@@ -0,0 +1,72 @@
+package scryball
+
+import "testing"
+
+func TestCopyLimitFromOracleText(t *testing.T) {
+	card := func(name, oracleText string) *MagicCard {
+		card := &MagicCard{Card: testAPICard(name+"-oracle", name+"-1", name, "Creature")}
+		card.OracleText = &oracleText
+		return card
+	}
+	swarm := card("Future Swarm", "Flying\nA deck can have any number of cards named Future Swarm.")
+	dwarves := card("Seven Dwarves", "Seven Dwarves gets +1/+1 for each other creature named Seven Dwarves you control.\nA deck can have up to seven cards named Seven Dwarves.")
+	bears := card("Grizzly Bears", "")
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+
+	deck := NewDecklist()
+	deck.AddCard(swarm, 30)
+	deck.AddCard(dwarves, 7)
+	deck.AddCard(mountain, 23)
+	if err := deck.ValidateConstructed(); err != nil {
+		t.Errorf("Expected 30 Future Swarm and 7 Seven Dwarves to be legal, got %v", err)
+	}
+
+	deck.AddSideboardCard(dwarves, 1)
+	deck.AddCard(bears, 5)
+	err := deck.ValidateConstructed()
+	if err == nil {
+		t.Fatal("Expected 8 Seven Dwarves and 5 Grizzly Bears to be illegal")
+	}
+	expected := "2 violations: total of 5 copies of Grizzly Bears between maindeck and sideboard, maximum is 4; " +
+		"total of 8 copies of Seven Dwarves between maindeck and sideboard, maximum is 7"
+	if err.Error() != expected {
+		t.Errorf("Expected %q, got %q", expected, err.Error())
+	}
+
+	// Cards without oracle text fall back to the names known to have the exception
+	rats := &MagicCard{Card: testAPICard("rats-oracle", "rats-1", "Relentless Rats", "Creature — Rat")}
+	if limit, ok := copyLimitException(rats); !ok || limit != 0 {
+		t.Errorf("Expected Relentless Rats to allow any number, got %d, %v", limit, ok)
+	}
+}
+
+func TestSetAnyNumberCards(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	deck := NewDecklist()
+	deck.AddCard(bolt, 60)
+
+	if err := deck.ValidateConstructed(); err == nil {
+		t.Fatal("Expected 60 Lightning Bolt to be illegal without configuration")
+	}
+
+	SetAnyNumberCards("lightning bolt")
+	defer SetAnyNumberCards()
+	if err := deck.ValidateConstructed(); err != nil {
+		t.Errorf("Expected configured any-number card to be legal, got %v", err)
+	}
+
+	// a new global instance leaves the list alone
+	if err := SetConfig(ScryballConfig{}); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := deck.ValidateConstructed(); err != nil {
+		t.Errorf("Expected SetConfig to keep the any-number cards, got %v", err)
+	}
+
+	if err := ResetToDefaults(); err != nil {
+		t.Fatalf("ResetToDefaults failed: %v", err)
+	}
+	if err := deck.ValidateConstructed(); err == nil {
+		t.Error("Expected ResetToDefaults to clear the any-number cards")
+	}
+}
@@ -0,0 +1,173 @@
+package scryball
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVColumn is a column ExportCSV can write. Its value is the header of the column.
+type CSVColumn string
+
+const (
+	CSVName            CSVColumn = "name"
+	CSVOracleID        CSVColumn = "oracle_id"
+	CSVManaCost        CSVColumn = "mana_cost"
+	CSVManaValue       CSVColumn = "mana_value"
+	CSVType            CSVColumn = "type_line"
+	CSVColors          CSVColumn = "colors"
+	CSVColorIdentity   CSVColumn = "color_identity"
+	CSVOracleText      CSVColumn = "oracle_text"
+	CSVPower           CSVColumn = "power"
+	CSVToughness       CSVColumn = "toughness"
+	CSVSet             CSVColumn = "set"
+	CSVSetName         CSVColumn = "set_name"
+	CSVCollectorNumber CSVColumn = "collector_number"
+	CSVRarity          CSVColumn = "rarity"
+	CSVPriceUSD        CSVColumn = "usd"
+	CSVPriceUSDFoil    CSVColumn = "usd_foil"
+	CSVPriceEUR        CSVColumn = "eur"
+	CSVPriceTix        CSVColumn = "tix"
+)
+
+// DefaultCSVColumns are the columns ExportCSV writes when none are given.
+var DefaultCSVColumns = []CSVColumn{CSVName, CSVManaCost, CSVType, CSVSet, CSVRarity, CSVPriceUSD}
+
+// csvValues reads each column's value from a card.
+var csvValues = map[CSVColumn]func(card *MagicCard) string{
+	CSVName: func(card *MagicCard) string { return card.Name },
+	CSVOracleID: func(card *MagicCard) string {
+		return derefString(card.OracleID)
+	},
+	CSVManaCost: func(card *MagicCard) string { return cardManaCost(card) },
+	CSVManaValue: func(card *MagicCard) string {
+		return strconv.FormatFloat(card.CMC, 'f', -1, 64)
+	},
+	CSVType:          func(card *MagicCard) string { return card.TypeLine },
+	CSVColors:        func(card *MagicCard) string { return strings.Join(card.Colors, "") },
+	CSVColorIdentity: func(card *MagicCard) string { return strings.Join(card.ColorIdentity, "") },
+	CSVOracleText:    func(card *MagicCard) string { return derefString(card.OracleText) },
+	CSVPower:         func(card *MagicCard) string { return derefString(card.Power) },
+	CSVToughness:     func(card *MagicCard) string { return derefString(card.Toughness) },
+	CSVSet: func(card *MagicCard) string {
+		if card.Set != "" {
+			return card.Set
+		}
+		return csvPrinting(card).SetCode
+	},
+	CSVSetName: func(card *MagicCard) string {
+		if card.SetName != "" {
+			return card.SetName
+		}
+		return csvPrinting(card).SetName
+	},
+	CSVCollectorNumber: func(card *MagicCard) string {
+		if card.CollectorNumber != "" {
+			return card.CollectorNumber
+		}
+		return csvPrinting(card).CollectorNumber
+	},
+	CSVRarity: func(card *MagicCard) string {
+		if card.Rarity != "" {
+			return card.Rarity
+		}
+		return string(csvPrinting(card).Rarity)
+	},
+	CSVPriceUSD:     csvPrice("usd"),
+	CSVPriceUSDFoil: csvPrice("usd_foil"),
+	CSVPriceEUR:     csvPrice("eur"),
+	CSVPriceTix:     csvPrice("tix"),
+}
+
+// csvPrinting returns the card's most recent cached printing, used when the
+// card was built from the database without printing-level fields.
+func csvPrinting(card *MagicCard) Printing {
+	if len(card.Printings) == 0 {
+		return Printing{}
+	}
+	return card.Printings[0]
+}
+
+// csvPrice reads a price from the card, falling back to its most recent printing with that price.
+func csvPrice(currency string) func(card *MagicCard) string {
+	return func(card *MagicCard) string {
+		return cardPrice(card, currency)
+	}
+}
+
+// cardPrice returns the card's raw price in currency, falling back to its most
+// recent printing with that price. "" if no printing has the price.
+func cardPrice(card *MagicCard, currency string) string {
+	if card.Card != nil {
+		if price := card.Prices[currency]; price != nil {
+			return *price
+		}
+	}
+	for _, printing := range card.Printings {
+		if price, ok := printing.Prices[currency]; ok {
+			return price
+		}
+	}
+	return ""
+}
+
+// derefString returns the string s points to, or "" for nil.
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// ExportCSV writes cards as CSV, one row per card, for spreadsheet use.
+//
+// Behavior:
+//   - The first row is a header with the column names ("name", "mana_cost", ...)
+//   - Writes DefaultCSVColumns when no columns are given
+//   - Set, collector number and rarity come from the card's printing, or its most
+//     recent cached printing for cards loaded from the database
+//   - Prices come from the card, or its most recent cached printing with that price
+//   - Missing values are written as empty cells
+//
+// Returns:
+//   - error: Unknown columns (before anything is written) or write errors
+//
+// Example:
+//
+//	cards, _ := scryball.Query("t:dragon r:mythic")
+//	err := scryball.ExportCSV(cards, os.Stdout, scryball.CSVName, scryball.CSVSet, scryball.CSVPriceUSD)
+//	// name,set,usd
+//	// Ancient Copper Dragon,clb,5.12
+func ExportCSV(cards []*MagicCard, w io.Writer, columns ...CSVColumn) error {
+	if len(columns) == 0 {
+		columns = DefaultCSVColumns
+	}
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		if _, ok := csvValues[column]; !ok {
+			return fmt.Errorf("unknown CSV column %q", column)
+		}
+		header[i] = string(column)
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	row := make([]string, len(columns))
+	for _, card := range cards {
+		for i, column := range columns {
+			row[i] = csvValues[column](card)
+		}
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,69 @@
+package scryball
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportCSV(t *testing.T) {
+	usd := "1.25"
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	cost := "{R}"
+	bolt.ManaCost = &cost
+	bolt.Prices["usd"] = &usd
+
+	// built from the database: printing-level fields only on Printings
+	fireCard := testAPICard("fire-oracle", "fire-1", "Fire // Ice", "Instant // Instant")
+	fireCard.Set, fireCard.Rarity = "", ""
+	fire := &MagicCard{
+		Card: fireCard,
+		Printings: []Printing{
+			{SetCode: "mh2", CollectorNumber: "290", Rarity: "uncommon", Prices: map[string]string{"eur": "0.50"}},
+			{SetCode: "apc", CollectorNumber: "128", Rarity: "uncommon", Prices: map[string]string{"usd": "0.99", "eur": "0.75"}},
+		},
+	}
+
+	var out strings.Builder
+	if err := ExportCSV([]*MagicCard{bolt, fire}, &out); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	expected := `name,mana_cost,type_line,set,rarity,usd
+Lightning Bolt,{R},Instant,tst,common,1.25
+Fire // Ice,,Instant // Instant,mh2,uncommon,0.99
+`
+	if out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	out.Reset()
+	if err := ExportCSV([]*MagicCard{fire}, &out, CSVName, CSVCollectorNumber, CSVPriceEUR); err != nil {
+		t.Fatalf("ExportCSV failed: %v", err)
+	}
+	if expected := "name,collector_number,eur\nFire // Ice,290,0.50\n"; out.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	out.Reset()
+	if err := ExportCSV([]*MagicCard{bolt}, &out, CSVName, "flavor"); err == nil || out.Len() != 0 {
+		t.Errorf("Expected an unknown column error before writing, got %v and %q", err, out.String())
+	}
+}
+
+func TestPrintingPricesFromDB(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	usd := "1.25"
+	bolt := testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")
+	bolt.Prices["usd"] = &usd
+	bolt.Prices["eur"] = nil
+	insertTestCard(t, sb, bolt)
+
+	card, err := sb.FetchCardByExactName(t.Context(), "Lightning Bolt")
+	if err != nil {
+		t.Fatalf("FetchCardByExactName failed: %v", err)
+	}
+	if got := card.Printings[0].Prices; len(got) != 1 || got["usd"] != "1.25" {
+		t.Errorf("Expected only the usd price on the cached printing, got %v", got)
+	}
+}
@@ -0,0 +1,48 @@
+package scryball
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// DealPacks shuffles the maindeck of a cube and deals packsPerPlayer packs of
+// packSize cards to each of players, for local draft simulators.
+//
+// Behavior:
+//   - The cube is the maindeck, one entry per copy; the sideboard is not dealt
+//   - Packs are dealt round by round: every player's first pack, then their second, ...
+//   - Cards left over after dealing stay out of the draft
+//   - A nil rng uses the default random source. The same seed deals the same
+//     packs for the same cube
+//
+// Returns:
+//   - [][][]*MagicCard: The packs of each player, packs[player][round]
+//   - error: Counts that aren't positive, or a cube with fewer cards than the packs need
+//
+// Example:
+//
+//	// 8 players, 3 rounds of 15-card packs
+//	packs, err := cube.DealPacks(8, 3, 15, rand.New(rand.NewPCG(seed, 0)))
+//	firstPick := packs[0][0]
+func (d *Decklist) DealPacks(players, packsPerPlayer, packSize int, rng *rand.Rand) ([][][]*MagicCard, error) {
+	if players <= 0 || packsPerPlayer <= 0 || packSize <= 0 {
+		return nil, fmt.Errorf("players, packs per player and pack size must be positive, got %d, %d and %d",
+			players, packsPerPlayer, packSize)
+	}
+	needed := players * packsPerPlayer * packSize
+	if cubeSize := d.NumberOfCards(); cubeSize < needed {
+		return nil, fmt.Errorf("cube has %d cards, %d players need %d", cubeSize, players, needed)
+	}
+
+	library := d.Shuffle(rng)
+	packs := make([][][]*MagicCard, players)
+	for player := range packs {
+		packs[player] = make([][]*MagicCard, packsPerPlayer)
+	}
+	for round := range packsPerPlayer {
+		for player := range players {
+			packs[player][round], library = library[:packSize:packSize], library[packSize:]
+		}
+	}
+	return packs, nil
+}
@@ -0,0 +1,53 @@
+package scryball
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestDealPacks(t *testing.T) {
+	cube := NewDecklist()
+	for i := range 50 {
+		name := fmt.Sprintf("Cube Card %d", i)
+		cube.AddCard(&MagicCard{Card: testAPICard("oracle-"+name, "print-"+name, name, "Creature")}, 1)
+	}
+
+	packs, err := cube.DealPacks(4, 3, 4, rand.New(rand.NewPCG(1, 2)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	seen := map[*MagicCard]bool{}
+	for player := range packs {
+		if len(packs[player]) != 3 {
+			t.Fatalf("Expected 3 packs for player %d, got %d", player, len(packs[player]))
+		}
+		for _, pack := range packs[player] {
+			if len(pack) != 4 {
+				t.Errorf("Expected packs of 4 cards, got %d", len(pack))
+			}
+			for _, card := range pack {
+				if seen[card] {
+					t.Errorf("Expected %s to be dealt once", card.Name)
+				}
+				seen[card] = true
+			}
+		}
+	}
+	if len(packs) != 4 || len(seen) != 48 {
+		t.Errorf("Expected 48 cards dealt to 4 players, got %d to %d", len(seen), len(packs))
+	}
+
+	again, _ := cube.DealPacks(4, 3, 4, rand.New(rand.NewPCG(1, 2)))
+	if !reflect.DeepEqual(packs, again) {
+		t.Error("Expected the same seed to deal the same packs")
+	}
+
+	if _, err := cube.DealPacks(8, 3, 15, nil); err == nil {
+		t.Error("Expected an error for a cube too small for the packs")
+	}
+	if _, err := cube.DealPacks(0, 3, 15, nil); err == nil {
+		t.Error("Expected an error for no players")
+	}
+}
@@ -0,0 +1,81 @@
+package scryball
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Curve is a mana curve: the number of nonland cards at each mana value,
+// with cards of mana value 7 or more in the last bucket.
+type Curve [8]int
+
+// CurveOf returns the curve of a mana value to card count mapping like
+// DeckStats.ManaCurve, counting negative mana values as 0.
+func CurveOf(manaCurve map[int]int) Curve {
+	var curve Curve
+	for manaValue, count := range manaCurve {
+		curve[min(max(manaValue, 0), len(curve)-1)] += count
+	}
+	return curve
+}
+
+// Total returns the number of cards on the curve.
+func (c Curve) Total() int {
+	total := 0
+	for _, count := range c {
+		total += count
+	}
+	return total
+}
+
+// label returns the label of bucket i: "0" to "6", then "7+".
+func (c Curve) label(i int) string {
+	if i == len(c)-1 {
+		return fmt.Sprintf("%d+", i)
+	}
+	return fmt.Sprint(i)
+}
+
+// String returns the curve on one line: "0:1 1:4 2:7 3:0 4:0 5:0 6:0 7+:1".
+func (c Curve) String() string {
+	buckets := make([]string, len(c))
+	for i, count := range c {
+		buckets[i] = c.label(i) + ":" + fmt.Sprint(count)
+	}
+	return strings.Join(buckets, " ")
+}
+
+// RenderASCII draws the curve as a horizontal bar chart, one line per mana value:
+//
+//	0  | # 1
+//	1  | #### 4
+//	2  | ####### 7
+//	...
+//	7+ | # 1
+//
+// Bars get one character per card, scaled down so the longest is width
+// characters if it would be longer; a width of 0 or less never scales.
+// Non-empty buckets always get at least one character.
+func (c Curve) RenderASCII(width int) string {
+	highest := 0
+	for _, count := range c {
+		highest = max(highest, count)
+	}
+
+	var b strings.Builder
+	for i, count := range c {
+		bar := count
+		if width > 0 && highest > width {
+			bar = count * width / highest
+			if count > 0 {
+				bar = max(bar, 1)
+			}
+		}
+		fmt.Fprintf(&b, "%-2s |", c.label(i))
+		if count > 0 {
+			fmt.Fprintf(&b, " %s %d", strings.Repeat("#", bar), count)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
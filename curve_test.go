@@ -0,0 +1,25 @@
+package scryball
+
+import "testing"
+
+func TestCurve(t *testing.T) {
+	curve := CurveOf(map[int]int{0: 1, 1: 4, 2: 7, 7: 1, 9: 2})
+	if curve != (Curve{1, 4, 7, 0, 0, 0, 0, 3}) {
+		t.Errorf("Expected mana values of 7 or more in the last bucket, got %v", curve)
+	}
+	if curve.Total() != 15 {
+		t.Errorf("Expected 15 cards, got %d", curve.Total())
+	}
+	if expected := "0:1 1:4 2:7 3:0 4:0 5:0 6:0 7+:3"; curve.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, curve.String())
+	}
+
+	expected := "0  | # 1\n1  | #### 4\n2  | ####### 7\n3  |\n4  |\n5  |\n6  |\n7+ | ### 3\n"
+	if got := curve.RenderASCII(0); got != expected {
+		t.Errorf("Expected unscaled chart:\n%s\ngot:\n%s", expected, got)
+	}
+	expected = "0  | # 1\n1  | # 4\n2  | ### 7\n3  |\n4  |\n5  |\n6  |\n7+ | # 3\n"
+	if got := curve.RenderASCII(3); got != expected {
+		t.Errorf("Expected chart scaled to 3 characters:\n%s\ngot:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,65 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+)
+
+// schemaVersion is the version of schema.sql, stored in the database as PRAGMA user_version
+// when NewSchema creates it or upgrades an older one. Bump it with every change to schema.sql.
+const schemaVersion = 9
+
+// DBInfo describes what is cached in a Scryball database.
+type DBInfo struct {
+	Cards         int   // Unique cards (by Oracle ID)
+	Printings     int   // Printings of those cards
+	Queries       int   // Cached Scryfall queries
+	Images        int   // Images cached in the database, not counting ImageDir
+	SizeBytes     int64 // Size of the database, also for in-memory databases
+	SchemaVersion int   // Schema version of the opened database, stored as PRAGMA user_version
+}
+
+// DBInfo reports what is cached in the instance's database, for dashboards and debugging.
+//
+// Behavior:
+//   - Only reads the database, never queries API
+//   - SizeBytes is the size of the database pages, not counting a pending
+//     write-ahead log or images saved to ImageDir
+//   - Has no rulings count: rulings are never cached, only their URI on each card
+//
+// Returns:
+//   - *DBInfo: Row counts, size and schema version
+//   - error: Database errors
+//
+// Example:
+//
+//	info, _ := sb.DBInfo(ctx)
+//	fmt.Printf("%d cards, %d printings, %.1f MB\n", info.Cards, info.Printings, float64(info.SizeBytes)/1e6)
+func (s *Scryball) DBInfo(ctx context.Context) (*DBInfo, error) {
+	counts, err := s.queries.GetCacheCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count cached rows: %v", err)
+	}
+
+	info := &DBInfo{
+		Cards:     int(counts.Cards),
+		Printings: int(counts.Printings),
+		Queries:   int(counts.Queries),
+		Images:    int(counts.Images),
+	}
+
+	var pageCount, pageSize int64
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pageCount); err != nil {
+		return nil, fmt.Errorf("failed to read page count: %v", err)
+	}
+	if err := s.db.QueryRowContext(ctx, "PRAGMA page_size").Scan(&pageSize); err != nil {
+		return nil, fmt.Errorf("failed to read page size: %v", err)
+	}
+	info.SizeBytes = pageCount * pageSize
+
+	if err := s.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&info.SchemaVersion); err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %v", err)
+	}
+
+	return info, nil
+}
@@ -0,0 +1,69 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestDBInfo(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-2", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("ring", "ring-1", "Sol Ring", "Artifact"))
+	if err := sb.cacheQuery(ctx, "t:instant", []string{"bolt"}); err != nil {
+		t.Fatalf("Failed to cache query: %v", err)
+	}
+
+	info, err := sb.DBInfo(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if info.Cards != 2 || info.Printings != 3 || info.Queries != 1 || info.Images != 0 {
+		t.Errorf("Expected 2 cards, 3 printings, 1 query and 0 images, got %+v", info)
+	}
+	if info.SizeBytes <= 0 {
+		t.Errorf("Expected a database size, got %d", info.SizeBytes)
+	}
+	if info.SchemaVersion != schemaVersion {
+		t.Errorf("Expected schema version %d, got %d", schemaVersion, info.SchemaVersion)
+	}
+}
+
+func TestDBInfoSchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	for _, tt := range []struct {
+		stored, want int
+	}{
+		{schemaVersion - 1, schemaVersion},     // upgraded by this version
+		{schemaVersion + 1, schemaVersion + 1}, // created by a newer version
+	} {
+		db, err := NewSchema(dbPath)
+		if err != nil {
+			t.Fatalf("NewSchema failed: %v", err)
+		}
+		if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", tt.stored)); err != nil {
+			t.Fatalf("Failed to set schema version: %v", err)
+		}
+		db.Close()
+
+		sb, err := NewWithConfig(ScryballConfig{DBPath: dbPath})
+		if err != nil {
+			t.Fatalf("Failed to reopen database: %v", err)
+		}
+		info, err := sb.DBInfo(ctx)
+		sb.Close()
+		if err != nil {
+			t.Fatalf("DBInfo failed: %v", err)
+		}
+		if info.SchemaVersion != tt.want {
+			t.Errorf("Expected schema version %d after opening a version %d database, got %d", tt.want, tt.stored, info.SchemaVersion)
+		}
+	}
+}
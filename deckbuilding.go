@@ -0,0 +1,201 @@
+package scryball
+
+import "fmt"
+
+// NewDecklist returns an empty Decklist ready to be built with AddCard and friends.
+func NewDecklist() *Decklist {
+	return &Decklist{
+		Maindeck:   make(map[*MagicCard]int),
+		Sideboard:  make(map[*MagicCard]int),
+		Maybeboard: make(map[*MagicCard]int),
+	}
+}
+
+// AddCard adds qty copies of card to the maindeck.
+//
+// Copies of a card already in the maindeck (same oracle ID) are merged into
+// the existing entry. The deck is not re-validated; call ValidateConstructed(),
+// ValidateLimited() etc. once building is done.
+//
+// Example:
+//
+//	deck := scryball.NewDecklist()
+//	bolt, _ := scryball.QueryCard("Lightning Bolt")
+//	deck.AddCard(bolt, 4)
+func (d *Decklist) AddCard(card *MagicCard, qty int) error {
+	return d.addToZone(card, qty, false)
+}
+
+// AddSideboardCard adds qty copies of card to the sideboard.
+//
+// See AddCard.
+func (d *Decklist) AddSideboardCard(card *MagicCard, qty int) error {
+	return d.addToZone(card, qty, true)
+}
+
+// RemoveCard removes qty copies of card from the maindeck.
+//
+// Returns an error if the maindeck has fewer than qty copies. The entry is
+// deleted once no copies are left.
+func (d *Decklist) RemoveCard(card *MagicCard, qty int) error {
+	return d.removeFromZone(card, qty, false)
+}
+
+// RemoveSideboardCard removes qty copies of card from the sideboard.
+//
+// See RemoveCard.
+func (d *Decklist) RemoveSideboardCard(card *MagicCard, qty int) error {
+	return d.removeFromZone(card, qty, true)
+}
+
+// SetQuantity sets the number of copies of card in the maindeck.
+//
+// A quantity of 0 removes the card from the maindeck.
+func (d *Decklist) SetQuantity(card *MagicCard, qty int) error {
+	return d.setZoneQuantity(card, qty, false)
+}
+
+// SetSideboardQuantity sets the number of copies of card in the sideboard.
+//
+// A quantity of 0 removes the card from the sideboard.
+func (d *Decklist) SetSideboardQuantity(card *MagicCard, qty int) error {
+	return d.setZoneQuantity(card, qty, true)
+}
+
+// MoveToSideboard moves qty copies of card from the maindeck to the sideboard.
+//
+// Returns an error, leaving the deck unchanged, if the maindeck has fewer than qty copies.
+func (d *Decklist) MoveToSideboard(card *MagicCard, qty int) error {
+	return d.moveBetweenZones(card, qty, false)
+}
+
+// MoveToMaindeck moves qty copies of card from the sideboard to the maindeck.
+//
+// Returns an error, leaving the deck unchanged, if the sideboard has fewer than qty copies.
+func (d *Decklist) MoveToMaindeck(card *MagicCard, qty int) error {
+	return d.moveBetweenZones(card, qty, true)
+}
+
+// returns the maindeck or sideboard, creating it for zero value Decklists
+func (d *Decklist) zone(sideboard bool) map[*MagicCard]int {
+	if sideboard {
+		if d.Sideboard == nil {
+			d.Sideboard = make(map[*MagicCard]int)
+		}
+		return d.Sideboard
+	}
+	if d.Maindeck == nil {
+		d.Maindeck = make(map[*MagicCard]int)
+	}
+	return d.Maindeck
+}
+
+func zoneName(sideboard bool) string {
+	if sideboard {
+		return "sideboard"
+	}
+	return "maindeck"
+}
+
+func (d *Decklist) addToZone(card *MagicCard, qty int, sideboard bool) error {
+	if card == nil {
+		return fmt.Errorf("cannot add nil card")
+	}
+	if qty < 1 {
+		return fmt.Errorf("quantity must be at least 1, got %d", qty)
+	}
+	addCardToMap(card, qty, d.zone(sideboard))
+	return nil
+}
+
+func (d *Decklist) removeFromZone(card *MagicCard, qty int, sideboard bool) error {
+	if card == nil {
+		return fmt.Errorf("cannot remove nil card")
+	}
+	if qty < 1 {
+		return fmt.Errorf("quantity must be at least 1, got %d", qty)
+	}
+
+	list := d.zone(sideboard)
+	key, ok := doesCardExistInMap(card, list)
+	if !ok {
+		return fmt.Errorf("%s is not in the %s", card.Name, zoneName(sideboard))
+	}
+	if list[key] < qty {
+		return fmt.Errorf("cannot remove %d copies of %s, %s has %d", qty, card.Name, zoneName(sideboard), list[key])
+	}
+
+	list[key] -= qty
+	if list[key] == 0 {
+		delete(list, key)
+		d.forgetPrinting(key)
+	}
+	return nil
+}
+
+func (d *Decklist) setZoneQuantity(card *MagicCard, qty int, sideboard bool) error {
+	if card == nil {
+		return fmt.Errorf("cannot set quantity of nil card")
+	}
+	if qty < 0 {
+		return fmt.Errorf("quantity must not be negative, got %d", qty)
+	}
+
+	list := d.zone(sideboard)
+	key, _ := doesCardExistInMap(card, list)
+	if qty == 0 {
+		delete(list, key)
+		d.forgetPrinting(key)
+		return nil
+	}
+	list[key] = qty
+	return nil
+}
+
+func (d *Decklist) moveBetweenZones(card *MagicCard, qty int, fromSideboard bool) error {
+	if card == nil {
+		return fmt.Errorf("cannot move nil card")
+	}
+	from := d.zone(fromSideboard)
+	key, _ := doesCardExistInMap(card, from)
+	requested, hasPrinting := d.Printings[key]
+
+	if err := d.removeFromZone(card, qty, fromSideboard); err != nil {
+		return err
+	}
+	to := d.zone(!fromSideboard)
+	newKey := addCardToMap(key, qty, to)
+
+	// keep the requested printing with the copies that moved
+	if hasPrinting {
+		if _, ok := d.Printings[newKey]; !ok {
+			d.Printings[newKey] = requested
+		}
+	}
+	return nil
+}
+
+// drops the requested printing of card once no zone references it
+func (d *Decklist) forgetPrinting(card *MagicCard) {
+	if _, ok := d.Printings[card]; !ok {
+		return
+	}
+	if _, ok := d.Maindeck[card]; ok {
+		return
+	}
+	if _, ok := d.Sideboard[card]; ok {
+		return
+	}
+	if _, ok := d.Maybeboard[card]; ok {
+		return
+	}
+	for _, commander := range d.Commanders {
+		if commander == card {
+			return
+		}
+	}
+	if d.Companion == card {
+		return
+	}
+	delete(d.Printings, card)
+}
@@ -0,0 +1,135 @@
+package scryball
+
+import "testing"
+
+func TestDecklistBuilding(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	boltReprint := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-2", "Lightning Bolt", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+
+	deck := NewDecklist()
+	if err := deck.AddCard(bolt, 2); err != nil {
+		t.Fatalf("AddCard failed: %v", err)
+	}
+	if err := deck.AddCard(boltReprint, 2); err != nil {
+		t.Fatalf("AddCard failed: %v", err)
+	}
+	if len(deck.Maindeck) != 1 || deck.Maindeck[bolt] != 4 {
+		t.Errorf("Expected copies with the same oracle ID to merge into 4 Lightning Bolt, got %v", deck.Maindeck)
+	}
+
+	if err := deck.AddCard(mountain, 20); err != nil {
+		t.Fatalf("AddCard failed: %v", err)
+	}
+	if err := deck.AddSideboardCard(pyro, 3); err != nil {
+		t.Fatalf("AddSideboardCard failed: %v", err)
+	}
+	if err := deck.AddCard(pyro, 0); err == nil {
+		t.Error("Expected error adding 0 copies")
+	}
+
+	if err := deck.SetQuantity(mountain, 18); err != nil {
+		t.Fatalf("SetQuantity failed: %v", err)
+	}
+	if deck.NumberOfCards() != 22 {
+		t.Errorf("Expected 22 maindeck cards, got %d", deck.NumberOfCards())
+	}
+
+	if err := deck.MoveToSideboard(bolt, 1); err != nil {
+		t.Fatalf("MoveToSideboard failed: %v", err)
+	}
+	if deck.Maindeck[bolt] != 3 || deck.Sideboard[bolt] != 1 {
+		t.Errorf("Expected 3 Lightning Bolt main and 1 side, got %d and %d", deck.Maindeck[bolt], deck.Sideboard[bolt])
+	}
+	if err := deck.MoveToSideboard(bolt, 5); err == nil {
+		t.Error("Expected error moving more copies than the maindeck has")
+	}
+	if deck.Maindeck[bolt] != 3 {
+		t.Errorf("Expected failed move to leave maindeck unchanged, got %d", deck.Maindeck[bolt])
+	}
+
+	if err := deck.MoveToSideboard(nil, 1); err == nil {
+		t.Error("Expected error moving a nil card to the sideboard")
+	}
+	if err := deck.MoveToMaindeck(nil, 1); err == nil {
+		t.Error("Expected error moving a nil card to the maindeck")
+	}
+
+	if err := deck.MoveToMaindeck(bolt, 1); err != nil {
+		t.Fatalf("MoveToMaindeck failed: %v", err)
+	}
+	if _, ok := deck.Sideboard[bolt]; ok {
+		t.Error("Expected Lightning Bolt to leave the sideboard once all copies moved")
+	}
+
+	if err := deck.RemoveCard(pyro, 1); err == nil {
+		t.Error("Expected error removing a card that is not in the maindeck")
+	}
+	if err := deck.RemoveSideboardCard(pyro, 3); err != nil {
+		t.Fatalf("RemoveSideboardCard failed: %v", err)
+	}
+	if deck.NumberOfSideboardCards() != 0 {
+		t.Errorf("Expected empty sideboard, got %d", deck.NumberOfSideboardCards())
+	}
+
+	if err := deck.SetQuantity(mountain, 0); err != nil {
+		t.Fatalf("SetQuantity failed: %v", err)
+	}
+	if _, ok := deck.Maindeck[mountain]; ok {
+		t.Error("Expected SetQuantity 0 to remove Mountain")
+	}
+
+	if err := deck.ValidateConstructed(); err == nil {
+		t.Error("Expected 4 card deck to fail constructed validation")
+	}
+}
+
+func TestDecklistBuildingZeroValue(t *testing.T) {
+	var deck Decklist
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+
+	if err := deck.AddSideboardCard(bolt, 1); err != nil {
+		t.Fatalf("AddSideboardCard on zero value Decklist failed: %v", err)
+	}
+	if err := deck.MoveToMaindeck(bolt, 1); err != nil {
+		t.Fatalf("MoveToMaindeck on zero value Decklist failed: %v", err)
+	}
+	if deck.NumberOfCards() != 1 {
+		t.Errorf("Expected 1 maindeck card, got %d", deck.NumberOfCards())
+	}
+}
+
+func TestDecklistMoveKeepsRequestedPrinting(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.requestPrinting(bolt, "STA", "42")
+
+	if err := deck.MoveToSideboard(bolt, 4); err != nil {
+		t.Fatalf("MoveToSideboard failed: %v", err)
+	}
+	if requested, ok := deck.Printings[bolt]; !ok || requested.SetCode != "STA" {
+		t.Errorf("Expected requested printing to move with the card, got %+v", deck.Printings)
+	}
+
+	deck.RemoveSideboardCard(bolt, 4)
+	if _, ok := deck.Printings[bolt]; ok {
+		t.Error("Expected requested printing to be dropped with the last copy")
+	}
+}
+
+func TestDecklistRemoveKeepsMaybeboardPrinting(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 1)
+	deck.Maybeboard[bolt] = 2
+	deck.requestPrinting(bolt, "STA", "42")
+
+	deck.RemoveCard(bolt, 1)
+	if requested, ok := deck.Printings[bolt]; !ok || requested.SetCode != "STA" {
+		t.Errorf("Expected requested printing to stay while the maybeboard has the card, got %+v", deck.Printings)
+	}
+}
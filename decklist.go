@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -13,8 +14,66 @@ import (
 
 // Decklist represents a Magic: The Gathering deck with maindeck and sideboard.
 type Decklist struct {
+	Name      string             // Deck name, if the source format carries one
+	Comments  string             // Free-form deck notes, if the source format carries them
 	Maindeck  map[*MagicCard]int // Card to quantity mapping
 	Sideboard map[*MagicCard]int // Card to quantity mapping (max 15 cards total)
+
+	// Cards from a "Maybeboard" or "Considering" section. They are not part of
+	// the deck: counts, validation and stats ignore them.
+	Maybeboard map[*MagicCard]int
+
+	Commanders []*MagicCard // Cards from a "Commander" section, not counted in Maindeck
+	Companion  *MagicCard   // Card from a "Companion" section, nil if none
+
+	// Printings chosen by the decklist, like "4 Lightning Bolt (STA) 42".
+	// Keyed by the same card pointers as Maindeck, Sideboard, Maybeboard, Commanders and Companion.
+	// Cards without a requested printing have no entry.
+	Printings map[*MagicCard]RequestedPrinting
+}
+
+// RequestedPrinting is the printing a decklist line asked for with "(SET) number".
+type RequestedPrinting struct {
+	SetCode         string    `json:"set_code"`                   // Set code as written in the decklist, upper case ("STA")
+	CollectorNumber string    `json:"collector_number,omitempty"` // Collector number, empty if only a set was given
+	Printing        *Printing `json:"-"`                          // Matching cached printing, nil if not cached
+}
+
+// requestPrinting records the printing requested for card, keeping the first request if there are several.
+func (d *Decklist) requestPrinting(card *MagicCard, setCode, collectorNumber string) {
+	if setCode == "" {
+		return
+	}
+	if d.Printings == nil {
+		d.Printings = make(map[*MagicCard]RequestedPrinting)
+	}
+	if _, ok := d.Printings[card]; ok {
+		return
+	}
+
+	requested := RequestedPrinting{SetCode: setCode, CollectorNumber: collectorNumber}
+	for i, printing := range card.Printings {
+		if !strings.EqualFold(printing.SetCode, setCode) {
+			continue
+		}
+		if collectorNumber == "" || strings.EqualFold(printing.CollectorNumber, collectorNumber) {
+			requested.Printing = &card.Printings[i]
+			break
+		}
+	}
+	d.Printings[card] = requested
+}
+
+// cardLine formats a card for text export, with its requested printing when there is one.
+func (d *Decklist) cardLine(qty int, card *MagicCard) string {
+	requested, ok := d.Printings[card]
+	if !ok {
+		return fmt.Sprintf("%d %s\n", qty, card.Name)
+	}
+	if requested.CollectorNumber == "" {
+		return fmt.Sprintf("%d %s (%s)\n", qty, card.Name, requested.SetCode)
+	}
+	return fmt.Sprintf("%d %s (%s) %s\n", qty, card.Name, requested.SetCode, requested.CollectorNumber)
 }
 
 // // Returns the decklist in text format, able to be exported to Arena or similar platform.
@@ -33,20 +92,57 @@ type Decklist struct {
 // 	return sb.String()
 // }
 
+// parseOptions controls how parseDecklist handles problems.
+type parseOptions struct {
+	// record line problems and keep going instead of failing on the first one
+	lenient bool
+	// resolve cards from the database only, never the API; misses are reported as unresolved
+	cacheOnly bool
+	// replace Alchemy rebalanced cards with their paper versions
+	paper bool
+}
+
+// parseResult is everything parseDecklist produces.
+type parseResult struct {
+	decklist   *Decklist
+	lineErrors []DecklistLineError // lenient mode only
+	unresolved []string            // cacheOnly mode only, in order of first appearance
+}
+
 // shared parsing implementation
-func (sb *Scryball) parseDecklist(ctx context.Context, decklistString string) (*Decklist, error) {
-	decklist := &Decklist{
-		Maindeck:  make(map[*MagicCard]int),
-		Sideboard: make(map[*MagicCard]int),
-	}
+//
+// In strict mode the first problem is returned as the error. In lenient mode
+// problems are collected as line errors and the offending line is skipped.
+func (sb *Scryball) parseDecklist(ctx context.Context, decklistString string, opts parseOptions) (*parseResult, error) {
+	decklist := NewDecklist()
 
 	lines := strings.Split(decklistString, "\n")
-	var inDeck bool // must start with "Deck"
-	var inSideboard bool
+	current := sectionMaindeck // lists without headers are all maindeck
+	seen := make(map[decklistSection]bool)
 	var sideboardTotal int
+	var lineErrors []DecklistLineError
+	var unresolved []string
+
+	resolve := sb.resolveDecklistCard
+	if opts.cacheOnly {
+		resolve = sb.FetchCardByExactName
+	}
+
+	// returns a non-nil error only when parsing must stop
+	fail := func(i int, cardName string, err error) error {
+		if !opts.lenient {
+			return err
+		}
+		lineErrors = append(lineErrors, DecklistLineError{Line: i + 1, CardName: cardName, Reason: err.Error(), Err: err})
+		return nil
+	}
 
 	var hasAbout = false
 	for i, line := range lines {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		line = strings.TrimSpace(line)
 		if i == 0 {
 			if strings.EqualFold(line, "About") {
@@ -57,130 +153,324 @@ func (sb *Scryball) parseDecklist(ctx context.Context, decklistString string) (*
 			if hasAbout {
 				parts := strings.Split(line, " ")
 				if strings.EqualFold(parts[0], "Name") {
+					decklist.Name = strings.TrimSpace(line[len(parts[0]):])
 					continue
 				} else {
-					return nil, fmt.Errorf("must have deck name even if unused with 'About'")
+					if err := fail(i, "", fmt.Errorf("must have deck name even if unused with 'About'")); err != nil {
+						return nil, err
+					}
 				}
 			}
 		}
 
+		line = stripComment(line)
 		if line == "" {
 			continue
 		}
 
-		if strings.EqualFold(line, "Deck") {
-			if inSideboard {
-				return nil, fmt.Errorf("already submitting sideboard, found on line %d", i)
+		if section, ok := parseSectionHeader(line); ok {
+			if seen[section] {
+				if err := fail(i, "", fmt.Errorf("cannot have %s section twice, found on line %d", section, i+1)); err != nil {
+					return nil, err
+				}
 			}
-
-			if inDeck {
-				return nil, fmt.Errorf("already parsing Deck, did you input a deck twice?")
-			} else {
-				inDeck = true
+			if section == sectionMaindeck && current == sectionSideboard {
+				if err := fail(i, "", fmt.Errorf("already submitting sideboard, found on line %d", i+1)); err != nil {
+					return nil, err
+				}
 			}
-
+			seen[section] = true
+			current = section
 			continue
 		}
 
-		if strings.EqualFold(line, "Sideboard") {
-			if inSideboard {
-				return nil, fmt.Errorf("cannot have sideboard twice, found on line %d", i)
-			}
-			inSideboard = true
-			continue
+		// MTGO text exports mark sideboard cards per line: "SB: 2 Negate"
+		section := current
+		if len(line) >= 3 && strings.EqualFold(line[:3], "SB:") {
+			line = strings.TrimSpace(line[3:])
+			section = sectionSideboard
 		}
 
+		_, setCode, collectorNumber := splitPrinting(line)
 		quantity, cardName, err := parseCardLine(line)
 		if err != nil {
-			return nil, err
+			if err := fail(i, "", err); err != nil {
+				return nil, err
+			}
+			continue
 		}
+		cardName = normalizeCardName(sb.resolveAlias(ctx, cardName))
 
-		var magicCard *MagicCard
-
-		// First check cache
-		magicCard, err = sb.FetchCardByExactName(ctx, cardName)
-		if err == sql.ErrNoRows {
-			// Not in cache, try API
-			// Search for exact match using the instance's client
-			cards, searchErr := sb.client.QueryForCards(fmt.Sprintf("!\"%s\"", cardName))
-			if searchErr != nil || len(cards) == 0 {
-				// Try broader search
-				cards, searchErr = sb.client.QueryForCards(cardName)
-				if searchErr != nil || len(cards) == 0 {
-					return nil, fmt.Errorf("card not found: %s", cardName)
-				}
+		magicCard, err := resolve(ctx, cardName)
+		if err != nil {
+			// "Fire // Ice" is a card name, "Lightning Bolt // burn" is a trailing comment
+			if uncommented, ok := stripSlashComment(cardName); ok {
+				magicCard, err = resolve(ctx, uncommented)
 			}
-
-			// Check for exact name match in results
-			var exactMatch *client.Card
-			for i := range cards {
-				if strings.EqualFold(cards[i].Name, cardName) {
-					exactMatch = &cards[i]
-					break
+			if opts.cacheOnly && err == sql.ErrNoRows {
+				if !slices.Contains(unresolved, cardName) {
+					unresolved = append(unresolved, cardName)
 				}
+				continue
 			}
-
-			var apiCard *client.Card
-			if exactMatch != nil {
-				apiCard = exactMatch
-			} else if len(cards) == 1 {
-				// If only one result, use it
-				apiCard = &cards[0]
-			} else {
-				// Multiple cards, ambiguous
-				var names []string
-				for _, c := range cards {
-					names = append(names, c.Name)
+			if err != nil {
+				if err := fail(i, cardName, err); err != nil {
+					return nil, err
 				}
-				return nil, fmt.Errorf("ambiguous card name '%s', could be: %s",
-					cardName, strings.Join(names, ", "))
+				continue
 			}
+		}
 
-			// Cache the card (InsertCardFromAPI now fetches ALL printings automatically)
-			magicCard, err = sb.InsertCardFromAPI(ctx, apiCard)
+		if opts.paper && magicCard.IsRebalanced() {
+			paperCard, err := resolve(ctx, magicCard.PaperName())
 			if err != nil {
-				return nil, fmt.Errorf("failed to cache card %s: %v", cardName, err)
+				if err := fail(i, cardName, fmt.Errorf("no paper version of %s: %v", magicCard.Name, err)); err != nil {
+					return nil, err
+				}
+				continue
 			}
-		} else if err != nil {
-			// Database error
-			return nil, fmt.Errorf("database error fetching %s: %v", cardName, err)
+			magicCard, setCode, collectorNumber = paperCard, "", ""
 		}
 
 		// Add to appropriate section
-		if inSideboard {
+		switch section {
+		case sectionCommander:
+			if quantity != 1 {
+				if err := fail(i, magicCard.Name, fmt.Errorf("commander %s must have quantity 1, has %d", magicCard.Name, quantity)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			decklist.Commanders = append(decklist.Commanders, magicCard)
+			decklist.requestPrinting(magicCard, setCode, collectorNumber)
+		case sectionCompanion:
+			if quantity != 1 {
+				if err := fail(i, magicCard.Name, fmt.Errorf("companion %s must have quantity 1, has %d", magicCard.Name, quantity)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if decklist.Companion != nil {
+				if err := fail(i, magicCard.Name, fmt.Errorf("cannot have more than one companion, found %s and %s", decklist.Companion.Name, magicCard.Name)); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			decklist.Companion = magicCard
+			decklist.requestPrinting(magicCard, setCode, collectorNumber)
+		case sectionSideboard:
+			if sideboardTotal+quantity > 15 {
+				if err := fail(i, magicCard.Name, fmt.Errorf("sideboard exceeds 15 cards (has %d)", sideboardTotal+quantity)); err != nil {
+					return nil, err
+				}
+				continue
+			}
 			sideboardTotal += quantity
-			if sideboardTotal > 15 {
-				return nil, fmt.Errorf("sideboard exceeds 15 cards (has %d)", sideboardTotal)
+
+			key := addCardToMap(magicCard, quantity, decklist.Sideboard)
+			decklist.requestPrinting(key, setCode, collectorNumber)
+		case sectionMaybeboard:
+			key := addCardToMap(magicCard, quantity, decklist.Maybeboard)
+			decklist.requestPrinting(key, setCode, collectorNumber)
+		default:
+			key := addCardToMap(magicCard, quantity, decklist.Maindeck)
+			decklist.requestPrinting(key, setCode, collectorNumber)
+		}
+
+	}
+
+	return &parseResult{
+		decklist:   decklist,
+		lineErrors: lineErrors,
+		unresolved: unresolved,
+	}, nil
+}
+
+// DecklistLineError describes a problem with a single line of a decklist.
+//
+// Returned by ParseDecklistLenient so every problem can be shown at once.
+type DecklistLineError struct {
+	Line     int    // 1-based line number in the decklist text
+	CardName string // Card name on the line, empty if the line could not be parsed
+	Reason   string // Human-readable description of the problem
+	Err      error  // The problem as an error, a *CardNotFoundError for unknown cards
+}
+
+func (e DecklistLineError) Error() string {
+	if e.CardName == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Reason)
+	}
+	return fmt.Sprintf("line %d (%s): %s", e.Line, e.CardName, e.Reason)
+}
+
+// Unwrap returns the underlying error, for errors.As with *CardNotFoundError.
+func (e DecklistLineError) Unwrap() error {
+	return e.Err
+}
+
+// decklistSection is a section of a text decklist, introduced by a header line.
+type decklistSection int
+
+const (
+	sectionMaindeck decklistSection = iota
+	sectionSideboard
+	sectionCommander
+	sectionCompanion
+	sectionMaybeboard
+)
+
+func (s decklistSection) String() string {
+	switch s {
+	case sectionSideboard:
+		return "Sideboard"
+	case sectionCommander:
+		return "Commander"
+	case sectionCompanion:
+		return "Companion"
+	case sectionMaybeboard:
+		return "Maybeboard"
+	default:
+		return "Deck"
+	}
+}
+
+// section headers as emitted by Arena, MTGO, Moxfield and Archidekt, lowercased
+var sectionHeaders = map[string]decklistSection{
+	"deck":        sectionMaindeck,
+	"main":        sectionMaindeck,
+	"maindeck":    sectionMaindeck,
+	"main deck":   sectionMaindeck,
+	"mainboard":   sectionMaindeck,
+	"sideboard":   sectionSideboard,
+	"side":        sectionSideboard,
+	"commander":   sectionCommander,
+	"commanders":  sectionCommander,
+	"companion":   sectionCompanion,
+	"maybeboard":  sectionMaybeboard,
+	"maybe":       sectionMaybeboard,
+	"considering": sectionMaybeboard,
+}
+
+// parseSectionHeader recognizes a section header line such as "Sideboard",
+// "Mainboard (60)" or "Commander (1)". A trailing card count in parentheses is ignored.
+func parseSectionHeader(line string) (decklistSection, bool) {
+	header := strings.TrimSuffix(strings.TrimSpace(line), ":")
+	if open := strings.LastIndex(header, "("); open != -1 && strings.HasSuffix(header, ")") {
+		if _, err := strconv.Atoi(strings.TrimSpace(header[open+1 : len(header)-1])); err == nil {
+			header = strings.TrimSpace(header[:open])
+		}
+	}
+
+	section, ok := sectionHeaders[strings.ToLower(header)]
+	return section, ok
+}
+
+// resolveDecklistCard looks a decklist card name up in the cache, falling back to the API.
+func (sb *Scryball) resolveDecklistCard(ctx context.Context, cardName string) (*MagicCard, error) {
+	// First check cache
+	magicCard, err := sb.FetchCardByExactName(ctx, cardName)
+	if err == sql.ErrNoRows {
+		// Not in cache, try API
+		// Search for exact match using the instance's client
+		cards, searchErr := sb.client.QueryForCards(fmt.Sprintf("!\"%s\"", cardName))
+		if searchErr != nil || len(cards) == 0 {
+			// A typo of a cached card, suggest it rather than guessing with a broader search
+			if similar, _ := sb.SimilarCardNames(ctx, cardName, 1); len(similar) > 0 {
+				return nil, sb.cardNotFoundError(ctx, cardName)
 			}
 
-			if key, exists := doesCardExistInMap(magicCard, decklist.Sideboard); exists {
-				decklist.Sideboard[key] += quantity
-			} else {
-				decklist.Sideboard[key] = quantity
+			// Try broader search
+			cards, searchErr = sb.client.QueryForCards(cardName)
+			if searchErr != nil || len(cards) == 0 {
+				return nil, sb.cardNotFoundError(ctx, cardName)
 			}
+		}
+
+		// Check for exact name match in results
+		var exactMatch *client.Card
+		for i := range cards {
+			if strings.EqualFold(cards[i].Name, cardName) {
+				exactMatch = &cards[i]
+				break
+			}
+		}
+
+		var apiCard *client.Card
+		if exactMatch != nil {
+			apiCard = exactMatch
+		} else if len(cards) == 1 {
+			// If only one result, use it
+			apiCard = &cards[0]
 		} else {
-			if key, exists := doesCardExistInMap(magicCard, decklist.Maindeck); exists {
-				decklist.Maindeck[key] += quantity
-			} else {
-				decklist.Maindeck[key] = quantity
+			// Multiple cards, ambiguous
+			var names []string
+			for _, c := range cards {
+				names = append(names, c.Name)
 			}
+			return nil, fmt.Errorf("ambiguous card name '%s', could be: %s",
+				cardName, strings.Join(names, ", "))
 		}
 
+		// Cache the card (InsertCardFromAPI now fetches ALL printings automatically)
+		magicCard, err = sb.InsertCardFromAPI(ctx, apiCard)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cache card %s: %v", cardName, err)
+		}
+	} else if err != nil {
+		// Database error
+		return nil, fmt.Errorf("database error fetching %s: %v", cardName, err)
 	}
 
-	return decklist, nil
+	return magicCard, nil
 }
 
 // if it does, it returns the key pointer
 func doesCardExistInMap(magicCard *MagicCard, list map[*MagicCard]int) (*MagicCard, bool) {
+	if _, ok := list[magicCard]; ok {
+		return magicCard, true
+	}
+	if magicCard.OracleID == nil {
+		return magicCard, false
+	}
 	for card := range list {
-		if strings.Compare(*magicCard.OracleID, *card.OracleID) == 0 {
+		if card.OracleID != nil && *magicCard.OracleID == *card.OracleID {
 			return card, true
 		}
 	}
 	return magicCard, false
 }
 
+// returns the cards of list sorted by name, so output built from the map is stable
+func sortedCards(list map[*MagicCard]int) []*MagicCard {
+	cards := make([]*MagicCard, 0, len(list))
+	for card := range list {
+		cards = append(cards, card)
+	}
+	sort.Slice(cards, func(i, j int) bool {
+		if cards[i].Name != cards[j].Name {
+			return cards[i].Name < cards[j].Name
+		}
+		// same name but not merged, e.g. cards built by hand without oracle IDs
+		return cardSortKey(cards[i]) < cardSortKey(cards[j])
+	})
+	return cards
+}
+
+func cardSortKey(card *MagicCard) string {
+	if card.OracleID == nil {
+		return ""
+	}
+	return *card.OracleID
+}
+
+// adds quantity copies of magicCard to list, merging with an existing entry for the same card.
+// Returns the key the copies were added under.
+func addCardToMap(magicCard *MagicCard, quantity int, list map[*MagicCard]int) *MagicCard {
+	key, _ := doesCardExistInMap(magicCard, list)
+	list[key] += quantity
+	return key
+}
+
 // ParseDecklist parses an pasted string decklist and returns a Decklist.
 //
 // Format expected:
@@ -198,12 +488,40 @@ func doesCardExistInMap(magicCard *MagicCard, list map[*MagicCard]int) (*MagicCa
 //	4 Lightning Bolt (2ED) 161
 //	2 Counterspell (ICE) 64
 //
+// Quantities may also be written "4x Card", "x4 Card" or "Card x4";
+// a line with only a card name means 1 copy.
+//
+// MTGO text exports are supported too: lines prefixed with "SB: " go to the
+// sideboard without needing a "Sideboard" header.
+//
+//	4 Lightning Bolt
+//	SB: 3 Pyroblast
+//
+// Lines starting with "#" or "//" are comments, as is anything after " #".
+// A trailing "// comment" is also ignored unless the full text is a card
+// name such as "Fire // Ice":
+//
+//	# Burn
+//	4 Lightning Bolt # best burn spell
+//	4 Chain Lightning // sorcery speed
+//
+// Section headers exported by Moxfield and Archidekt are also recognized,
+// with or without a trailing card count:
+//
+//	Commander (1)
+//	1 Atraxa, Praetors' Voice
+//
+//	Mainboard (99)
+//	1 Sol Ring
+//
 // Behavior:
 //   - Fetches missing cards with single API call per unique card
 //   - Each fetched card includes all printings across all sets
 //   - Handles exact name matches
 //   - Returns error for ambiguous card names
 //   - Sideboard section must be preceded by "Sideboard" header
+//   - "Commander" cards populate Decklist.Commanders, "Companion" populates Decklist.Companion
+//   - "Maybeboard"/"Considering" cards populate Decklist.Maybeboard and don't count toward the deck
 //
 // Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
 //
@@ -245,7 +563,11 @@ func ParseDecklistWithContext(ctx context.Context, decklistString string) (*Deck
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize scryball %v", err)
 	}
-	return sb.parseDecklist(ctx, decklistString)
+	result, err := sb.parseDecklist(ctx, decklistString, parseOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return result.decklist, nil
 }
 
 // ParseDecklist parses a decklist using this Scryball instance's client and database.
@@ -271,52 +593,225 @@ func (s *Scryball) ParseDecklist(decklistString string) (*Decklist, error) {
 //   - Returns error for ambiguous card names
 //   - Respects context cancellation and timeouts
 func (s *Scryball) ParseDecklistWithContext(ctx context.Context, decklistString string) (*Decklist, error) {
-	return s.parseDecklist(ctx, decklistString)
+	result, err := s.parseDecklist(ctx, decklistString, parseOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return result.decklist, nil
+}
+
+// ParseDecklistLenient parses a decklist like ParseDecklist, but keeps going past problems.
+//
+// Behavior:
+//   - Lines that cannot be parsed or resolved are skipped and reported
+//   - Returns a partially populated Decklist with every line that succeeded
+//   - Each problem is reported once, with its line number and card name
+//
+// Returns:
+//   - *Decklist: Deck built from every valid line
+//   - []DecklistLineError: Every problem found (empty if the whole list is valid)
+//   - error: Only for failures that stop parsing entirely, such as context cancellation
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+//
+// Example:
+//
+//	deck, problems, err := scryball.ParseDecklistLenient(deckString)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	for _, problem := range problems {
+//	    fmt.Println(problem) // line 3 (Lightning Blot): card not found: Lightning Blot, did you mean Lightning Bolt?
+//	}
+func ParseDecklistLenient(decklist string) (*Decklist, []DecklistLineError, error) {
+	ctx := context.Background()
+	return ParseDecklistLenientWithContext(ctx, decklist)
+}
+
+// ParseDecklistLenientWithContext is ParseDecklistLenient with context support.
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ParseDecklistLenientWithContext(ctx context.Context, decklistString string) (*Decklist, []DecklistLineError, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.parseDecklistLenient(ctx, decklistString)
+}
+
+// ParseDecklistLenient parses a decklist using this Scryball instance, collecting every problem.
+//
+// See the package-level ParseDecklistLenient for details.
+func (s *Scryball) ParseDecklistLenient(decklistString string) (*Decklist, []DecklistLineError, error) {
+	ctx := context.Background()
+	return s.ParseDecklistLenientWithContext(ctx, decklistString)
+}
+
+// ParseDecklistLenientWithContext parses a decklist using this Scryball instance with context support, collecting every problem.
+func (s *Scryball) ParseDecklistLenientWithContext(ctx context.Context, decklistString string) (*Decklist, []DecklistLineError, error) {
+	return s.parseDecklistLenient(ctx, decklistString)
+}
+
+func (sb *Scryball) parseDecklistLenient(ctx context.Context, decklistString string) (*Decklist, []DecklistLineError, error) {
+	result, err := sb.parseDecklist(ctx, decklistString, parseOptions{lenient: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.decklist, result.lineErrors, nil
+}
+
+// ParseDecklistOffline parses a decklist resolving cards strictly from the local cache.
+//
+// Behavior:
+//   - Only checks database cache, never queries API
+//   - Cards missing from the cache are skipped and returned as unresolved names
+//   - Any other problem (bad line, sideboard over 15, ...) fails like ParseDecklist
+//   - Useful for validating decks in environments without network access
+//
+// Returns:
+//   - *Decklist: Deck built from every card found in the cache
+//   - []string: Card names not found in the cache, in order of first appearance
+//   - error: Parse errors or database errors
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ParseDecklistOffline(decklist string) (*Decklist, []string, error) {
+	ctx := context.Background()
+	return ParseDecklistOfflineWithContext(ctx, decklist)
+}
+
+// ParseDecklistOfflineWithContext is ParseDecklistOffline with context support.
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ParseDecklistOfflineWithContext(ctx context.Context, decklistString string) (*Decklist, []string, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.parseDecklistOffline(ctx, decklistString)
+}
+
+// ParseDecklistOffline parses a decklist using only this Scryball instance's database.
+//
+// See the package-level ParseDecklistOffline for details.
+func (s *Scryball) ParseDecklistOffline(decklistString string) (*Decklist, []string, error) {
+	ctx := context.Background()
+	return s.ParseDecklistOfflineWithContext(ctx, decklistString)
+}
+
+// ParseDecklistOfflineWithContext parses a decklist using only this Scryball instance's database, with context support.
+func (s *Scryball) ParseDecklistOfflineWithContext(ctx context.Context, decklistString string) (*Decklist, []string, error) {
+	return s.parseDecklistOffline(ctx, decklistString)
+}
+
+func (sb *Scryball) parseDecklistOffline(ctx context.Context, decklistString string) (*Decklist, []string, error) {
+	result, err := sb.parseDecklist(ctx, decklistString, parseOptions{cacheOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return result.decklist, result.unresolved, nil
 }
 
 // parseCardLine extracts quantity and card name from a deck line.
+//
+// Accepts "4 Lightning Bolt", "4x Lightning Bolt", "x4 Lightning Bolt",
+// "Lightning Bolt x4" and a bare "Lightning Bolt" (quantity 1). A trailing
+// Arena-style set code and collector number, "(2ED) 161", is ignored.
 func parseCardLine(line string) (int, string, error) {
-	var quantity int
-	var cardName string
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return 0, "", fmt.Errorf("invalid format: empty line")
+	}
 
-	// Check if line has parentheses for set code
-	parenStart := strings.LastIndex(line, "(")
-	parenEnd := strings.LastIndex(line, ")")
+	// Format with set code: "4 Thoughtcast (J25) 374"
+	line, _, _ = splitPrinting(line)
 
-	if parenStart != -1 && parenEnd != -1 && parenStart < parenEnd {
-		// Format with set code: "4 Thoughtcast (J25) 374"
-		beforeParen := strings.TrimSpace(line[:parenStart])
+	quantity := 1
+	cardName := line
 
-		parts := strings.SplitN(beforeParen, " ", 2)
+	parts := strings.SplitN(line, " ", 2)
+	if q, ok := parseQuantity(parts[0], false); ok {
+		// Quantity first: "4 Lightning Bolt", "4x Lightning Bolt", "x4 Lightning Bolt"
 		if len(parts) < 2 {
 			return 0, "", fmt.Errorf("invalid format: %s", line)
 		}
-
-		q, err := strconv.Atoi(parts[0])
-		if err != nil {
-			return 0, "", fmt.Errorf("invalid quantity: %s", parts[0])
-		}
 		quantity = q
 		cardName = strings.TrimSpace(parts[1])
-
-	} else {
-		// Format without set code: "4 Lightning Bolt"
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) < 2 {
-			return 0, "", fmt.Errorf("invalid format: %s", line)
+	} else if space := strings.LastIndex(line, " "); space != -1 {
+		// Quantity last: "Lightning Bolt x4"
+		if q, ok := parseQuantity(line[space+1:], true); ok {
+			quantity = q
+			cardName = strings.TrimSpace(line[:space])
 		}
+	}
 
-		q, err := strconv.Atoi(parts[0])
-		if err != nil {
-			return 0, "", fmt.Errorf("invalid quantity: %s", parts[0])
-		}
-		quantity = q
-		cardName = strings.TrimSpace(parts[1])
+	if cardName == "" {
+		return 0, "", fmt.Errorf("invalid format: %s", line)
 	}
 
 	return quantity, cardName, nil
 }
 
+// splitPrinting splits a trailing "(SET) number" off a deck line.
+//
+// Returns the rest of the line, the upper cased set code and the collector
+// number. Set code and collector number are empty if the line has no printing.
+func splitPrinting(line string) (string, string, string) {
+	parenStart := strings.LastIndex(line, "(")
+	parenEnd := strings.LastIndex(line, ")")
+	if parenStart == -1 || parenEnd == -1 || parenStart > parenEnd {
+		return line, "", ""
+	}
+
+	setCode := strings.ToUpper(strings.TrimSpace(line[parenStart+1 : parenEnd]))
+	collectorNumber := strings.TrimSpace(line[parenEnd+1:])
+	return strings.TrimSpace(line[:parenStart]), setCode, collectorNumber
+}
+
+// stripComment removes "#" and "//" comment lines and trailing "#" comments.
+//
+// Trailing "//" comments are left in place since " // " also separates the
+// halves of split and double-faced card names, see stripSlashComment.
+func stripComment(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+		return ""
+	}
+	if i := strings.Index(line, " #"); i != -1 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(line)
+}
+
+// stripSlashComment removes a trailing "//" comment from a card name.
+// Returns false if there is no "//" to strip.
+func stripSlashComment(cardName string) (string, bool) {
+	i := strings.Index(cardName, "//")
+	if i == -1 {
+		return cardName, false
+	}
+	name := strings.TrimSpace(cardName[:i])
+	return name, name != ""
+}
+
+// parseQuantity parses "4", "4x" or "x4". When requireX is set, a bare number is rejected.
+func parseQuantity(token string, requireX bool) (int, bool) {
+	digits := token
+	switch {
+	case strings.HasPrefix(digits, "x"), strings.HasPrefix(digits, "X"):
+		digits = digits[1:]
+	case strings.HasSuffix(digits, "x"), strings.HasSuffix(digits, "X"):
+		digits = digits[:len(digits)-1]
+	case requireX:
+		return 0, false
+	}
+
+	q, err := strconv.Atoi(digits)
+	if err != nil || q < 1 || digits[0] == '+' || digits[0] == '-' {
+		return 0, false
+	}
+	return q, true
+}
+
 // NumberOfCards returns the total number of cards in the maindeck.
 //
 // This counts individual cards, so 4 Lightning Bolts = 4 cards.
@@ -342,12 +837,12 @@ func (d *Decklist) NumberOfSideboardCards() int {
 // GetMaindeck returns all maindeck cards as a flat list (including duplicates).
 //
 // Example: If decklist has "4 Lightning Bolt", this returns 4 separate MagicCard instances.
-// Useful for statistical analysis or iterating over every card.
+// Useful for statistical analysis or iterating over every card. Cards are sorted by name.
 func (d *Decklist) GetMaindeck() []*MagicCard {
 	var cards []*MagicCard
 
-	for card, qty := range d.Maindeck {
-		for range qty {
+	for _, card := range sortedCards(d.Maindeck) {
+		for range d.Maindeck[card] {
 			cards = append(cards, card)
 		}
 	}
@@ -358,12 +853,12 @@ func (d *Decklist) GetMaindeck() []*MagicCard {
 // GetSideboard returns all sideboard cards as a flat list (including duplicates).
 //
 // Example: If sideboard has "3 Pyroblast", this returns 3 separate MagicCard instances.
-// Useful for statistical analysis or iterating over every sideboard card.
+// Useful for statistical analysis or iterating over every sideboard card. Cards are sorted by name.
 func (d *Decklist) GetSideboard() []*MagicCard {
 	var cards []*MagicCard
 
-	for card, qty := range d.Sideboard {
-		for range qty {
+	for _, card := range sortedCards(d.Sideboard) {
+		for range d.Sideboard[card] {
 			cards = append(cards, card)
 		}
 	}
@@ -375,101 +870,270 @@ func (d *Decklist) GetSideboard() []*MagicCard {
 //
 // The output can be passed back to ParseDecklist() to recreate the same deck.
 // Format: "4 Lightning Bolt\n3 Mountain\n\nSideboard\n2 Pyroblast"
+//
+// Cards are sorted by name within each section, so the same deck always
+// produces the same text and exports can be diffed.
+//
+// Commanders and Companion, when present, are written in their own sections
+// ahead of a "Deck" header, as Arena does:
+//
+//	Commander
+//	1 Atraxa, Praetors' Voice
+//
+//	Deck
+//	1 Sol Ring
+//
+// See Export for other styles.
 func (d *Decklist) String() string {
+	return d.exportSections(false, d.cardLine)
+}
+
+// ExportFormat is a text style for Decklist.Export.
+type ExportFormat int
+
+const (
+	FormatPlain    ExportFormat = iota // "4 Lightning Bolt" lines with section headers, no printings
+	FormatArena                        // Arena import format, with set codes and collector numbers
+	FormatMTGOText                     // MTGO .txt format, sideboard after a blank line
+)
+
+// Export returns the decklist as text in the given style.
+//
+// Behavior:
+//   - FormatPlain: card names only, with "Commander", "Companion",
+//     "Sideboard" and "Maybeboard" section headers. Requested printings are left out
+//   - FormatArena: always starts the maindeck with a "Deck" header. Each card is
+//     written with its requested printing (see Decklist.Printings), or else its most
+//     recent cached printing on Arena, as "4 Lightning Bolt (STA) 42"
+//   - FormatMTGOText: no headers, the sideboard follows the maindeck after a
+//     blank line. Commanders and Companion are written to the sideboard, as MTGO expects.
+//     MTGO has no maybeboard, so Maybeboard is left out
+//   - Cards are sorted by name within each section
+//
+// Every style can be read back by ParseDecklist or ParseAnyDecklist.
+//
+// Example:
+//
+//	fmt.Print(deck.Export(scryball.FormatArena))
+//	// Deck
+//	// 4 Lightning Bolt (STA) 42
+//	// 20 Mountain (M21) 270
+func (d *Decklist) Export(format ExportFormat) string {
+	switch format {
+	case FormatArena:
+		return d.exportSections(true, d.arenaLine)
+	case FormatMTGOText:
+		return d.exportMTGOText()
+	default:
+		return d.exportSections(false, plainLine)
+	}
+}
+
+// exportSections writes the decklist with section headers, formatting each card with line.
+// The "Deck" header is written when forced or when there are commanders or a companion.
+func (d *Decklist) exportSections(deckHeader bool, line func(int, *MagicCard) string) string {
 	var sb strings.Builder
 
-	for card, qty := range d.Maindeck {
-		sb.WriteString(fmt.Sprintf("%d %s\n", qty, card.Name))
+	if len(d.Commanders) > 0 {
+		sb.WriteString("Commander\n")
+		for _, card := range d.Commanders {
+			sb.WriteString(line(1, card))
+		}
+		sb.WriteString("\n")
+	}
+	if d.Companion != nil {
+		sb.WriteString("Companion\n" + line(1, d.Companion) + "\n")
+	}
+	if deckHeader || len(d.Commanders) > 0 || d.Companion != nil {
+		sb.WriteString("Deck\n")
+	}
+
+	for _, card := range sortedCards(d.Maindeck) {
+		sb.WriteString(line(d.Maindeck[card], card))
 	}
 
 	if len(d.Sideboard) > 0 {
 		sb.WriteString("\nSideboard\n")
-		for card, qty := range d.Sideboard {
-			sb.WriteString(fmt.Sprintf("%d %s\n", qty, card.Name))
+		for _, card := range sortedCards(d.Sideboard) {
+			sb.WriteString(line(d.Sideboard[card], card))
+		}
+	}
+
+	if len(d.Maybeboard) > 0 {
+		sb.WriteString("\nMaybeboard\n")
+		for _, card := range sortedCards(d.Maybeboard) {
+			sb.WriteString(line(d.Maybeboard[card], card))
 		}
 	}
 
 	return sb.String()
 }
 
-// ValidateDecklist checks if a decklist meets format requirements, returns nil if legal.
-//
-// Set maxCards to 0 for no maindeck limit.
-//
-// See d.ValidateConstructed()... etc.
-func (d *Decklist) ValidateDecklist(minCards, maxCards, maxSideboard int) error {
-	mainTotal := d.NumberOfCards()
-	sideTotal := d.NumberOfSideboardCards()
-
-	if mainTotal < minCards {
-		return fmt.Errorf("maindeck has %d cards, minimum is %d", mainTotal, minCards)
+// sideboardWithCommanders returns the sideboard with the commanders and companion
+// added, for formats without sections of their own for them, like MTGO's.
+func (d *Decklist) sideboardWithCommanders() map[*MagicCard]int {
+	sideboard := make(map[*MagicCard]int, len(d.Sideboard)+len(d.Commanders)+1)
+	for card, qty := range d.Sideboard {
+		sideboard[card] = qty
 	}
-
-	if maxCards > 0 && mainTotal > maxCards {
-		return fmt.Errorf("maindeck has %d cards, maximum is %d", mainTotal, maxCards)
+	for _, card := range d.Commanders {
+		addCardToMap(card, 1, sideboard)
 	}
-
-	if sideTotal > maxSideboard {
-		return fmt.Errorf("sideboard has %d cards, maximum is %d", sideTotal, maxSideboard)
+	if d.Companion != nil {
+		addCardToMap(d.Companion, 1, sideboard)
 	}
+	return sideboard
+}
 
-	// Count total copies across main and sideboard
-	totalCopies := make(map[string]int)
-	for card, qty := range d.Maindeck {
-		totalCopies[card.Name] += qty
+// exportMTGOText writes the decklist in MTGO's .txt format.
+func (d *Decklist) exportMTGOText() string {
+	var sb strings.Builder
+
+	for _, card := range sortedCards(d.Maindeck) {
+		sb.WriteString(plainLine(d.Maindeck[card], card))
 	}
-	for card, qty := range d.Sideboard {
-		totalCopies[card.Name] += qty
+
+	sideboard := d.sideboardWithCommanders()
+	if len(sideboard) > 0 {
+		sb.WriteString("\n")
+		for _, card := range sortedCards(sideboard) {
+			sb.WriteString(plainLine(sideboard[card], card))
+		}
 	}
 
-	for cardName, total := range totalCopies {
-		if total > 4 && !isBasicLandName(cardName) && !isSpecialCardName(cardName) {
-			return fmt.Errorf("total of %d copies of %s between maindeck and sideboard, maximum is 4", total, cardName)
+	return sb.String()
+}
+
+// plainLine formats a card for text export without its printing.
+func plainLine(qty int, card *MagicCard) string {
+	return fmt.Sprintf("%d %s\n", qty, card.Name)
+}
+
+// arenaLine formats a card for Arena export, falling back to its most recent
+// Arena printing when no printing was requested.
+func (d *Decklist) arenaLine(qty int, card *MagicCard) string {
+	if _, ok := d.Printings[card]; ok {
+		return d.cardLine(qty, card)
+	}
+	for _, printing := range card.Printings {
+		if slices.Contains(printing.Games, "arena") && printing.CollectorNumber != "" {
+			return fmt.Sprintf("%d %s (%s) %s\n", qty, card.Name, strings.ToUpper(printing.SetCode), printing.CollectorNumber)
 		}
 	}
+	return plainLine(qty, card)
+}
 
-	return nil
+// ValidateDecklist checks if a decklist meets format requirements, returns nil if legal.
+//
+// Set maxCards to 0 for no maindeck limit. Enforces the 4-copy rule (except basic
+// lands and special cards ie. Relentless Rats).
+//
+// Every problem found is returned in a *ValidationError, not just the first.
+//
+// See d.ValidateConstructed()... etc. and d.Validate() for custom formats.
+func (d *Decklist) ValidateDecklist(minCards, maxCards, maxSideboard int) error {
+	return d.Validate(DeckSizeRule(minCards, maxCards), SideboardSizeRule(maxSideboard), CopyLimitRule(4))
 }
 
 // ValidateConstructed validates the deck for Constructed formats (60+ cards, 15 card sideboard).
 //
 // Enforces the 4-copy rule (except basic lands and special cards ie. Relentless Rats)
 //
-// Minimum 60 cards in maindeck, maximum 15 in sideboard.
+// Minimum 60 cards in maindeck, maximum 15 in sideboard. See ConstructedRules.
 func (d *Decklist) ValidateConstructed() error {
-	d.ValidateFourOfs()
-	return d.ValidateDecklist(60, 0, 15)
+	return ConstructedRules.Validate(d)
 }
 
 // ValidateLimited validates the deck for Limited formats like Draft or Sealed (40+ cards).
 //
-// Minimum 40 cards in maindeck, no maximum in the sideboard.
+// Minimum 40 cards in maindeck, no maximum in the sideboard. See LimitedRules.
 func (d *Decklist) ValidateLimited() error {
-	return d.ValidateDecklist(40, 0, 0)
+	return LimitedRules.Validate(d)
 }
 
-func (d *Decklist) ValidateSingleton() error {
-	for card, qty := range d.Maindeck {
-		if qty > 1 && !isBasicLand(card) && !isSpecialCard(card) {
-			return fmt.Errorf("maindeck has %d copies of %s, maximum is 1", qty, card.Name)
+// ValidatePauper validates the deck for Pauper (60+ cards, 15 card sideboard, commons only).
+//
+// Enforces the 4-copy rule like ValidateConstructed(). Every card in the maindeck
+// and sideboard must have at least one common printing among its cached
+// Printings, so a card printed at common once is allowed even if its other
+// printings are rare. Cards banned or not legal in Pauper are rejected when
+// legality data is available.
+func (d *Decklist) ValidatePauper() error {
+	return slices.Concat(ConstructedRules, ValidationRules{LegalityRule("pauper"), CommonPrintingRule()}).Validate(d)
+}
+
+// ValidateVintage validates the deck for Vintage (60+ cards, 15 card sideboard).
+//
+// Enforces the 4-copy rule like ValidateConstructed(), and using each card's
+// cached legalities:
+//   - Restricted cards are limited to one copy between maindeck and sideboard
+//   - Banned cards (ante, conspiracies, ...) are rejected
+//
+// Cards without legality data are only held to the 4-copy rule.
+func (d *Decklist) ValidateVintage() error {
+	return slices.Concat(ConstructedRules, ValidationRules{LegalityRule("vintage")}).Validate(d)
+}
+
+// ValidatePenny validates the deck for Penny Dreadful (60+ cards, 15 card sideboard).
+//
+// Enforces the 4-copy rule like ValidateConstructed(). Cards that are not legal
+// in the current Penny Dreadful season are rejected using each card's cached
+// legalities, see MagicCard.IsPennyLegal. Cards without legality data are only
+// held to the 4-copy rule.
+func (d *Decklist) ValidatePenny() error {
+	return slices.Concat(ConstructedRules, ValidationRules{LegalityRule(string(FormatPenny))}).Validate(d)
+}
+
+func hasCommonPrinting(card *MagicCard) bool {
+	for _, printing := range card.Printings {
+		if printing.Rarity == RarityCommon {
+			return true
 		}
 	}
-	return nil
+	return false
+}
+
+// ValidateSingleton checks the maindeck has at most one copy of each card
+// (except basic lands and special cards ie. Relentless Rats).
+//
+// Every offending card is reported in a *ValidationError.
+func (d *Decklist) ValidateSingleton() error {
+	return d.validateMaindeckCopies(1)
 }
 
+// ValidateFourOfs checks the maindeck has at most four copies of each card
+// (except basic lands and special cards ie. Relentless Rats).
+//
+// Every offending card is reported in a *ValidationError.
 func (d *Decklist) ValidateFourOfs() error {
-	for card, qty := range d.Maindeck {
-		if qty > 4 && !isBasicLand(card) && !isSpecialCard(card) {
-			return fmt.Errorf("maindeck has %d copies of %s, maximum is 4", qty, card.Name)
+	return d.validateMaindeckCopies(4)
+}
+
+func (d *Decklist) validateMaindeckCopies(max int) error {
+	var found violations
+	for _, card := range sortedCards(d.Maindeck) {
+		qty := d.Maindeck[card]
+		if exceeds, limit := exceedsCopyLimit(card, qty, max); exceeds {
+			found.add(Violation{Rule: RuleCopyLimit, Card: card, Quantity: qty, Limit: limit}, "maindeck has %d copies of %s, maximum is %d", qty, card.Name, limit)
 		}
 	}
-	return nil
+	return found.err()
 }
 
+// isBasicLand reports whether card has the Basic supertype on its front face's
+// type line, "Basic Snow Land — Forest" included. Cards without a type line, like
+// ones built from a name only, are looked up by name with isBasicLandName.
 func isBasicLand(card *MagicCard) bool {
-	return isBasicLandName(card.Name)
+	if card.TypeLine == "" {
+		return isBasicLandName(card.Name)
+	}
+	typeLine, _, _ := strings.Cut(card.TypeLine, " // ")
+	types, _, _ := strings.Cut(typeLine, " — ")
+	fields := strings.Fields(types)
+	return slices.Contains(fields, "Basic") && slices.Contains(fields, "Land")
 }
 
+// isBasicLandName reports whether name is one of the basic lands printed so far.
 func isBasicLandName(name string) bool {
 	basicLands := []string{
 		"Plains", "Island", "Swamp", "Mountain", "Forest",
@@ -480,28 +1144,3 @@ func isBasicLandName(name string) bool {
 
 	return slices.Contains(basicLands, name)
 }
-
-func isSpecialCard(card *MagicCard) bool {
-	return isSpecialCardName(card.Name)
-}
-
-// TODO: a better impl than this.
-func isSpecialCardName(name string) bool {
-	// Cards that can have any number in deck
-	specialCards := []string{
-		"Relentless Rats",
-		"Shadowborn Apostle",
-		"Rat Colony",
-		"Persistent Petitioners",
-		"Dragon's Approach",
-		"Seven Dwarves", // Can have up to 7
-		"Nazgûl",        // Can have up to 9
-	}
-
-	for _, special := range specialCards {
-		if strings.EqualFold(name, special) {
-			return true
-		}
-	}
-	return false
-}
@@ -2,6 +2,7 @@ package scryball
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -193,6 +194,69 @@ func TestValidateLimited(t *testing.T) {
 	}
 }
 
+func TestValidatePauper(t *testing.T) {
+	mountain := &MagicCard{
+		Card:      &client.Card{Name: "Mountain"},
+		Printings: []Printing{{ID: "mountain-1", Rarity: "common"}},
+	}
+	// rare in its latest printing, common in an older one
+	bolt := &MagicCard{
+		Card: &client.Card{Name: "Lightning Bolt"},
+		Printings: []Printing{
+			{ID: "bolt-2", Rarity: "rare"},
+			{ID: "bolt-1", Rarity: "common"},
+		},
+	}
+	goyf := &MagicCard{
+		Card:      &client.Card{Name: "Tarmogoyf"},
+		Printings: []Printing{{ID: "goyf-1", Rarity: "rare"}, {ID: "goyf-2", Rarity: "mythic"}},
+	}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 56)
+	if err := deck.ValidatePauper(); err != nil {
+		t.Errorf("Expected deck with a common printing of every card to be valid, got %v", err)
+	}
+
+	deck.AddSideboardCard(goyf, 1)
+	if err := deck.ValidatePauper(); err == nil || !strings.Contains(err.Error(), "Tarmogoyf") {
+		t.Errorf("Expected Tarmogoyf to be rejected, got %v", err)
+	}
+
+	deck.RemoveSideboardCard(goyf, 1)
+	bolt.Legalities = map[string]string{"pauper": "banned"}
+	if err := deck.ValidatePauper(); err == nil {
+		t.Error("Expected banned card to be rejected")
+	}
+}
+
+func TestValidateVintage(t *testing.T) {
+	island := &MagicCard{Card: &client.Card{Name: "Island", Legalities: map[string]string{"vintage": "legal"}}}
+	lotus := &MagicCard{Card: &client.Card{Name: "Black Lotus", Legalities: map[string]string{"vintage": "restricted"}}}
+	force := &MagicCard{Card: &client.Card{Name: "Force of Will", Legalities: map[string]string{"vintage": "legal"}}}
+	contract := &MagicCard{Card: &client.Card{Name: "Contract from Below", Legalities: map[string]string{"vintage": "banned"}}}
+
+	deck := NewDecklist()
+	deck.AddCard(lotus, 1)
+	deck.AddCard(force, 4)
+	deck.AddCard(island, 55)
+	if err := deck.ValidateVintage(); err != nil {
+		t.Errorf("Expected one restricted card to be valid, got %v", err)
+	}
+
+	deck.AddSideboardCard(lotus, 1)
+	if err := deck.ValidateVintage(); err == nil || !strings.Contains(err.Error(), "restricted") {
+		t.Errorf("Expected second Black Lotus in sideboard to be rejected, got %v", err)
+	}
+
+	deck.RemoveSideboardCard(lotus, 1)
+	deck.AddSideboardCard(contract, 1)
+	if err := deck.ValidateVintage(); err == nil || !strings.Contains(err.Error(), "Contract from Below") {
+		t.Errorf("Expected banned card to be rejected, got %v", err)
+	}
+}
+
 func TestValidateDecklist_FourCopyRule(t *testing.T) {
 	// Create a deck with enough cards to pass minimum count
 	testDeck := &Decklist{
@@ -262,6 +326,28 @@ func TestIsBasicLand(t *testing.T) {
 	}
 }
 
+func TestIsBasicLandFromTypeLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		typeLine string
+		expected bool
+	}{
+		{"Snow-Covered Forest", "Basic Snow Land — Forest", true},
+		{"Future Basic", "Basic Land — Future", true},
+		{"Arctic Treeline", "Snow Land — Forest Plains", false},
+		{"Mountain", "Land", false}, // type line wins over the name
+		{"Plains", "", true},        // no type line, like cards built from a name only
+		{"Dryad Arbor", "Land Creature — Forest Dryad", false},
+	}
+
+	for _, tt := range tests {
+		card := &MagicCard{Card: testAPICard(tt.name+"-oracle", tt.name+"-1", tt.name, tt.typeLine)}
+		if result := isBasicLand(card); result != tt.expected {
+			t.Errorf("isBasicLand(%s, %q) = %v, expected %v", tt.name, tt.typeLine, result, tt.expected)
+		}
+	}
+}
+
 func TestIsSpecialCard(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -341,10 +427,16 @@ func TestParseCardLine(t *testing.T) {
 		{"4 Lightning Bolt (2ED) 161", 4, "Lightning Bolt", false},
 		{"2 Counterspell (ICE) 64", 2, "Counterspell", false},
 		{"20 Mountain", 20, "Mountain", false},
-		{"Lightning Bolt", 0, "", true},              // No quantity
-		{"4", 0, "", true},                           // No card name
-		{"", 0, "", true},                            // Empty line
-		{"not a number Lightning Bolt", 0, "", true}, // Invalid quantity
+		{"4x Lightning Bolt", 4, "Lightning Bolt", false},
+		{"4X Lightning Bolt", 4, "Lightning Bolt", false},
+		{"x4 Lightning Bolt", 4, "Lightning Bolt", false},
+		{"Lightning Bolt x4", 4, "Lightning Bolt", false},
+		{"3x Counterspell (ICE) 64", 3, "Counterspell", false},
+		{"Lightning Bolt", 1, "Lightning Bolt", false}, // No quantity implies 1
+		{"Borrowing 100,000 Arrows", 1, "Borrowing 100,000 Arrows", false},
+		{"4", 0, "", true},  // No card name
+		{"4x", 0, "", true}, // No card name
+		{"", 0, "", true},   // Empty line
 	}
 
 	for _, tt := range tests {
@@ -525,3 +617,491 @@ func TestParseDecklist_InstanceIndependence(t *testing.T) {
 		t.Error("Expected different card instances for independent Scryball instances")
 	}
 }
+
+func TestParseSectionHeader(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected decklistSection
+		isHeader bool
+	}{
+		{"Deck", sectionMaindeck, true},
+		{"Mainboard (60)", sectionMaindeck, true},
+		{"Sideboard", sectionSideboard, true},
+		{"Sideboard (15)", sectionSideboard, true},
+		{"SIDEBOARD:", sectionSideboard, true},
+		{"Commander (1)", sectionCommander, true},
+		{"Companion", sectionCompanion, true},
+		{"Maybeboard", sectionMaybeboard, true},
+		{"Considering (12)", sectionMaybeboard, true},
+		{"4 Lightning Bolt", 0, false},
+		{"Sideboard (fifteen)", 0, false},
+	}
+
+	for _, tt := range tests {
+		section, ok := parseSectionHeader(tt.input)
+		if ok != tt.isHeader {
+			t.Errorf("parseSectionHeader(%q) header = %v, expected %v", tt.input, ok, tt.isHeader)
+			continue
+		}
+		if ok && section != tt.expected {
+			t.Errorf("parseSectionHeader(%q) = %s, expected %s", tt.input, section, tt.expected)
+		}
+	}
+}
+
+func TestParseDecklistSectionHeaders(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("atraxa-oracle", "atraxa-1", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror"))
+	insertTestCard(t, sb, testAPICard("solring-oracle", "solring-1", "Sol Ring", "Artifact"))
+	insertTestCard(t, sb, testAPICard("forest-oracle", "forest-1", "Forest", "Basic Land — Forest"))
+	insertTestCard(t, sb, testAPICard("lurrus-oracle", "lurrus-1", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare"))
+	insertTestCard(t, sb, testAPICard("negate-oracle", "negate-1", "Negate", "Instant"))
+
+	decklistString := `Commander (1)
+1 Atraxa, Praetors' Voice
+
+Mainboard (98)
+1 Sol Ring
+97 Forest
+
+Companion (1)
+1 Lurrus of the Dream-Den
+
+Sideboard (2)
+2 Negate
+
+Maybeboard
+1 Negate
+`
+
+	deck, err := sb.ParseDecklist(decklistString)
+	if err != nil {
+		t.Fatalf("Failed to parse sectioned decklist: %v", err)
+	}
+	if deck.NumberOfCards() != 98 {
+		t.Errorf("Expected 98 maindeck cards (commander is separate), got %d", deck.NumberOfCards())
+	}
+	if deck.NumberOfSideboardCards() != 2 {
+		t.Errorf("Expected 2 sideboard cards, got %d", deck.NumberOfSideboardCards())
+	}
+	if len(deck.Commanders) != 1 || deck.Commanders[0].Name != "Atraxa, Praetors' Voice" {
+		t.Errorf("Expected Atraxa as the only commander, got %v", deck.Commanders)
+	}
+	if deck.Companion == nil || deck.Companion.Name != "Lurrus of the Dream-Den" {
+		t.Errorf("Expected Lurrus as companion, got %v", deck.Companion)
+	}
+
+	str := deck.String()
+	for _, want := range []string{"Commander\n1 Atraxa, Praetors' Voice\n", "Companion\n1 Lurrus of the Dream-Den\n", "Deck\n"} {
+		if !strings.Contains(str, want) {
+			t.Errorf("String output missing %q, got:\n%s", want, str)
+		}
+	}
+
+	reparsed, err := sb.ParseDecklist(str)
+	if err != nil {
+		t.Fatalf("Failed to reparse String() output: %v", err)
+	}
+	if len(reparsed.Commanders) != 1 || reparsed.Companion == nil || reparsed.NumberOfCards() != 98 {
+		t.Errorf("String() round trip lost commander, companion, or maindeck cards")
+	}
+
+	if _, err := sb.ParseDecklist("Sideboard\n1 Negate\nSideboard (1)\n1 Negate"); err == nil {
+		t.Error("Expected error for repeated Sideboard section")
+	}
+}
+
+func TestParseDecklistMaybeboard(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("negate", "negate-1", "Negate", "Instant"))
+	insertTestCard(t, sb, testAPICard("gust", "gust-1", "Aether Gust", "Instant"))
+
+	for _, header := range []string{"Maybeboard", "Considering (3)"} {
+		deck, unresolved, err := sb.ParseDecklistOffline("Deck\n4 Lightning Bolt\n\nSideboard\n2 Negate\n\n" + header + "\n2 Aether Gust\n1 Negate\n")
+		if err != nil || len(unresolved) != 0 {
+			t.Fatalf("%s: ParseDecklistOffline failed: %v %v", header, err, unresolved)
+		}
+		if deck.NumberOfCards() != 4 || deck.NumberOfSideboardCards() != 2 {
+			t.Errorf("%s: maybeboard counted into the deck: %d maindeck, %d sideboard", header, deck.NumberOfCards(), deck.NumberOfSideboardCards())
+		}
+		if got := describeZone(deck.Maybeboard); got != "2 Aether Gust, 1 Negate" {
+			t.Errorf("%s: Maybeboard = %s", header, got)
+		}
+
+		str := deck.String()
+		if !strings.HasSuffix(str, "\nMaybeboard\n2 Aether Gust\n1 Negate\n") {
+			t.Errorf("%s: String() should end with the maybeboard, got:\n%s", header, str)
+		}
+		if strings.Contains(deck.Export(FormatMTGOText), "Aether Gust") {
+			t.Errorf("%s: MTGO export should leave out the maybeboard", header)
+		}
+		reparsed, _, err := sb.ParseDecklistOffline(str)
+		if err != nil || describeZone(reparsed.Maybeboard) != "2 Aether Gust, 1 Negate" {
+			t.Errorf("%s: String() round trip lost the maybeboard: %v", header, err)
+		}
+	}
+}
+
+// describeZone lists a zone as "qty name" sorted by name.
+func describeZone(zone map[*MagicCard]int) string {
+	var parts []string
+	for _, card := range sortedCards(zone) {
+		parts = append(parts, fmt.Sprintf("%d %s", zone[card], card.Name))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestStripComment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"# Burn package", ""},
+		{"// Burn package", ""},
+		{"4 Lightning Bolt # best burn spell", "4 Lightning Bolt"},
+		{"Sideboard # vs control", "Sideboard"},
+		{"1 Fire // Ice", "1 Fire // Ice"},
+		{"4 Lightning Bolt", "4 Lightning Bolt"},
+	}
+
+	for _, tt := range tests {
+		if got := stripComment(tt.input); got != tt.expected {
+			t.Errorf("stripComment(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseDecklistComments(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("fireice-oracle", "fireice-1", "Fire // Ice", "Instant // Instant"))
+	insertTestCard(t, sb, testAPICard("negate-oracle", "negate-1", "Negate", "Instant"))
+
+	decklistString := `# Izzet tempo, for the league
+// maindeck
+4 Lightning Bolt # best burn spell
+2 Fire // Ice
+1 Lightning Bolt // one more
+
+Sideboard # vs control
+2 Negate
+`
+
+	deck, err := sb.ParseDecklist(decklistString)
+	if err != nil {
+		t.Fatalf("Failed to parse commented decklist: %v", err)
+	}
+	if deck.NumberOfCards() != 7 {
+		t.Errorf("Expected 7 maindeck cards, got %d", deck.NumberOfCards())
+	}
+	if len(deck.Maindeck) != 2 {
+		t.Errorf("Expected 2 unique maindeck cards, got %d", len(deck.Maindeck))
+	}
+	if deck.NumberOfSideboardCards() != 2 {
+		t.Errorf("Expected 2 sideboard cards, got %d", deck.NumberOfSideboardCards())
+	}
+}
+
+func TestParseDecklistMTGOSideboardPrefix(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"))
+	insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+
+	decklistString := `4 Lightning Bolt
+20 Mountain
+SB: 3 Pyroblast
+sb: 1 Pyroblast
+`
+
+	deck, err := sb.ParseDecklist(decklistString)
+	if err != nil {
+		t.Fatalf("Failed to parse MTGO decklist: %v", err)
+	}
+	if deck.NumberOfCards() != 24 {
+		t.Errorf("Expected 24 maindeck cards, got %d", deck.NumberOfCards())
+	}
+	if deck.NumberOfSideboardCards() != 4 {
+		t.Errorf("Expected 4 sideboard cards, got %d", deck.NumberOfSideboardCards())
+	}
+	if len(deck.Sideboard) != 1 {
+		t.Errorf("Expected SB: lines for the same card to merge, got %d entries", len(deck.Sideboard))
+	}
+}
+
+func TestParseDecklistLenient(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+
+	decklistString := `4 Lightning Bolt
+4
+
+Sideboard
+12 Pyroblast
+4 Pyroblast
+`
+
+	deck, problems, err := sb.ParseDecklistLenient(decklistString)
+	if err != nil {
+		t.Fatalf("ParseDecklistLenient failed: %v", err)
+	}
+	if deck.NumberOfCards() != 4 {
+		t.Errorf("Expected 4 maindeck cards, got %d", deck.NumberOfCards())
+	}
+	if deck.NumberOfSideboardCards() != 12 {
+		t.Errorf("Expected 12 sideboard cards, got %d", deck.NumberOfSideboardCards())
+	}
+
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 problems, got %d: %v", len(problems), problems)
+	}
+	if problems[0].Line != 2 || problems[0].CardName != "" {
+		t.Errorf("Expected unparseable line 2, got %+v", problems[0])
+	}
+	if problems[1].Line != 6 || problems[1].CardName != "Pyroblast" || !strings.Contains(problems[1].Reason, "exceeds 15") {
+		t.Errorf("Expected sideboard overflow on line 6, got %+v", problems[1])
+	}
+	if problems[1].Error() != "line 6 (Pyroblast): sideboard exceeds 15 cards (has 16)" {
+		t.Errorf("Unexpected error text: %s", problems[1].Error())
+	}
+
+	// strict parsing stops at the first problem
+	if _, err := sb.ParseDecklist(decklistString); err == nil {
+		t.Error("Expected strict ParseDecklist to fail")
+	}
+
+	// section errors name the same line as the DecklistLineError
+	_, problems, err = sb.ParseDecklistLenient("Sideboard\n1 Pyroblast\n\nSideboard\n1 Pyroblast\n")
+	if err != nil {
+		t.Fatalf("ParseDecklistLenient failed: %v", err)
+	}
+	if len(problems) != 1 || problems[0].Error() != "line 4: cannot have Sideboard section twice, found on line 4" {
+		t.Errorf("Expected a duplicate section on line 4, got %v", problems)
+	}
+}
+
+func TestParseDecklistOffline(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+
+	decklistString := `4 Lightning Bolt
+4 Chain Lightning
+2 Chain Lightning
+
+Sideboard
+3 Pyroblast
+`
+
+	deck, unresolved, err := sb.ParseDecklistOffline(decklistString)
+	if err != nil {
+		t.Fatalf("ParseDecklistOffline failed: %v", err)
+	}
+	if deck.NumberOfCards() != 4 {
+		t.Errorf("Expected only the cached 4 Lightning Bolt in maindeck, got %d", deck.NumberOfCards())
+	}
+	if deck.NumberOfSideboardCards() != 0 {
+		t.Errorf("Expected empty sideboard, got %d", deck.NumberOfSideboardCards())
+	}
+
+	expected := []string{"Chain Lightning", "Pyroblast"}
+	if strings.Join(unresolved, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected unresolved %v, got %v", expected, unresolved)
+	}
+
+	if _, _, err := sb.ParseDecklistOffline("4\n"); err == nil {
+		t.Error("Expected parse errors to still fail in offline mode")
+	}
+}
+
+func TestParseDecklistRequestedPrintings(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	bolt := testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")
+	bolt.CollectorNumber = "161"
+	sta := testAPICard("bolt-oracle", "bolt-2", "Lightning Bolt", "Instant")
+	sta.Set = "sta"
+	sta.CollectorNumber = "42"
+	insertTestCard(t, sb, bolt, sta)
+	insertTestCard(t, sb, testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"))
+	insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+
+	decklistString := `4 Lightning Bolt (sta) 42
+20 Mountain
+
+Sideboard
+3 Pyroblast (ICE) 212
+`
+
+	deck, err := sb.ParseDecklist(decklistString)
+	if err != nil {
+		t.Fatalf("Failed to parse decklist: %v", err)
+	}
+
+	var boltCard, pyroCard, mountainCard *MagicCard
+	for card := range deck.Maindeck {
+		switch card.Name {
+		case "Lightning Bolt":
+			boltCard = card
+		case "Mountain":
+			mountainCard = card
+		}
+	}
+	for card := range deck.Sideboard {
+		pyroCard = card
+	}
+
+	requested, ok := deck.Printings[boltCard]
+	if !ok {
+		t.Fatal("Expected a requested printing for Lightning Bolt")
+	}
+	if requested.SetCode != "STA" || requested.CollectorNumber != "42" {
+		t.Errorf("Expected (STA) 42, got (%s) %s", requested.SetCode, requested.CollectorNumber)
+	}
+	if requested.Printing == nil || requested.Printing.ID != "bolt-2" {
+		t.Errorf("Expected cached printing bolt-2, got %+v", requested.Printing)
+	}
+
+	// requested but not cached: the set and number are still kept
+	requested, ok = deck.Printings[pyroCard]
+	if !ok || requested.SetCode != "ICE" || requested.CollectorNumber != "212" || requested.Printing != nil {
+		t.Errorf("Expected uncached (ICE) 212 for Pyroblast, got %+v", requested)
+	}
+
+	if _, ok := deck.Printings[mountainCard]; ok {
+		t.Error("Expected no requested printing for Mountain")
+	}
+
+	out := deck.String()
+	if !strings.Contains(out, "4 Lightning Bolt (STA) 42\n") || !strings.Contains(out, "3 Pyroblast (ICE) 212\n") {
+		t.Errorf("Expected requested printings in String(), got:\n%s", out)
+	}
+	if !strings.Contains(out, "20 Mountain\n") {
+		t.Errorf("Expected plain line for Mountain, got:\n%s", out)
+	}
+}
+
+func TestDecklistStringIsSorted(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"))
+	insertTestCard(t, sb, testAPICard("chain-oracle", "chain-1", "Chain Lightning", "Sorcery"))
+	insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+	insertTestCard(t, sb, testAPICard("blast-oracle", "blast-1", "Smash to Smithereens", "Instant"))
+
+	deck, err := sb.ParseDecklist(`20 Mountain
+4 Lightning Bolt
+4 Chain Lightning
+
+Sideboard
+3 Smash to Smithereens
+2 Pyroblast
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse decklist: %v", err)
+	}
+
+	expected := `4 Chain Lightning
+4 Lightning Bolt
+20 Mountain
+
+Sideboard
+2 Pyroblast
+3 Smash to Smithereens
+`
+	for range 10 {
+		if got := deck.String(); got != expected {
+			t.Fatalf("Expected sorted output:\n%s\ngot:\n%s", expected, got)
+		}
+	}
+
+	maindeck := deck.GetMaindeck()
+	if maindeck[0].Name != "Chain Lightning" || maindeck[len(maindeck)-1].Name != "Mountain" {
+		t.Errorf("Expected GetMaindeck sorted by name, got %s first and %s last", maindeck[0].Name, maindeck[len(maindeck)-1].Name)
+	}
+}
+
+func TestDecklistExport(t *testing.T) {
+	bolt := &MagicCard{
+		Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"),
+		Printings: []Printing{
+			{SetCode: "sld", CollectorNumber: "1000", Games: []string{"paper"}},
+			{SetCode: "sta", CollectorNumber: "42", Games: []string{"paper", "arena"}},
+		},
+	}
+	mountain := &MagicCard{
+		Card:      testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"),
+		Printings: []Printing{{SetCode: "m21", CollectorNumber: "270", Games: []string{"arena"}}},
+	}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+	lurrus := &MagicCard{Card: testAPICard("lurrus-oracle", "lurrus-1", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare")}
+
+	deck := &Decklist{
+		Maindeck:  map[*MagicCard]int{bolt: 4, mountain: 20},
+		Sideboard: map[*MagicCard]int{pyro: 3},
+		Companion: lurrus,
+		Printings: map[*MagicCard]RequestedPrinting{mountain: {SetCode: "ZEN", CollectorNumber: "234"}},
+	}
+
+	tests := []struct {
+		format ExportFormat
+		want   string
+	}{
+		{FormatPlain, `Companion
+1 Lurrus of the Dream-Den
+
+Deck
+4 Lightning Bolt
+20 Mountain
+
+Sideboard
+3 Pyroblast
+`},
+		{FormatArena, `Companion
+1 Lurrus of the Dream-Den
+
+Deck
+4 Lightning Bolt (STA) 42
+20 Mountain (ZEN) 234
+
+Sideboard
+3 Pyroblast
+`},
+		{FormatMTGOText, `4 Lightning Bolt
+20 Mountain
+
+1 Lurrus of the Dream-Den
+3 Pyroblast
+`},
+	}
+
+	for _, tt := range tests {
+		if got := deck.Export(tt.format); got != tt.want {
+			t.Errorf("Export(%d):\nexpected:\n%s\ngot:\n%s", tt.format, tt.want, got)
+		}
+	}
+
+	deck.Companion = nil
+	if got := deck.Export(FormatArena); !strings.HasPrefix(got, "Deck\n") {
+		t.Errorf("Expected Arena export to start with a Deck header, got:\n%s", got)
+	}
+	if got := deck.Export(FormatPlain); !strings.HasPrefix(got, "4 Lightning Bolt\n") {
+		t.Errorf("Expected plain export without headers, got:\n%s", got)
+	}
+}
@@ -0,0 +1,198 @@
+package scryball
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CardChange is the difference in copies of one card between two decklists.
+type CardChange struct {
+	Card   *MagicCard
+	Before int // Copies in the first decklist, 0 if added
+	After  int // Copies in the second decklist, 0 if removed
+}
+
+// Delta returns the change in copies, negative when copies were removed.
+func (c CardChange) Delta() int {
+	return c.After - c.Before
+}
+
+// ZoneDiff lists the changes to one zone (maindeck or sideboard), each sorted by card name.
+type ZoneDiff struct {
+	Added   []CardChange // Cards only in the second decklist
+	Removed []CardChange // Cards only in the first decklist
+	Changed []CardChange // Cards in both with a different quantity
+}
+
+// Empty reports whether the zone is unchanged.
+func (z ZoneDiff) Empty() bool {
+	return len(z.Added) == 0 && len(z.Removed) == 0 && len(z.Changed) == 0
+}
+
+// DecklistDiff is the result of DiffDecklists.
+type DecklistDiff struct {
+	Maindeck  ZoneDiff
+	Sideboard ZoneDiff
+}
+
+// Empty reports whether both decklists have the same maindeck and sideboard.
+func (d DecklistDiff) Empty() bool {
+	return d.Maindeck.Empty() && d.Sideboard.Empty()
+}
+
+// DiffDecklists compares the maindeck and sideboard of two decklists.
+//
+// Behavior:
+//   - Cards are matched by oracle ID, so different printings count as the same card
+//   - A card moved between zones shows up as removed from one and added to the other
+//   - Commanders, Companion and Maybeboard are not compared
+//
+// Example:
+//
+//	diff := scryball.DiffDecklists(lastWeek, thisWeek)
+//	for _, change := range diff.Maindeck.Changed {
+//		fmt.Printf("%+d %s\n", change.Delta(), change.Card.Name)
+//	}
+func DiffDecklists(a, b *Decklist) DecklistDiff {
+	return DecklistDiff{
+		Maindeck:  diffZones(a.Maindeck, b.Maindeck),
+		Sideboard: diffZones(a.Sideboard, b.Sideboard),
+	}
+}
+
+// FormatDeckDiff describes a diff on one line for changelogs and chat bots:
+//
+//	+2 Counterspell, -1 Negate (side: +1 Aether Gust)
+//
+// Behavior:
+//   - Cards gaining copies come first, then cards losing copies, each sorted by name
+//   - Sideboard changes follow in parentheses, or alone as "side: ..." if the maindeck is unchanged
+//   - Returns "no changes" for an empty diff
+func FormatDeckDiff(diff DecklistDiff) string {
+	main, side := formatZoneDiff(diff.Maindeck), formatZoneDiff(diff.Sideboard)
+	switch {
+	case main == "" && side == "":
+		return "no changes"
+	case side == "":
+		return main
+	case main == "":
+		return "side: " + side
+	}
+	return fmt.Sprintf("%s (side: %s)", main, side)
+}
+
+// formatZoneDiff formats the changes of one zone, empty if it is unchanged.
+func formatZoneDiff(zone ZoneDiff) string {
+	var changes []CardChange
+	changes = append(changes, zone.Added...)
+	changes = append(changes, zone.Removed...)
+	changes = append(changes, zone.Changed...)
+	sort.SliceStable(changes, func(i, j int) bool {
+		if added, other := changes[i].Delta() > 0, changes[j].Delta() > 0; added != other {
+			return added
+		}
+		return changes[i].Card.Name < changes[j].Card.Name
+	})
+
+	parts := make([]string, len(changes))
+	for i, change := range changes {
+		parts[i] = fmt.Sprintf("%+d %s", change.Delta(), change.Card.Name)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func diffZones(before, after map[*MagicCard]int) ZoneDiff {
+	var diff ZoneDiff
+
+	for _, card := range sortedCards(before) {
+		key, ok := doesCardExistInMap(card, after)
+		switch {
+		case !ok:
+			diff.Removed = append(diff.Removed, CardChange{Card: card, Before: before[card]})
+		case after[key] != before[card]:
+			diff.Changed = append(diff.Changed, CardChange{Card: card, Before: before[card], After: after[key]})
+		}
+	}
+	for _, card := range sortedCards(after) {
+		if _, ok := doesCardExistInMap(card, before); !ok {
+			diff.Added = append(diff.Added, CardChange{Card: card, After: after[card]})
+		}
+	}
+
+	return diff
+}
+
+// MergeDecklists combines decklists into a new Decklist, adding up quantities per zone.
+//
+// Behavior:
+//   - Cards are matched by oracle ID and their quantities summed
+//   - Commanders are combined without duplicates; the first Companion found is kept
+//   - Requested printings are kept, the first decklist's wins when they disagree
+//   - Name and Comments are taken from the first decklist
+//   - The inputs are not modified and the result is not validated
+func MergeDecklists(decklists ...*Decklist) *Decklist {
+	merged := NewDecklist()
+
+	for i, deck := range decklists {
+		if deck == nil {
+			continue
+		}
+		if i == 0 {
+			merged.Name = deck.Name
+			merged.Comments = deck.Comments
+		}
+
+		for _, card := range sortedCards(deck.Maindeck) {
+			key := addCardToMap(card, deck.Maindeck[card], merged.Maindeck)
+			merged.copyPrinting(deck, card, key)
+		}
+		for _, card := range sortedCards(deck.Sideboard) {
+			key := addCardToMap(card, deck.Sideboard[card], merged.Sideboard)
+			merged.copyPrinting(deck, card, key)
+		}
+		for _, card := range sortedCards(deck.Maybeboard) {
+			key := addCardToMap(card, deck.Maybeboard[card], merged.Maybeboard)
+			merged.copyPrinting(deck, card, key)
+		}
+
+		for _, commander := range deck.Commanders {
+			if !merged.hasCommander(commander) {
+				merged.Commanders = append(merged.Commanders, commander)
+				merged.copyPrinting(deck, commander, commander)
+			}
+		}
+		if merged.Companion == nil && deck.Companion != nil {
+			merged.Companion = deck.Companion
+			merged.copyPrinting(deck, deck.Companion, deck.Companion)
+		}
+	}
+
+	return merged
+}
+
+// copies the requested printing of card in from to key, unless key already has one
+func (d *Decklist) copyPrinting(from *Decklist, card, key *MagicCard) {
+	requested, ok := from.Printings[card]
+	if !ok {
+		return
+	}
+	if d.Printings == nil {
+		d.Printings = make(map[*MagicCard]RequestedPrinting)
+	}
+	if _, ok := d.Printings[key]; !ok {
+		d.Printings[key] = requested
+	}
+}
+
+func (d *Decklist) hasCommander(card *MagicCard) bool {
+	for _, commander := range d.Commanders {
+		if commander == card {
+			return true
+		}
+		if commander.OracleID != nil && card.OracleID != nil && *commander.OracleID == *card.OracleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package scryball
+
+import "testing"
+
+func TestDiffDecklists(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	boltReprint := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-2", "Lightning Bolt", "Instant")}
+	chain := &MagicCard{Card: testAPICard("chain-oracle", "chain-1", "Chain Lightning", "Sorcery")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+
+	before := NewDecklist()
+	before.AddCard(bolt, 4)
+	before.AddCard(mountain, 20)
+	before.AddSideboardCard(pyro, 3)
+
+	after := NewDecklist()
+	after.AddCard(boltReprint, 4)
+	after.AddCard(mountain, 18)
+	after.AddCard(chain, 2)
+	after.AddCard(pyro, 1)
+	after.AddSideboardCard(pyro, 2)
+
+	diff := DiffDecklists(before, after)
+	if diff.Empty() {
+		t.Fatal("Expected a non-empty diff")
+	}
+
+	if len(diff.Maindeck.Added) != 2 || diff.Maindeck.Added[0].Card != chain || diff.Maindeck.Added[1].Card != pyro {
+		t.Errorf("Expected Chain Lightning and Pyroblast added to maindeck, got %+v", diff.Maindeck.Added)
+	}
+	if len(diff.Maindeck.Removed) != 0 {
+		t.Errorf("Expected nothing removed from maindeck (reprint is the same card), got %+v", diff.Maindeck.Removed)
+	}
+	if len(diff.Maindeck.Changed) != 1 || diff.Maindeck.Changed[0].Card != mountain || diff.Maindeck.Changed[0].Delta() != -2 {
+		t.Errorf("Expected -2 Mountain, got %+v", diff.Maindeck.Changed)
+	}
+	if len(diff.Sideboard.Changed) != 1 || diff.Sideboard.Changed[0].Before != 3 || diff.Sideboard.Changed[0].After != 2 {
+		t.Errorf("Expected Pyroblast 3 -> 2 in sideboard, got %+v", diff.Sideboard.Changed)
+	}
+
+	if !DiffDecklists(before, before).Empty() {
+		t.Error("Expected a decklist to have no diff with itself")
+	}
+}
+
+func TestMergeDecklists(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+	atraxa := &MagicCard{Card: testAPICard("atraxa-oracle", "atraxa-1", "Atraxa, Praetors' Voice", "Legendary Creature")}
+
+	a := NewDecklist()
+	a.Name = "Burn"
+	a.AddCard(bolt, 4)
+	a.AddSideboardCard(pyro, 2)
+	a.Commanders = []*MagicCard{atraxa}
+
+	b := NewDecklist()
+	b.AddCard(bolt, 2)
+	b.AddCard(mountain, 20)
+	b.AddSideboardCard(pyro, 1)
+	b.Commanders = []*MagicCard{atraxa}
+
+	merged := MergeDecklists(a, b, nil)
+	if merged.Name != "Burn" {
+		t.Errorf("Expected name from first decklist, got %q", merged.Name)
+	}
+	if merged.Maindeck[bolt] != 6 || merged.Maindeck[mountain] != 20 {
+		t.Errorf("Expected 6 Lightning Bolt and 20 Mountain, got %v", merged.Maindeck)
+	}
+	if merged.Sideboard[pyro] != 3 {
+		t.Errorf("Expected 3 Pyroblast in sideboard, got %d", merged.Sideboard[pyro])
+	}
+	if len(merged.Commanders) != 1 {
+		t.Errorf("Expected duplicate commanders to be combined, got %d", len(merged.Commanders))
+	}
+	if a.Maindeck[bolt] != 4 {
+		t.Errorf("Expected inputs to be left unchanged, got %d Lightning Bolt", a.Maindeck[bolt])
+	}
+}
+
+func TestFormatDeckDiff(t *testing.T) {
+	counterspell := &MagicCard{Card: testAPICard("counterspell-oracle", "counterspell-1", "Counterspell", "Instant")}
+	negate := &MagicCard{Card: testAPICard("negate-oracle", "negate-1", "Negate", "Instant")}
+	gust := &MagicCard{Card: testAPICard("gust-oracle", "gust-1", "Aether Gust", "Instant")}
+	island := &MagicCard{Card: testAPICard("island-oracle", "island-1", "Island", "Basic Land — Island")}
+
+	before := NewDecklist()
+	before.AddCard(counterspell, 2)
+	before.AddCard(negate, 1)
+	before.AddCard(island, 20)
+
+	after := NewDecklist()
+	after.AddCard(counterspell, 4)
+	after.AddCard(island, 20)
+	after.AddSideboardCard(gust, 1)
+
+	if got, expected := FormatDeckDiff(DiffDecklists(before, after)), "+2 Counterspell, -1 Negate (side: +1 Aether Gust)"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	sideOnly := NewDecklist()
+	sideOnly.AddCard(counterspell, 2)
+	sideOnly.AddCard(negate, 1)
+	sideOnly.AddCard(island, 20)
+	sideOnly.AddSideboardCard(gust, 2)
+	if got, expected := FormatDeckDiff(DiffDecklists(before, sideOnly)), "side: +2 Aether Gust"; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	if got := FormatDeckDiff(DiffDecklists(before, before)); got != "no changes" {
+		t.Errorf("Expected \"no changes\", got %q", got)
+	}
+}
@@ -0,0 +1,245 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// DecklistFormat is a decklist file format recognized by DetectDecklistFormat.
+type DecklistFormat int
+
+const (
+	DecklistFormatUnknown    DecklistFormat = iota
+	DecklistFormatArena                     // "Deck" / "Sideboard" headers, optional "(SET) number"
+	DecklistFormatMTGO                      // plain "4 Card" lines, sideboard after a blank line or "SB:"
+	DecklistFormatMoxfield                  // "SIDEBOARD:" headers and "*F*" foil markers
+	DecklistFormatDek                       // Magic Online .dek XML
+	DecklistFormatCockatrice                // Cockatrice .cod XML
+)
+
+func (f DecklistFormat) String() string {
+	switch f {
+	case DecklistFormatArena:
+		return "Arena"
+	case DecklistFormatMTGO:
+		return "MTGO"
+	case DecklistFormatMoxfield:
+		return "Moxfield"
+	case DecklistFormatDek:
+		return ".dek"
+	case DecklistFormatCockatrice:
+		return "Cockatrice"
+	default:
+		return "Unknown"
+	}
+}
+
+// DetectDecklistFormat guesses the format of a decklist from its contents.
+//
+// Behavior:
+//   - XML is Cockatrice if its root is <cockatrice_deck>, .dek if it is <Deck>
+//   - Text with "*F*" / "*E*" foil markers or "SIDEBOARD:" style headers is Moxfield
+//   - Text with Arena headers ("Deck", "About", "Sideboard", ...) or "(SET) number"
+//     printings is Arena
+//   - Any other text with "4 Card" lines is MTGO
+//   - Returns DecklistFormatUnknown if no card line is found
+//
+// Detection never looks cards up, so it is cheap and works offline.
+func DetectDecklistFormat(text string) DecklistFormat {
+	text = strings.TrimSpace(strings.TrimPrefix(text, "\ufeff"))
+	if strings.HasPrefix(text, "<") {
+		switch {
+		case strings.Contains(text, "<cockatrice_deck"):
+			return DecklistFormatCockatrice
+		case strings.Contains(text, "<Deck"):
+			return DecklistFormatDek
+		}
+		return DecklistFormatUnknown
+	}
+
+	var cards, arena, moxfield bool
+	for _, line := range strings.Split(text, "\n") {
+		line = stripComment(line)
+		if line == "" {
+			continue
+		}
+
+		if _, ok := parseSectionHeader(line); ok || strings.EqualFold(line, "About") {
+			if strings.HasSuffix(line, ":") {
+				moxfield = true
+			} else {
+				arena = true
+			}
+			continue
+		}
+
+		if len(line) >= 3 && strings.EqualFold(line[:3], "SB:") {
+			cards = true
+			continue
+		}
+		if _, ok := parseQuantity(strings.SplitN(line, " ", 2)[0], false); !ok {
+			continue
+		}
+		cards = true
+
+		rest, marked := stripFoilMarkers(line)
+		if marked {
+			moxfield = true
+		}
+		if _, setCode, _ := splitPrinting(rest); setCode != "" {
+			arena = true
+		}
+	}
+
+	switch {
+	case !cards:
+		return DecklistFormatUnknown
+	case moxfield:
+		return DecklistFormatMoxfield
+	case arena:
+		return DecklistFormatArena
+	default:
+		return DecklistFormatMTGO
+	}
+}
+
+// stripFoilMarkers removes Moxfield's trailing "*F*" (foil) and "*E*" (etched) markers.
+// Returns false if the line had none.
+func stripFoilMarkers(line string) (string, bool) {
+	marked := false
+	for {
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) < 3 || !strings.HasSuffix(trimmed, "*") {
+			return trimmed, marked
+		}
+		marker := trimmed[len(trimmed)-3:]
+		if marker[0] != '*' || (marker[1] != 'F' && marker[1] != 'E') {
+			return trimmed, marked
+		}
+		line = trimmed[:len(trimmed)-3]
+		marked = true
+	}
+}
+
+// mtgoSideboardText adds a "Sideboard" header in front of the last block of an
+// MTGO text export, where the sideboard follows the maindeck after a blank line.
+// Lists with section headers, "SB:" lines or a single block are returned unchanged.
+func mtgoSideboardText(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	blank := -1
+	for i, line := range lines {
+		line = stripComment(line)
+		switch {
+		case strings.TrimSpace(lines[i]) == "":
+			blank = i
+		case len(line) >= 3 && strings.EqualFold(line[:3], "SB:"):
+			return text
+		default:
+			if _, ok := parseSectionHeader(line); ok {
+				return text
+			}
+		}
+	}
+	if blank == -1 {
+		return text
+	}
+
+	withHeader := append(lines[:blank:blank], "", "Sideboard")
+	return strings.Join(append(withHeader, lines[blank+1:]...), "\n")
+}
+
+// moxfieldText removes the foil markers Moxfield appends to card lines.
+func moxfieldText(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i], _ = stripFoilMarkers(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// shared auto-detecting parsing implementation
+func (sb *Scryball) parseAnyDecklist(ctx context.Context, text string) (*Decklist, DecklistFormat, error) {
+	format := DetectDecklistFormat(text)
+
+	var decklist *Decklist
+	var err error
+	switch format {
+	case DecklistFormatCockatrice:
+		decklist, err = sb.parseCockatriceDecklist(ctx, text)
+	case DecklistFormatDek:
+		decklist, err = sb.parseDekDecklist(ctx, text)
+	case DecklistFormatArena, DecklistFormatMTGO, DecklistFormatMoxfield:
+		switch format {
+		case DecklistFormatMTGO:
+			text = mtgoSideboardText(text)
+		case DecklistFormatMoxfield:
+			text = moxfieldText(text)
+		}
+		var result *parseResult
+		result, err = sb.parseDecklist(ctx, text, parseOptions{})
+		if err == nil {
+			decklist = result.decklist
+		}
+	default:
+		return nil, format, fmt.Errorf("unrecognized decklist format")
+	}
+
+	if err != nil {
+		return nil, format, err
+	}
+	return decklist, format, nil
+}
+
+// ParseAnyDecklist detects the format of a decklist and parses it with the matching parser.
+//
+// Formats supported: Arena, MTGO text, Moxfield, Magic Online .dek and Cockatrice .cod
+// (see DetectDecklistFormat).
+//
+// Behavior:
+//   - Arena, MTGO text and Moxfield lists are parsed like ParseDecklist
+//   - MTGO text lists take the block after the last blank line as the sideboard
+//   - Moxfield "*F*" and "*E*" foil markers are ignored
+//   - .dek cards record the printing matching their CatID when it is cached
+//   - .cod decks are parsed like ParseCockatriceDecklist
+//
+// Returns:
+//   - *Decklist: Parsed deck
+//   - DecklistFormat: The detected format, also returned with parse errors
+//   - error: Unrecognized format, parse errors, or card lookup failures
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+//
+// Example:
+//
+//	deck, format, err := scryball.ParseAnyDecklist(upload)
+//	if err != nil {
+//	    log.Fatalf("%s decklist: %v", format, err)
+//	}
+//	fmt.Printf("Parsed %s deck with %d cards\n", format, deck.NumberOfCards())
+func ParseAnyDecklist(text string) (*Decklist, DecklistFormat, error) {
+	ctx := context.Background()
+	return ParseAnyDecklistWithContext(ctx, text)
+}
+
+// ParseAnyDecklistWithContext detects and parses a decklist with context support.
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ParseAnyDecklistWithContext(ctx context.Context, text string) (*Decklist, DecklistFormat, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, DecklistFormatUnknown, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.parseAnyDecklist(ctx, text)
+}
+
+// ParseAnyDecklist detects and parses a decklist using this Scryball instance's client and database.
+func (s *Scryball) ParseAnyDecklist(text string) (*Decklist, DecklistFormat, error) {
+	ctx := context.Background()
+	return s.ParseAnyDecklistWithContext(ctx, text)
+}
+
+// ParseAnyDecklistWithContext detects and parses a decklist using this Scryball instance's client and database with context support.
+func (s *Scryball) ParseAnyDecklistWithContext(ctx context.Context, text string) (*Decklist, DecklistFormat, error) {
+	return s.parseAnyDecklist(ctx, text)
+}
@@ -0,0 +1,116 @@
+package scryball
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectDecklistFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want DecklistFormat
+	}{
+		{"arena", "Deck\n4 Lightning Bolt (STA) 42\n20 Mountain (M21) 270\n\nSideboard\n3 Pyroblast (EMA) 142", DecklistFormatArena},
+		{"arena_about", "About\nName Burn\n\nDeck\n4 Lightning Bolt", DecklistFormatArena},
+		{"arena_printings_only", "4 Lightning Bolt (STA) 42\n20 Mountain (M21) 270", DecklistFormatArena},
+		{"mtgo", "4 Lightning Bolt\n20 Mountain\n\n3 Pyroblast", DecklistFormatMTGO},
+		{"mtgo_sb_prefix", "4 Lightning Bolt\nSB: 3 Pyroblast", DecklistFormatMTGO},
+		{"moxfield_foil", "1 Sol Ring (C21) 263 *F*\n1 Command Tower (C21) 284", DecklistFormatMoxfield},
+		{"moxfield_header", "4 Lightning Bolt\n\nSIDEBOARD:\n3 Pyroblast", DecklistFormatMoxfield},
+		{"dek", `<?xml version="1.0" encoding="utf-8"?>` + "\n" + `<Deck><Cards CatID="78252" Quantity="4" Sideboard="false" Name="Lightning Bolt" /></Deck>`, DecklistFormatDek},
+		{"cockatrice", `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<cockatrice_deck version="1"><zone name="main"/></cockatrice_deck>`, DecklistFormatCockatrice},
+		{"other_xml", `<html><body>4 Lightning Bolt</body></html>`, DecklistFormatUnknown},
+		{"no_cards", "just some notes\nabout a deck", DecklistFormatUnknown},
+		{"empty", "", DecklistFormatUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectDecklistFormat(tt.text); got != tt.want {
+				t.Errorf("DetectDecklistFormat() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAnyDecklist(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	bolt := testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")
+	mtgoID := 78252
+	bolt.MTGOID = &mtgoID
+	bolt.Set = "2xm"
+	bolt.CollectorNumber = "117"
+	insertTestCard(t, sb, bolt)
+	insertTestCard(t, sb, testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"))
+	insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+
+	tests := []struct {
+		name   string
+		text   string
+		format DecklistFormat
+	}{
+		{"arena", "Deck\n4 Lightning Bolt\n20 Mountain\n\nSideboard\n3 Pyroblast", DecklistFormatArena},
+		{"mtgo", "4 Lightning Bolt\n20 Mountain\n\n3 Pyroblast\n", DecklistFormatMTGO},
+		{"moxfield", "4 Lightning Bolt (2XM) 117 *F*\n20 Mountain\n\nSIDEBOARD:\n3 Pyroblast *E*", DecklistFormatMoxfield},
+		{"dek", `<?xml version="1.0" encoding="utf-8"?>
+<Deck xmlns:xsd="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+  <NetDeckID>0</NetDeckID>
+  <PreconstructedDeckID>0</PreconstructedDeckID>
+  <Cards CatID="78252" Quantity="4" Sideboard="false" Name="Lightning Bolt" Annotation="0" />
+  <Cards CatID="0" Quantity="20" Sideboard="false" Name="Mountain" Annotation="0" />
+  <Cards CatID="0" Quantity="3" Sideboard="true" Name="Pyroblast" Annotation="0" />
+</Deck>`, DecklistFormatDek},
+		{"cockatrice", `<cockatrice_deck version="1">
+    <zone name="main">
+        <card number="4" name="Lightning Bolt"/>
+        <card number="20" name="Mountain"/>
+    </zone>
+    <zone name="side">
+        <card number="3" name="Pyroblast"/>
+    </zone>
+</cockatrice_deck>`, DecklistFormatCockatrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deck, format, err := sb.ParseAnyDecklist(tt.text)
+			if err != nil {
+				t.Fatalf("ParseAnyDecklist failed: %v", err)
+			}
+			if format != tt.format {
+				t.Errorf("Expected format %s, got %s", tt.format, format)
+			}
+			if deck.NumberOfCards() != 24 {
+				t.Errorf("Expected 24 maindeck cards, got %d", deck.NumberOfCards())
+			}
+			if deck.NumberOfSideboardCards() != 3 {
+				t.Errorf("Expected 3 sideboard cards, got %d", deck.NumberOfSideboardCards())
+			}
+		})
+	}
+
+	t.Run("dek_printing", func(t *testing.T) {
+		deck, _, err := sb.ParseAnyDecklist(tests[3].text)
+		if err != nil {
+			t.Fatalf("ParseAnyDecklist failed: %v", err)
+		}
+		for card, requested := range deck.Printings {
+			if card.Name != "Lightning Bolt" || requested.SetCode != "2XM" || requested.Printing == nil {
+				t.Errorf("Expected Lightning Bolt's 2XM printing to be requested, got %s %+v", card.Name, requested)
+			}
+		}
+		if len(deck.Printings) != 1 {
+			t.Errorf("Expected 1 requested printing, got %d", len(deck.Printings))
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, format, err := sb.ParseAnyDecklist("not a decklist")
+		if format != DecklistFormatUnknown || err == nil || !strings.Contains(err.Error(), "unrecognized") {
+			t.Errorf("Expected unrecognized format error, got %s: %v", format, err)
+		}
+	})
+}
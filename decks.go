@@ -0,0 +1,223 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// Zones of a saved deck, as stored in deck_cards.
+const (
+	zoneMaindeck   = "maindeck"
+	zoneSideboard  = "sideboard"
+	zoneCommander  = "commander"
+	zoneCompanion  = "companion"
+	zoneMaybeboard = "maybeboard"
+)
+
+// SavedDeck describes a deck stored with SaveDeck.
+type SavedDeck struct {
+	Name     string
+	Comments string
+	SavedAt  string // When the deck was last saved
+}
+
+// SaveDeck stores a decklist in the instance's database under name, replacing
+// any deck saved with the same name, so apps don't need a second datastore.
+//
+// Behavior:
+//   - Cards are stored by Oracle ID with their quantity and requested printing,
+//     not as card data, so loading a deck picks up refreshed cards
+//   - Stores every zone: maindeck, sideboard, maybeboard, commanders and companion
+//   - The deck's comments are stored, its Name is replaced by name when loaded
+//
+// Returns:
+//   - error: Empty name, cards without an Oracle ID, or database errors
+//
+// Example:
+//
+//	deck, _ := sb.ParseDecklist(list)
+//	err := sb.SaveDeck(ctx, "Burn", deck)
+func (s *Scryball) SaveDeck(ctx context.Context, name string, deck *Decklist) error {
+	if name == "" {
+		return errors.New("deck name must not be empty")
+	}
+
+	var cards []scryfall.InsertDeckCardParams
+	add := func(zone string, card *MagicCard, qty int) error {
+		if card.OracleID == nil {
+			return fmt.Errorf("cannot save %s without an Oracle ID", card.Name)
+		}
+		params := scryfall.InsertDeckCardParams{
+			DeckName: name,
+			Zone:     zone,
+			Position: int64(len(cards)),
+			OracleID: *card.OracleID,
+			Quantity: int64(qty),
+		}
+		if requested, ok := deck.Printings[card]; ok {
+			params.SetCode = requested.SetCode
+			params.CollectorNumber = requested.CollectorNumber
+		}
+		cards = append(cards, params)
+		return nil
+	}
+	for _, card := range sortedCards(deck.Maindeck) {
+		if err := add(zoneMaindeck, card, deck.Maindeck[card]); err != nil {
+			return err
+		}
+	}
+	for _, card := range sortedCards(deck.Sideboard) {
+		if err := add(zoneSideboard, card, deck.Sideboard[card]); err != nil {
+			return err
+		}
+	}
+	for _, card := range sortedCards(deck.Maybeboard) {
+		if err := add(zoneMaybeboard, card, deck.Maybeboard[card]); err != nil {
+			return err
+		}
+	}
+	for _, card := range deck.Commanders {
+		if err := add(zoneCommander, card, 1); err != nil {
+			return err
+		}
+	}
+	if deck.Companion != nil {
+		if err := add(zoneCompanion, deck.Companion, 1); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin saving deck %q: %v", name, err)
+	}
+	defer tx.Rollback()
+	queries := s.queries.WithTx(tx)
+
+	if err := queries.UpsertDeck(ctx, scryfall.UpsertDeckParams{Name: name, Comments: deck.Comments}); err != nil {
+		return fmt.Errorf("could not save deck %q: %v", name, err)
+	}
+	if err := queries.DeleteDeckCards(ctx, name); err != nil {
+		return fmt.Errorf("could not replace cards of deck %q: %v", name, err)
+	}
+	for _, card := range cards {
+		if err := queries.InsertDeckCard(ctx, card); err != nil {
+			return fmt.Errorf("could not save card %s of deck %q: %v", card.OracleID, name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deck %q: %v", name, err)
+	}
+	return nil
+}
+
+// LoadDeck reads a deck stored with SaveDeck.
+//
+// Behavior:
+//   - Cards are read from the cache, cards no longer cached are fetched from Scryfall
+//   - Requested printings are matched against each card's Printings again
+//   - The returned decklist is named name
+//
+// Returns:
+//   - *Decklist: The saved deck
+//   - error: No deck saved as name, card lookup failures, or database errors
+func (s *Scryball) LoadDeck(ctx context.Context, name string) (*Decklist, error) {
+	saved, err := s.queries.GetDeck(ctx, name)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("no deck saved as %q", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("database error loading deck %q: %v", name, err)
+	}
+	rows, err := s.queries.GetDeckCards(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("database error loading cards of deck %q: %v", name, err)
+	}
+
+	deck := NewDecklist()
+	deck.Name = saved.Name
+	deck.Comments = saved.Comments
+
+	cards := map[string]*MagicCard{}
+	for _, row := range rows {
+		card, ok := cards[row.OracleID]
+		if !ok {
+			card, err = s.findCardOracleID(ctx, row.OracleID)
+			if err != nil {
+				return nil, fmt.Errorf("could not load deck %q: %w", name, err)
+			}
+			cards[row.OracleID] = card
+		}
+
+		switch row.Zone {
+		case zoneMaindeck:
+			card = addCardToMap(card, int(row.Quantity), deck.Maindeck)
+		case zoneSideboard:
+			card = addCardToMap(card, int(row.Quantity), deck.Sideboard)
+		case zoneMaybeboard:
+			card = addCardToMap(card, int(row.Quantity), deck.Maybeboard)
+		case zoneCommander:
+			deck.Commanders = append(deck.Commanders, card)
+		case zoneCompanion:
+			deck.Companion = card
+		default:
+			return nil, fmt.Errorf("deck %q has a card in unknown zone %q", name, row.Zone)
+		}
+		deck.requestPrinting(card, row.SetCode, row.CollectorNumber)
+	}
+	return deck, nil
+}
+
+// ListDecks returns every deck stored with SaveDeck, sorted by name.
+func (s *Scryball) ListDecks(ctx context.Context) ([]SavedDeck, error) {
+	rows, err := s.queries.ListDecks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list decks: %v", err)
+	}
+
+	decks := make([]SavedDeck, 0, len(rows))
+	for _, row := range rows {
+		decks = append(decks, SavedDeck{Name: row.Name, Comments: row.Comments, SavedAt: row.SavedAt})
+	}
+	return decks, nil
+}
+
+// DeleteDeck removes a deck stored with SaveDeck.
+//
+// Returns:
+//   - error: No deck saved as name, or database errors
+func (s *Scryball) DeleteDeck(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin deleting deck %q: %v", name, err)
+	}
+	defer tx.Rollback()
+	queries := s.queries.WithTx(tx)
+
+	if err := queries.DeleteDeckCards(ctx, name); err != nil {
+		return fmt.Errorf("could not delete cards of deck %q: %v", name, err)
+	}
+	deleted, err := queries.DeleteDeck(ctx, name)
+	if err != nil {
+		return fmt.Errorf("could not delete deck %q: %v", name, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("no deck saved as %q", name)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deleting deck %q: %v", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package scryball
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSaveAndLoadDeck(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	boltCard := testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")
+	boltCard.Set, boltCard.CollectorNumber = "sta", "42"
+	bolt := insertTestCard(t, sb, boltCard)
+	mountain := insertTestCard(t, sb, testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"))
+	pyro := insertTestCard(t, sb, testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"))
+	kiki := insertTestCard(t, sb, testAPICard("kiki-oracle", "kiki-1", "Kiki-Jiki, Mirror Breaker", "Legendary Creature — Goblin Shaman"))
+
+	deck := NewDecklist()
+	deck.Comments = "Go face"
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 20)
+	deck.AddSideboardCard(pyro, 3)
+	deck.Commanders = []*MagicCard{kiki}
+	deck.requestPrinting(bolt, "STA", "42")
+
+	if err := sb.SaveDeck(ctx, "Burn", deck); err != nil {
+		t.Fatalf("SaveDeck failed: %v", err)
+	}
+
+	loaded, err := sb.LoadDeck(ctx, "Burn")
+	if err != nil {
+		t.Fatalf("LoadDeck failed: %v", err)
+	}
+	if loaded.Name != "Burn" || loaded.Comments != "Go face" {
+		t.Errorf("Expected deck Burn with comments, got %q %q", loaded.Name, loaded.Comments)
+	}
+	if loaded.String() != deck.String() {
+		t.Errorf("Expected loaded deck\n%s\nto match saved deck\n%s", loaded.String(), deck.String())
+	}
+	if len(loaded.Commanders) != 1 || loaded.Commanders[0].Name != kiki.Name {
+		t.Errorf("Expected commander %s, got %v", kiki.Name, loaded.Commanders)
+	}
+	for card := range loaded.Maindeck {
+		requested, ok := loaded.Printings[card]
+		if card.Name != "Lightning Bolt" {
+			continue
+		}
+		if !ok || requested.Printing == nil || requested.Printing.ID != "bolt-1" {
+			t.Errorf("Expected Lightning Bolt to request printing bolt-1, got %+v", requested)
+		}
+	}
+
+	// Saving again under the same name replaces the deck
+	deck.RemoveSideboardCard(pyro, 3)
+	if err := sb.SaveDeck(ctx, "Burn", deck); err != nil {
+		t.Fatalf("SaveDeck failed: %v", err)
+	}
+	if err := sb.SaveDeck(ctx, "Another", NewDecklist()); err != nil {
+		t.Fatalf("SaveDeck failed: %v", err)
+	}
+	loaded, err = sb.LoadDeck(ctx, "Burn")
+	if err != nil {
+		t.Fatalf("LoadDeck failed: %v", err)
+	}
+	if loaded.NumberOfSideboardCards() != 0 {
+		t.Errorf("Expected the resaved deck to have no sideboard, got %d cards", loaded.NumberOfSideboardCards())
+	}
+
+	decks, err := sb.ListDecks(ctx)
+	if err != nil {
+		t.Fatalf("ListDecks failed: %v", err)
+	}
+	if len(decks) != 2 || decks[0].Name != "Another" || decks[1].Name != "Burn" {
+		t.Errorf("Expected decks Another and Burn, got %+v", decks)
+	}
+
+	if err := sb.DeleteDeck(ctx, "Burn"); err != nil {
+		t.Fatalf("DeleteDeck failed: %v", err)
+	}
+	if _, err := sb.LoadDeck(ctx, "Burn"); err == nil {
+		t.Error("Expected an error loading a deleted deck")
+	}
+	if err := sb.DeleteDeck(ctx, "Burn"); err == nil {
+		t.Error("Expected an error deleting a missing deck")
+	}
+	if err := sb.SaveDeck(ctx, "", deck); err == nil {
+		t.Error("Expected an error saving a deck without a name")
+	}
+}
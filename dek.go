@@ -0,0 +1,149 @@
+package scryball
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// mtgoDeck mirrors Magic Online's .dek XML deck format.
+type mtgoDeck struct {
+	XMLName              xml.Name       `xml:"Deck"`
+	XMLNSXSD             string         `xml:"xmlns:xsd,attr"`
+	XMLNSXSI             string         `xml:"xmlns:xsi,attr"`
+	NetDeckID            int            `xml:"NetDeckID"`
+	PreconstructedDeckID int            `xml:"PreconstructedDeckID"`
+	Cards                []mtgoDeckCard `xml:"Cards"`
+}
+
+type mtgoDeckCard struct {
+	CatID      int    `xml:"CatID,attr"`
+	Quantity   int    `xml:"Quantity,attr"`
+	Sideboard  bool   `xml:"Sideboard,attr"`
+	Name       string `xml:"Name,attr"`
+	Annotation int    `xml:"Annotation,attr"`
+}
+
+// MTGOPrinting returns the most recent printing of the card available on Magic Online.
+//
+// Returns false if no cached printing has an MTGO catalog ID.
+func (card *MagicCard) MTGOPrinting() (Printing, bool) {
+	for _, printing := range card.Printings {
+		if printing.MTGOID != 0 {
+			return printing, true
+		}
+	}
+	return Printing{}, false
+}
+
+// ExportDek writes the decklist in Magic Online's .dek XML format.
+//
+// Behavior:
+//   - Uses the MTGO catalog ID of each card's requested printing (see Decklist.Printings)
+//     when it is on MTGO, otherwise of its most recent MTGO printing
+//   - Cards are written maindeck first, then sideboard, sorted by name
+//   - Commanders and Companion are written to the sideboard, as MTGO expects,
+//     so they are read back as sideboard cards
+//   - Nothing is written if any card has no MTGO printing
+//
+// Returns:
+//   - error: Lists every card without an MTGO printing, or write errors
+func (d *Decklist) ExportDek(w io.Writer) error {
+	deck := mtgoDeck{
+		XMLNSXSD: "http://www.w3.org/2001/XMLSchema",
+		XMLNSXSI: "http://www.w3.org/2001/XMLSchema-instance",
+	}
+
+	var missing []string
+	addZone := func(list map[*MagicCard]int, sideboard bool) {
+		for _, card := range sortedCards(list) {
+			printing, ok := card.MTGOPrinting()
+			if requested := d.Printings[card].Printing; requested != nil && requested.MTGOID != 0 {
+				printing, ok = *requested, true
+			}
+			if !ok {
+				missing = append(missing, card.Name)
+				continue
+			}
+			deck.Cards = append(deck.Cards, mtgoDeckCard{
+				CatID:     printing.MTGOID,
+				Quantity:  list[card],
+				Sideboard: sideboard,
+				Name:      card.Name,
+			})
+		}
+	}
+	addZone(d.Maindeck, false)
+	addZone(d.sideboardWithCommanders(), true)
+
+	if len(missing) > 0 {
+		return fmt.Errorf("no MTGO printing for: %s", strings.Join(missing, ", "))
+	}
+
+	out, err := xml.MarshalIndent(deck, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode .dek: %v", err)
+	}
+
+	if _, err := io.WriteString(w, `<?xml version="1.0" encoding="utf-8"?>`+"\n"); err != nil {
+		return err
+	}
+	if _, err := w.Write(out); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// shared .dek parsing implementation
+//
+// Cards are resolved by name. When a cached printing has the card's CatID it
+// is recorded as the requested printing, so ExportDek writes the same CatID back.
+func (sb *Scryball) parseDekDecklist(ctx context.Context, dek string) (*Decklist, error) {
+	var deck mtgoDeck
+	if err := xml.Unmarshal([]byte(dek), &deck); err != nil {
+		return nil, fmt.Errorf("invalid .dek deck: %v", err)
+	}
+
+	decklist := &Decklist{
+		Maindeck:  make(map[*MagicCard]int),
+		Sideboard: make(map[*MagicCard]int),
+	}
+
+	var sideboardTotal int
+	for _, card := range deck.Cards {
+		cardName := strings.TrimSpace(card.Name)
+		if cardName == "" {
+			return nil, fmt.Errorf("card without a name (CatID %d)", card.CatID)
+		}
+		if card.Quantity < 1 {
+			return nil, fmt.Errorf("invalid quantity %d for %s", card.Quantity, cardName)
+		}
+
+		magicCard, err := sb.resolveDecklistCard(ctx, cardName)
+		if err != nil {
+			return nil, err
+		}
+
+		list := decklist.Maindeck
+		if card.Sideboard {
+			sideboardTotal += card.Quantity
+			if sideboardTotal > 15 {
+				return nil, fmt.Errorf("sideboard exceeds 15 cards (has %d)", sideboardTotal)
+			}
+			list = decklist.Sideboard
+		}
+
+		key := addCardToMap(magicCard, card.Quantity, list)
+		for _, printing := range key.Printings {
+			if card.CatID != 0 && printing.MTGOID == card.CatID {
+				decklist.requestPrinting(key, strings.ToUpper(printing.SetCode), printing.CollectorNumber)
+				break
+			}
+		}
+	}
+
+	return decklist, nil
+}
@@ -0,0 +1,110 @@
+package scryball
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestExportDek(t *testing.T) {
+	bolt := &MagicCard{
+		Card: &client.Card{Name: "Lightning Bolt"},
+		Printings: []Printing{
+			{SetCode: "sld", MTGOID: 0},
+			{SetCode: "2xm", MTGOID: 78252},
+			{SetCode: "m11", MTGOID: 37904},
+		},
+	}
+	pyro := &MagicCard{
+		Card:      &client.Card{Name: "Pyroblast"},
+		Printings: []Printing{{SetCode: "ema", MTGOID: 61047}},
+	}
+
+	deck := &Decklist{
+		Maindeck:  map[*MagicCard]int{bolt: 4},
+		Sideboard: map[*MagicCard]int{pyro: 3},
+	}
+
+	var out strings.Builder
+	if err := deck.ExportDek(&out); err != nil {
+		t.Fatalf("ExportDek failed: %v", err)
+	}
+
+	dek := out.String()
+	if !strings.HasPrefix(dek, `<?xml version="1.0" encoding="utf-8"?>`) {
+		t.Error("Expected XML declaration")
+	}
+	for _, want := range []string{
+		`<Cards CatID="78252" Quantity="4" Sideboard="false" Name="Lightning Bolt" Annotation="0"></Cards>`,
+		`<Cards CatID="61047" Quantity="3" Sideboard="true" Name="Pyroblast" Annotation="0"></Cards>`,
+		`<NetDeckID>0</NetDeckID>`,
+	} {
+		if !strings.Contains(dek, want) {
+			t.Errorf("Expected .dek to contain %s, got:\n%s", want, dek)
+		}
+	}
+
+	t.Run("missing_mtgo_printing", func(t *testing.T) {
+		paperOnly := &MagicCard{
+			Card:      &client.Card{Name: "Black Lotus"},
+			Printings: []Printing{{SetCode: "lea"}},
+		}
+		deck.Maindeck[paperOnly] = 1
+
+		var out strings.Builder
+		err := deck.ExportDek(&out)
+		if err == nil || !strings.Contains(err.Error(), "Black Lotus") {
+			t.Errorf("Expected error naming Black Lotus, got: %v", err)
+		}
+		if out.Len() != 0 {
+			t.Error("Expected nothing to be written on error")
+		}
+	})
+}
+
+func TestExportDekCommanderRoundTrip(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insert := func(oracleID, name, typeLine string, mtgoID int) *MagicCard {
+		card := testAPICard(oracleID, oracleID+"-1", name, typeLine)
+		card.MTGOID = &mtgoID
+		return insertTestCard(t, sb, card)
+	}
+	atraxa := insert("atraxa", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror", 60000)
+	lurrus := insert("lurrus", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare", 80000)
+	ring := insert("ring", "Sol Ring", "Artifact", 70000)
+
+	deck := &Decklist{
+		Maindeck:   map[*MagicCard]int{ring: 1},
+		Sideboard:  map[*MagicCard]int{},
+		Commanders: []*MagicCard{atraxa},
+		Companion:  lurrus,
+	}
+
+	var out strings.Builder
+	if err := deck.ExportDek(&out); err != nil {
+		t.Fatalf("ExportDek failed: %v", err)
+	}
+	for _, want := range []string{
+		`<Cards CatID="60000" Quantity="1" Sideboard="true" Name="Atraxa, Praetors&#39; Voice" Annotation="0"></Cards>`,
+		`<Cards CatID="80000" Quantity="1" Sideboard="true" Name="Lurrus of the Dream-Den" Annotation="0"></Cards>`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected .dek to contain %s, got:\n%s", want, out.String())
+		}
+	}
+
+	parsed, err := sb.parseDekDecklist(context.Background(), out.String())
+	if err != nil {
+		t.Fatalf("Failed to parse exported .dek: %v", err)
+	}
+	if got := describeZone(parsed.Maindeck); got != "1 Sol Ring" {
+		t.Errorf("Round trip changed the maindeck: %s", got)
+	}
+	if got := describeZone(parsed.Sideboard); got != "1 Atraxa, Praetors' Voice, 1 Lurrus of the Dream-Den" {
+		t.Errorf("Expected the commander and companion in the sideboard, got %s", got)
+	}
+}
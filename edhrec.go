@@ -0,0 +1,56 @@
+package scryball
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SortByEDHRecRank sorts cards in place by EDHREC rank, most played first.
+// Cards without a rank go last, in their original order.
+//
+// Example:
+//
+//	cards, _ := scryball.Query("id:simic t:creature f:commander")
+//	scryball.SortByEDHRecRank(cards)
+//	for _, card := range cards[:10] {
+//	    fmt.Println(*card.EDHRecRank, card.Name)
+//	}
+func SortByEDHRecRank(cards []*MagicCard) {
+	slices.SortStableFunc(cards, func(a, b *MagicCard) int {
+		rankA, okA := edhrecRank(a)
+		rankB, okB := edhrecRank(b)
+		switch {
+		case okA && okB:
+			return cmp.Compare(rankA, rankB)
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+		return 0
+	})
+}
+
+// FilterByEDHRecRank returns the cards ranked max or better on EDHREC, in their
+// original order. Cards without a rank are left out.
+//
+// Example:
+//
+//	staples := scryball.FilterByEDHRecRank(cards, 500)
+func FilterByEDHRecRank(cards []*MagicCard, max int) []*MagicCard {
+	filtered := []*MagicCard{}
+	for _, card := range cards {
+		if rank, ok := edhrecRank(card); ok && rank <= max {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered
+}
+
+// edhrecRank returns the card's EDHREC rank, false if it has none.
+func edhrecRank(card *MagicCard) (int, bool) {
+	if card.Card == nil || card.EDHRecRank == nil {
+		return 0, false
+	}
+	return *card.EDHRecRank, true
+}
@@ -0,0 +1,46 @@
+package scryball
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortAndFilterByEDHRecRank(t *testing.T) {
+	cards := []*MagicCard{
+		testCard("Unranked", "Unranked", "Artifact"),
+		testCard("Arcane Signet", "Arcane Signet", "Artifact", withEDHRecRank(2)),
+		testCard("Mind Stone", "Mind Stone", "Artifact", withEDHRecRank(90)),
+		testCard("Sol Ring", "Sol Ring", "Artifact", withEDHRecRank(1)),
+		testCard("Also Unranked", "Also Unranked", "Artifact"),
+	}
+
+	var names []string
+	for _, card := range FilterByEDHRecRank(cards, 10) {
+		names = append(names, card.Name)
+	}
+	if expected := []string{"Arcane Signet", "Sol Ring"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+
+	SortByEDHRecRank(cards)
+	names = nil
+	for _, card := range cards {
+		names = append(names, card.Name)
+	}
+	if expected := []string{"Sol Ring", "Arcane Signet", "Mind Stone", "Unranked", "Also Unranked"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+}
+
+func TestEDHRecRankLoadedFromDB(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	card := insertTestCard(t, sb, testCard("Sol Ring", "Sol Ring", "Artifact", withEDHRecRank(1)).Card)
+	if card.EDHRecRank == nil || *card.EDHRecRank != 1 {
+		t.Errorf("Expected EDHREC rank 1 from the cache, got %v", card.EDHRecRank)
+	}
+	if unranked := insertTestCard(t, sb, testCard("Unranked", "Unranked", "Artifact").Card); unranked.EDHRecRank != nil {
+		t.Errorf("Expected no EDHREC rank, got %d", *unranked.EDHRecRank)
+	}
+}
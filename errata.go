@@ -0,0 +1,67 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Errata is a change to a card's Oracle text or type line, found when the
+// card was refreshed from Scryfall.
+type Errata struct {
+	OracleID      string
+	Name          string    // Card name after the change
+	OldOracleText string    // Oracle text before the change, with the text of each face on its own line
+	NewOracleText string    // Oracle text after the change
+	OldTypeLine   string    // Type line before the change
+	NewTypeLine   string    // Type line after the change
+	ChangedAt     time.Time // When the change was cached, in UTC
+}
+
+// TextChanged reports whether the Oracle text changed, rather than only the type line.
+func (e Errata) TextChanged() bool {
+	return e.OldOracleText != e.NewOracleText
+}
+
+// RecentErrata returns the Oracle text and type line changes cached since
+// since, newest first, so rules tools can flag functionally changed cards.
+//
+// Behavior:
+//   - Only reads the database, never queries API
+//   - Changes are recorded whenever a cached card is stored again with new
+//     data: when an uncached Query returns it, or by ImportBulkData,
+//     RefreshPreviews or CheckNewPrintings
+//   - A zero since returns every recorded change
+//
+// Returns:
+//   - []Errata: Changes, newest first (empty if none)
+//   - error: Database errors
+//
+// Example:
+//
+//	sb.ImportBulkData(ctx, todaysBulkFile)
+//	errata, _ := sb.RecentErrata(ctx, time.Now().Add(-24*time.Hour))
+//	for _, e := range errata {
+//	    fmt.Printf("%s: %q is now %q\n", e.Name, e.OldOracleText, e.NewOracleText)
+//	}
+func (s *Scryball) RecentErrata(ctx context.Context, since time.Time) ([]Errata, error) {
+	rows, err := s.queries.ListCardErrata(ctx, since.UTC().Format(time.DateTime))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list errata: %v", err)
+	}
+
+	errata := make([]Errata, len(rows))
+	for i, row := range rows {
+		changedAt, _ := time.Parse(time.DateTime, row.ChangedAt)
+		errata[i] = Errata{
+			OracleID:      row.OracleID,
+			Name:          row.Name,
+			OldOracleText: row.OldOracleText,
+			NewOracleText: row.NewOracleText,
+			OldTypeLine:   row.OldTypeLine,
+			NewTypeLine:   row.NewTypeLine,
+			ChangedAt:     changedAt,
+		}
+	}
+	return errata, nil
+}
@@ -0,0 +1,86 @@
+package scryball
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestRecentErrata(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	text := func(s string) *string { return &s }
+	card := testAPICard("guide", "guide-1", "Goblin Guide", "Creature — Goblin Scout")
+	card.OracleText = text("Haste")
+	insertTestCard(t, sb, card)
+
+	// Storing the same card again is not errata.
+	if err := sb.upsertAPICards(ctx, []*client.Card{card}); err != nil {
+		t.Fatalf("Failed to store card: %v", err)
+	}
+	if errata, err := sb.RecentErrata(ctx, time.Time{}); err != nil || len(errata) != 0 {
+		t.Fatalf("Expected no errata, got %+v, %v", errata, err)
+	}
+
+	card.OracleText = text("Haste\nWhenever Goblin Guide attacks, defending player reveals the top card of their library.")
+	if err := sb.upsertAPICards(ctx, []*client.Card{card}); err != nil {
+		t.Fatalf("Failed to store card: %v", err)
+	}
+	card.TypeLine = "Creature — Goblin Scout Warrior"
+	if err := sb.upsertAPICards(ctx, []*client.Card{card}); err != nil {
+		t.Fatalf("Failed to store card: %v", err)
+	}
+
+	errata, err := sb.RecentErrata(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(errata) != 2 {
+		t.Fatalf("Expected 2 errata, got %+v", errata)
+	}
+	if typeChange := errata[0]; typeChange.TextChanged() || typeChange.NewTypeLine != "Creature — Goblin Scout Warrior" {
+		t.Errorf("Expected the newest erratum to change the type line only, got %+v", typeChange)
+	}
+	if textChange := errata[1]; !textChange.TextChanged() || textChange.OldOracleText != "Haste" || textChange.Name != "Goblin Guide" {
+		t.Errorf("Expected the oldest erratum to change the text, got %+v", textChange)
+	}
+	if time.Since(errata[0].ChangedAt) > time.Hour {
+		t.Errorf("Expected a recent change time, got %v", errata[0].ChangedAt)
+	}
+
+	if errata, err := sb.RecentErrata(ctx, time.Now().Add(time.Hour)); err != nil || len(errata) != 0 {
+		t.Errorf("Expected no errata in the future, got %+v, %v", errata, err)
+	}
+}
+
+func TestRecentErrataOfFaces(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	text := func(s string) *string { return &s }
+	card := testAPICard("delver", "delver-1", "Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect")
+	card.CardFaces = []client.CardFace{
+		{Name: "Delver of Secrets", OracleText: text("At the beginning of your upkeep, look at the top card of your library.")},
+		{Name: "Insectile Aberration", OracleText: text("Flying")},
+	}
+	insertTestCard(t, sb, card)
+
+	card.CardFaces[1].OracleText = text("Flying, vigilance")
+	if err := sb.upsertAPICards(ctx, []*client.Card{card}); err != nil {
+		t.Fatalf("Failed to store card: %v", err)
+	}
+
+	errata, err := sb.RecentErrata(ctx, time.Time{})
+	if err != nil || len(errata) != 1 {
+		t.Fatalf("Expected 1 erratum, got %+v, %v", errata, err)
+	}
+	expected := "At the beginning of your upkeep, look at the top card of your library.\nFlying, vigilance"
+	if errata[0].NewOracleText != expected {
+		t.Errorf("Expected new text %q, got %q", expected, errata[0].NewOracleText)
+	}
+}
@@ -0,0 +1,144 @@
+package scryball
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// IsMultiFaced reports whether the card has more than one face: split, flip,
+// adventure, transform, modal double-faced and other multi-face layouts.
+func (card *MagicCard) IsMultiFaced() bool {
+	return card.Card != nil && len(card.CardFaces) > 1
+}
+
+// Faces returns every face of the card, front first.
+//
+// Behavior:
+//   - Single-faced cards return one face built from the card's own fields
+//   - Fields Scryfall only lists on the card rather than each face fall back to
+//     the card: colors, image URIs, artist, illustration, watermark, Oracle ID
+//   - Faces without a type line use the card's type line
+//   - Faces without a mana value get it from their mana cost, or the card's
+//     mana value when they have none, like the back of a transform card
+//   - The returned faces are copies, changing them does not change the card
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Fire // Ice")
+//	for _, face := range card.Faces() {
+//	    fmt.Println(face.Name, face.ManaCost, *face.CMC) // Fire {1}{R} 2, Ice {1}{U} 2
+//	}
+func (card *MagicCard) Faces() []client.CardFace {
+	if card.Card == nil {
+		return nil
+	}
+	if len(card.CardFaces) == 0 {
+		return []client.CardFace{card.singleFace()}
+	}
+
+	faces := make([]client.CardFace, len(card.CardFaces))
+	for i, face := range card.CardFaces {
+		if face.Colors == nil {
+			face.Colors = card.Colors
+		}
+		if face.ImageURIs == nil {
+			face.ImageURIs = card.ImageURIs
+		}
+		if face.Artist == nil {
+			face.Artist = card.Artist
+		}
+		if face.IllustrationID == nil {
+			face.IllustrationID = card.IllustrationID
+		}
+		if face.Watermark == nil {
+			face.Watermark = card.Watermark
+		}
+		if face.OracleID == nil {
+			face.OracleID = card.OracleID
+		}
+		if face.TypeLine == nil {
+			face.TypeLine = &card.TypeLine
+		}
+		if face.CMC == nil {
+			cmc := card.CMC
+			if face.ManaCost != "" {
+				cmc = manaValue(face.ManaCost)
+			}
+			face.CMC = &cmc
+		}
+		faces[i] = face
+	}
+	return faces
+}
+
+// Front returns the front face of the card, the card itself for single-faced cards.
+// See Faces for the fields that fall back to the card.
+func (card *MagicCard) Front() client.CardFace {
+	faces := card.Faces()
+	if len(faces) == 0 {
+		return client.CardFace{}
+	}
+	return faces[0]
+}
+
+// Back returns the second face of a multi-faced card: the back of a transform
+// card, the adventure of an adventurer card, the right half of a split card.
+// Returns false for single-faced cards.
+func (card *MagicCard) Back() (client.CardFace, bool) {
+	if !card.IsMultiFaced() {
+		return client.CardFace{}, false
+	}
+	return card.Faces()[1], true
+}
+
+// singleFace builds the only face of a single-faced card from the card's fields.
+func (card *MagicCard) singleFace() client.CardFace {
+	cmc := card.CMC
+	return client.CardFace{
+		Object:          "card_face",
+		Name:            card.Name,
+		ManaCost:        derefString(card.ManaCost),
+		CMC:             &cmc,
+		TypeLine:        &card.TypeLine,
+		OracleText:      card.OracleText,
+		OracleID:        card.OracleID,
+		Colors:          card.Colors,
+		ColorIndicator:  card.ColorIndicator,
+		Power:           card.Power,
+		Toughness:       card.Toughness,
+		Loyalty:         card.Loyalty,
+		Defense:         card.Defense,
+		FlavorText:      card.FlavorText,
+		Artist:          card.Artist,
+		IllustrationID:  card.IllustrationID,
+		ImageURIs:       card.ImageURIs,
+		Watermark:       card.Watermark,
+		PrintedName:     card.PrintedName,
+		PrintedText:     card.PrintedText,
+		PrintedTypeLine: card.PrintedTypeLine,
+	}
+}
+
+// manaValue returns the mana value of a mana cost like "{2}{W/U}{R}".
+// X is 0, a hybrid symbol counts its largest half: {2/W} is 2.
+func manaValue(cost string) float64 {
+	var total float64
+	for _, symbol := range manaSymbols(cost) {
+		largest := 1.0
+		for _, part := range strings.Split(symbol, "/") {
+			if n, err := strconv.ParseFloat(part, 64); err == nil && (n > largest || part == symbol) {
+				largest = n
+			}
+		}
+		switch {
+		case symbol == "X" || symbol == "Y" || symbol == "Z":
+			largest = 0
+		case strings.HasPrefix(symbol, "H"):
+			largest = 0.5
+		}
+		total += largest
+	}
+	return total
+}
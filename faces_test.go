@@ -0,0 +1,105 @@
+package scryball
+
+import (
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestCardFaces(t *testing.T) {
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withManaCost("{R}"), withCMC(1), withColors("R"))
+	if bolt.IsMultiFaced() {
+		t.Error("Expected Lightning Bolt to be single-faced")
+	}
+	if _, ok := bolt.Back(); ok {
+		t.Error("Expected Lightning Bolt to have no back")
+	}
+	front := bolt.Front()
+	if front.Name != "Lightning Bolt" || front.ManaCost != "{R}" || *front.TypeLine != "Instant" || *front.CMC != 1 {
+		t.Errorf("Expected the front face to be the card itself, got %+v", front)
+	}
+
+	fire, ice := "Fire", "Ice"
+	fireIce := testAPICard("fire-ice", "fire-ice-1", "Fire // Ice", "Instant // Instant")
+	fireIce.CMC = 4
+	fireIce.Colors = []string{"R", "U"}
+	fireIce.ImageURIs = map[string]string{"normal": "https://cards.scryfall.io/normal/front/f/i/fire-ice.jpg"}
+	fireIce.CardFaces = []client.CardFace{
+		{Name: fire, ManaCost: "{1}{R}"},
+		{Name: ice, ManaCost: "{1}{U}"},
+	}
+	card := &MagicCard{Card: fireIce}
+
+	if !card.IsMultiFaced() {
+		t.Error("Expected Fire // Ice to be multi-faced")
+	}
+	back, ok := card.Back()
+	if !ok || back.Name != ice {
+		t.Fatalf("Expected Ice as the back, got %+v", back)
+	}
+	if *back.CMC != 2 || *back.TypeLine != "Instant // Instant" {
+		t.Errorf("Expected mana value 2 from the face's cost and the card's type line, got %v, %v", *back.CMC, *back.TypeLine)
+	}
+	if len(back.Colors) != 2 || back.ImageURIs["normal"] == "" {
+		t.Errorf("Expected colors and images from the card, got %v, %v", back.Colors, back.ImageURIs)
+	}
+	if card.CardFaces[1].CMC != nil {
+		t.Error("Expected Faces not to change the card")
+	}
+
+	delver := testAPICard("delver", "delver-1", "Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect")
+	delver.Layout = "transform"
+	delver.CMC = 1
+	delver.CardFaces = []client.CardFace{
+		{Name: "Delver of Secrets", ManaCost: "{U}", Colors: []string{"U"}},
+		{Name: "Insectile Aberration", Colors: []string{"U"}},
+	}
+	back, _ = (&MagicCard{Card: delver}).Back()
+	if *back.CMC != 1 {
+		t.Errorf("Expected the back of a transform card to have the card's mana value, got %v", *back.CMC)
+	}
+}
+
+func TestManaValue(t *testing.T) {
+	for cost, expected := range map[string]float64{
+		"":              0,
+		"{0}":           0,
+		"{X}{R}":        1,
+		"{2}{W}{W}":     4,
+		"{2/W}{2/W}":    4,
+		"{W/U}{B/P}{G}": 3,
+		"{HW}":          0.5,
+		"{10}":          10,
+	} {
+		if got := manaValue(cost); got != expected {
+			t.Errorf("manaValue(%q) = %v, expected %v", cost, got, expected)
+		}
+	}
+}
+
+func TestCardFacesLoadedFromDB(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	frontText, backText := "At the beginning of your upkeep, look at the top card of your library.", "Flying"
+	delver := testAPICard("delver", "delver-1", "Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect")
+	delver.Layout = "transform"
+	delver.CardFaces = []client.CardFace{
+		{Object: "card_face", Name: "Delver of Secrets", ManaCost: "{U}", OracleText: &frontText, ImageURIs: map[string]string{"normal": "https://cards.scryfall.io/normal/front/d/e/delver-1.jpg"}},
+		{Object: "card_face", Name: "Insectile Aberration", OracleText: &backText, ImageURIs: map[string]string{"normal": "https://cards.scryfall.io/normal/back/d/e/delver-1.jpg"}},
+	}
+
+	card := insertTestCard(t, sb, delver)
+	if !card.IsMultiFaced() {
+		t.Fatalf("Expected the cached card to keep its faces, got %+v", card.CardFaces)
+	}
+	back, _ := card.Back()
+	if back.Name != "Insectile Aberration" || *back.OracleText != backText || back.ImageURIs["normal"] == "" {
+		t.Errorf("Expected the back face to round trip through the cache, got %+v", back)
+	}
+
+	bolt := insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+	if bolt.CardFaces != nil {
+		t.Errorf("Expected no faces for a single-faced card, got %+v", bolt.CardFaces)
+	}
+}
@@ -0,0 +1,139 @@
+package scryball
+
+import (
+	"slices"
+	"strings"
+)
+
+// Filter returns the cards match keeps, in their original order.
+//
+// Example:
+//
+//	cheap := scryball.Filter(cards, func(card *scryball.MagicCard) bool {
+//	    return card.CMC <= 2
+//	})
+func Filter(cards []*MagicCard, match func(card *MagicCard) bool) []*MagicCard {
+	filtered := []*MagicCard{}
+	for _, card := range cards {
+		if match(card) {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered
+}
+
+// Partition splits cards into the ones match keeps and the rest, both in their original order.
+//
+// Example:
+//
+//	creatures, others := scryball.Partition(cards, func(card *scryball.MagicCard) bool {
+//	    return strings.Contains(card.TypeLine, "Creature")
+//	})
+func Partition(cards []*MagicCard, match func(card *MagicCard) bool) (matched, rest []*MagicCard) {
+	matched, rest = []*MagicCard{}, []*MagicCard{}
+	for _, card := range cards {
+		if match(card) {
+			matched = append(matched, card)
+		} else {
+			rest = append(rest, card)
+		}
+	}
+	return matched, rest
+}
+
+// PartitionLands splits cards into lands and nonlands by their front face, both in their original order.
+func PartitionLands(cards []*MagicCard) (lands, nonlands []*MagicCard) {
+	return Partition(cards, func(card *MagicCard) bool {
+		return card.Card != nil && isLand(card)
+	})
+}
+
+// FilterByColor returns the cards that are color, in their original order.
+// ColorColorless keeps the colorless cards. Multicolored cards are kept for
+// each of their colors. Double-faced cards count the colors of both faces.
+//
+// Example:
+//
+//	red := scryball.FilterByColor(cards, scryball.ColorRed)
+func FilterByColor(cards []*MagicCard, color Color) []*MagicCard {
+	return Filter(cards, func(card *MagicCard) bool {
+		if card.Card == nil {
+			return false
+		}
+		colors := cardColors(card)
+		if color == ColorColorless {
+			return len(colors) == 0
+		}
+		return colors.Contains(color)
+	})
+}
+
+// FilterByType returns the cards whose type line has every word of typeName,
+// ignoring case, in their original order. Types, supertypes and subtypes of
+// every face all match: "creature", "Legendary Creature", "Elf" and "Saga".
+//
+// Example:
+//
+//	elves := scryball.FilterByType(cards, "elf")
+func FilterByType(cards []*MagicCard, typeName string) []*MagicCard {
+	wanted := strings.Fields(strings.ToLower(typeName))
+	return Filter(cards, func(card *MagicCard) bool {
+		if card.Card == nil || len(wanted) == 0 {
+			return false
+		}
+		words := strings.Fields(strings.ToLower(card.TypeLine))
+		for _, word := range wanted {
+			if !slices.Contains(words, word) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// FilterByRarity returns the cards with a printing of rarity, in their original order.
+//
+// Example:
+//
+//	commons := scryball.FilterByRarity(cards, scryball.RarityCommon)
+func FilterByRarity(cards []*MagicCard, rarity Rarity) []*MagicCard {
+	return Filter(cards, func(card *MagicCard) bool {
+		if card.Card != nil && Rarity(card.Rarity) == rarity {
+			return true
+		}
+		return slices.ContainsFunc(card.Printings, func(p Printing) bool {
+			return p.Rarity == rarity
+		})
+	})
+}
+
+// FilterBySet returns the cards with a printing in the set with setCode,
+// ignoring case, in their original order.
+//
+// Example:
+//
+//	alpha := scryball.FilterBySet(cards, "lea")
+func FilterBySet(cards []*MagicCard, setCode string) []*MagicCard {
+	return Filter(cards, func(card *MagicCard) bool {
+		if card.Card != nil && strings.EqualFold(card.Set, setCode) {
+			return true
+		}
+		return slices.ContainsFunc(card.Printings, func(p Printing) bool {
+			return strings.EqualFold(p.SetCode, setCode)
+		})
+	})
+}
+
+// cardColors returns the card's colors, or the colors of its faces for
+// double-faced cards, which have no colors of their own.
+func cardColors(card *MagicCard) Colors {
+	colors := card.ColorSet()
+	if len(colors) > 0 || len(card.CardFaces) == 0 {
+		return colors
+	}
+	var faceColors []string
+	for _, face := range card.CardFaces {
+		faceColors = append(faceColors, face.Colors...)
+	}
+	return toColors(faceColors)
+}
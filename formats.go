@@ -0,0 +1,198 @@
+package scryball
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Format is a Scryfall legality key, the formats a card's Legalities map lists.
+type Format string
+
+const (
+	FormatStandard        Format = "standard"
+	FormatFuture          Format = "future"
+	FormatPioneer         Format = "pioneer"
+	FormatExplorer        Format = "explorer"
+	FormatModern          Format = "modern"
+	FormatLegacy          Format = "legacy"
+	FormatVintage         Format = "vintage"
+	FormatPauper          Format = "pauper"
+	FormatPenny           Format = "penny"
+	FormatPremodern       Format = "premodern"
+	FormatOldschool       Format = "oldschool"
+	FormatAlchemy         Format = "alchemy"
+	FormatHistoric        Format = "historic"
+	FormatTimeless        Format = "timeless"
+	FormatCommander       Format = "commander"
+	FormatDuel            Format = "duel"
+	FormatPauperCommander Format = "paupercommander"
+	FormatPreDH           Format = "predh"
+	FormatBrawl           Format = "brawl"
+	FormatStandardBrawl   Format = "standardbrawl"
+	FormatOathbreaker     Format = "oathbreaker"
+	FormatGladiator       Format = "gladiator"
+)
+
+// IsLegal reports whether the card can be played in format: its legality is
+// "legal" or "restricted". Cards without legality data are legal nowhere.
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Lightning Bolt")
+//	card.IsLegal(scryball.FormatModern)   // true
+//	card.IsLegal(scryball.FormatStandard) // false
+func (card *MagicCard) IsLegal(format Format) bool {
+	if card.Card == nil {
+		return false
+	}
+	switch card.Legalities[string(format)] {
+	case "legal", "restricted":
+		return true
+	}
+	return false
+}
+
+// LegalFormats returns every format the card is legal or restricted in, sorted by name.
+func (card *MagicCard) LegalFormats() []Format {
+	if card.Card == nil {
+		return nil
+	}
+	var legal []Format
+	for format := range card.Legalities {
+		if card.IsLegal(Format(format)) {
+			legal = append(legal, Format(format))
+		}
+	}
+	slices.Sort(legal)
+	return legal
+}
+
+// constructedFormat returns the rules of a 60 card format with a 15 card sideboard.
+func constructedFormat(format string) ValidationRules {
+	return slices.Concat(ConstructedRules, ValidationRules{LegalityRule(format)})
+}
+
+// commanderFormat returns the rules of a singleton commander format of exactly size cards.
+func commanderFormat(format string, size int) ValidationRules {
+	return ValidationRules{CommanderRule(), DeckSizeRule(size, size), CopyLimitRule(1), LegalityRule(format)}
+}
+
+// brawlFormat is commanderFormat for formats that allow planeswalker commanders.
+func brawlFormat(format string, size int) ValidationRules {
+	return ValidationRules{commanderRule(canBeBrawlCommander), DeckSizeRule(size, size), CopyLimitRule(1), LegalityRule(format)}
+}
+
+// formats is every format LegalFormats checks, keyed like Scryfall legalities.
+var formats = map[string]ValidationRules{
+	"standard":  constructedFormat("standard"),
+	"future":    constructedFormat("future"),
+	"pioneer":   constructedFormat("pioneer"),
+	"explorer":  constructedFormat("explorer"),
+	"modern":    constructedFormat("modern"),
+	"legacy":    constructedFormat("legacy"),
+	"vintage":   constructedFormat("vintage"),
+	"pauper":    constructedFormat("pauper"),
+	"penny":     constructedFormat("penny"),
+	"premodern": constructedFormat("premodern"),
+	"oldschool": constructedFormat("oldschool"),
+	"alchemy":   constructedFormat("alchemy"),
+	"historic":  constructedFormat("historic"),
+	"timeless":  constructedFormat("timeless"),
+
+	"commander":       commanderFormat("commander", 100),
+	"duel":            commanderFormat("duel", 100),
+	"paupercommander": commanderFormat("paupercommander", 100),
+	"predh":           commanderFormat("predh", 100),
+	"brawl":           brawlFormat("brawl", 100),
+	"standardbrawl":   brawlFormat("standardbrawl", 60),
+	"oathbreaker":     brawlFormat("oathbreaker", 60),
+	"gladiator":       {DeckSizeRule(100, 100), SideboardSizeRule(0), CopyLimitRule(1), LegalityRule("gladiator")},
+}
+
+// LegalFormats returns the formats the deck is legal in, sorted by name.
+//
+// Behavior:
+//   - Every card (maindeck, sideboard, commanders, companion) must be legal or
+//     restricted in the format, using each card's Legalities
+//   - Restricted cards are limited to one copy
+//   - Deck size, sideboard size and copy limits must be met: 60+ cards with a 15 card
+//     sideboard and 4 copies for constructed formats, exactly 100 singleton cards
+//     including commanders for commander formats (60 for standardbrawl and oathbreaker)
+//   - Commander formats need one commander or a valid pair (see ValidateCommander),
+//     and every card within their color identity
+//   - Cards without legality data make the deck legal in no format
+//
+// Format names are Scryfall's legality keys: "standard", "modern", "pauper", "commander", ...
+func (d *Decklist) LegalFormats() []string {
+	var legal []string
+	for format, rules := range formats {
+		if !d.hasLegalityData(format) {
+			continue
+		}
+		if rules.Validate(d) == nil {
+			legal = append(legal, format)
+		}
+	}
+	sort.Strings(legal)
+	return legal
+}
+
+// ValidateFormat checks the deck against the rules of format, the same rules LegalFormats uses.
+//
+// Returns:
+//   - error: nil if the deck is legal, a *ValidationError listing every violation,
+//     or an error if format is not one LegalFormats checks
+//
+// Example:
+//
+//	if err := deck.ValidateFormat("modern"); err != nil {
+//		fmt.Println(err)
+//	}
+func (d *Decklist) ValidateFormat(format string) error {
+	rules, ok := formats[format]
+	if !ok {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	return rules.Validate(d)
+}
+
+// hasLegalityData reports whether every card in the deck has a legality for format.
+func (d *Decklist) hasLegalityData(format string) bool {
+	for _, card := range d.allCards() {
+		if card.Legalities[format] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// BannedCards returns the cards whose legality in format is "banned" or "not_legal".
+//
+// Behavior:
+//   - Checks maindeck, sideboard, commanders and companion
+//   - Each card is listed once, sorted by name
+//   - Cards without legality data for format are not listed
+//
+// Example:
+//
+//	for _, card := range deck.BannedCards("modern") {
+//		fmt.Printf("%s is %s in Modern\n", card.Name, card.Legalities["modern"])
+//	}
+func (d *Decklist) BannedCards(format string) []*MagicCard {
+	banned := []*MagicCard{}
+	for _, card := range d.allCards() {
+		switch card.Legalities[format] {
+		case "banned", "not_legal":
+			banned = append(banned, card)
+		}
+	}
+	return banned
+}
+
+// IsPennyLegal reports whether the card is legal in the current Penny Dreadful
+// season, the budget format of cards costing 0.02 tix or less on MTGO.
+// See PennyRank for how popular the card is in the format.
+func (card *MagicCard) IsPennyLegal() bool {
+	return card.IsLegal(FormatPenny)
+}
@@ -0,0 +1,192 @@
+package scryball
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDecklistLegalFormats(t *testing.T) {
+	everywhere := make([]string, 0, len(formats))
+	for format := range formats {
+		everywhere = append(everywhere, format)
+	}
+
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withLegalities("modern", "legacy", "vintage", "pauper", "commander"))
+	mountain := testCard("mountain", "Mountain", "Basic Land — Mountain", withLegalities(everywhere...))
+	pyro := testCard("pyro", "Pyroblast", "Instant", withLegalities("legacy", "vintage", "commander"))
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 56)
+	deck.AddSideboardCard(pyro, 4)
+
+	expected := []string{"legacy", "vintage"}
+	if got := deck.LegalFormats(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	deck.RemoveSideboardCard(pyro, 4)
+	expected = []string{"legacy", "modern", "pauper", "vintage"}
+	if got := deck.LegalFormats(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v without sideboard, got %v", expected, got)
+	}
+
+	// restricted cards are limited to one copy
+	bolt.Legalities["vintage"] = "restricted"
+	expected = []string{"legacy", "modern", "pauper"}
+	if got := deck.LegalFormats(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v with 4 restricted Lightning Bolt, got %v", expected, got)
+	}
+
+	deck.RemoveCard(mountain, 1)
+	if got := deck.LegalFormats(); len(got) != 0 {
+		t.Errorf("Expected 59 card deck to be legal nowhere, got %v", got)
+	}
+}
+
+func TestDecklistValidateFormat(t *testing.T) {
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withLegalities("modern", "legacy"))
+	mountain := testCard("mountain", "Mountain", "Basic Land — Mountain", withLegalities("modern", "legacy", "standard"))
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 56)
+
+	if err := deck.ValidateFormat("modern"); err != nil {
+		t.Errorf("Expected deck to be legal in modern, got %v", err)
+	}
+	var verr *ValidationError
+	if err := deck.ValidateFormat("standard"); !errors.As(err, &verr) || len(verr.Violations) != 1 {
+		t.Errorf("Expected 1 violation in standard, got %v", err)
+	}
+	if err := deck.ValidateFormat("chess"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestDecklistLegalFormatsCommander(t *testing.T) {
+	atraxa := testCard("atraxa", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror", withLegalities("commander", "duel"))
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withLegalities("commander", "duel", "legacy"))
+	forest := testCard("forest", "Forest", "Basic Land — Forest", withLegalities("commander", "duel", "legacy"))
+
+	deck := NewDecklist()
+	deck.Commanders = []*MagicCard{atraxa}
+	deck.AddCard(bolt, 1)
+	deck.AddCard(forest, 98)
+
+	expected := []string{"commander", "duel"}
+	if got := deck.LegalFormats(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	deck.SetQuantity(bolt, 2)
+	deck.SetQuantity(forest, 97)
+	if got := deck.LegalFormats(); len(got) != 0 {
+		t.Errorf("Expected 2 Lightning Bolt to break singleton, got %v", got)
+	}
+
+	noCommander := NewDecklist()
+	noCommander.AddCard(forest, 100)
+	for _, format := range noCommander.LegalFormats() {
+		if format == "commander" {
+			t.Error("Expected commander to require a commander")
+		}
+	}
+}
+
+func TestDecklistBannedCards(t *testing.T) {
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withLegalities("modern", "legacy"))
+	ragavan := testCard("ragavan", "Ragavan, Nimble Pilferer", "Legendary Creature — Monkey Pirate", withLegalities("legacy"))
+	ragavan.Legalities["modern"] = "banned"
+	omnath := testCard("omnath", "Omnath, Locus of Creation", "Legendary Creature — Elemental", withLegalities("legacy"))
+	lurrus := testCard("lurrus", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare", withLegalities("legacy"))
+	lurrus.Legalities["modern"] = "banned"
+	unknown := &MagicCard{Card: testAPICard("unknown", "unknown-1", "Unknown Card", "Instant")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(ragavan, 4)
+	deck.AddCard(unknown, 1)
+	deck.AddSideboardCard(omnath, 2)
+	deck.AddSideboardCard(ragavan, 1)
+	deck.Companion = lurrus
+
+	banned := deck.BannedCards("modern")
+	var names []string
+	for _, card := range banned {
+		names = append(names, card.Name)
+	}
+	expected := []string{"Lurrus of the Dream-Den", "Omnath, Locus of Creation", "Ragavan, Nimble Pilferer"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+
+	if banned := deck.BannedCards("legacy"); len(banned) != 0 {
+		t.Errorf("Expected nothing banned in legacy, got %d cards", len(banned))
+	}
+}
+
+func TestCardLegality(t *testing.T) {
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withLegalities("modern", "legacy", "pauper"))
+	bolt.Legalities["vintage"] = "restricted"
+	bolt.Legalities["standard"] = "banned"
+
+	if !bolt.IsLegal(FormatModern) || !bolt.IsLegal(FormatVintage) {
+		t.Error("Expected Lightning Bolt to be legal in modern and vintage")
+	}
+	if bolt.IsLegal(FormatStandard) || bolt.IsLegal(FormatPioneer) {
+		t.Error("Expected banned and not legal formats not to be legal")
+	}
+
+	expected := []Format{FormatLegacy, FormatModern, FormatPauper, FormatVintage}
+	if got := bolt.LegalFormats(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	unknown := &MagicCard{Card: testAPICard("new", "new-1", "Spoiled Card", "Instant")}
+	if unknown.IsLegal(FormatModern) || len(unknown.LegalFormats()) != 0 {
+		t.Error("Expected a card without legality data to be legal nowhere")
+	}
+}
+
+func TestCardLegalityFromDB(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	card := insertTestCard(t, sb, testCard("bolt", "Lightning Bolt", "Instant", withLegalities("modern")).Card)
+	if !card.IsLegal(FormatModern) || card.IsLegal(FormatStandard) {
+		t.Errorf("Expected legalities to be loaded from the cache, got %v", card.Legalities)
+	}
+}
+
+func TestPennyDreadful(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	rank := 12
+	apiCard := testCard("bolt", "Lightning Bolt", "Instant", withLegalities("penny", "modern")).Card
+	apiCard.PennyRank = &rank
+	bolt := insertTestCard(t, sb, apiCard)
+	if !bolt.IsPennyLegal() || bolt.PennyRank == nil || *bolt.PennyRank != 12 {
+		t.Errorf("Expected penny legality and rank 12 from the cache, got %v, %v", bolt.Legalities["penny"], bolt.PennyRank)
+	}
+
+	mountain := testCard("mountain", "Mountain", "Basic Land — Mountain", withLegalities("penny"))
+	snapcaster := testCard("snapcaster", "Snapcaster Mage", "Creature — Human Wizard", withLegalities("modern"))
+	if snapcaster.IsPennyLegal() {
+		t.Error("Expected Snapcaster Mage not to be penny legal")
+	}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 56)
+	if err := deck.ValidatePenny(); err != nil {
+		t.Errorf("Expected a penny legal deck, got %v", err)
+	}
+
+	deck.AddSideboardCard(snapcaster, 2)
+	if err := deck.ValidatePenny(); err == nil {
+		t.Error("Expected Snapcaster Mage to be rejected")
+	}
+}
@@ -0,0 +1,138 @@
+package scryball
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SimilarCardNames returns cached card names that are close to name, for
+// "did you mean" suggestions when a name has a typo.
+//
+// Behavior:
+//   - Only checks database cache, never queries API
+//   - Compares names by edit distance, ignoring case, accents and punctuation. Each face of a
+//     double-faced or split card is compared on its own as well
+//   - Allows about one typo per 4 letters of name, at least 1
+//   - Closest names first, ties sorted by name, at most max names
+//
+// Returns:
+//   - []string: Similar cached names (empty array if none are close)
+//   - error: Database errors
+//
+// Example:
+//
+//	names, err := sb.SimilarCardNames(ctx, "Lightning Blot", 5)
+//	// [Lightning Bolt]
+func (s *Scryball) SimilarCardNames(ctx context.Context, name string, max int) ([]string, error) {
+	names, err := s.queries.ListCardNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("database error listing card names: %v", err)
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var (
+		candidates  []candidate
+		maxDistance = maxTypos(name)
+	)
+	for _, cardName := range names {
+		if d := nameDistance(name, cardName); d <= maxDistance {
+			candidates = append(candidates, candidate{cardName, d})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		return cmp.Or(cmp.Compare(a.distance, b.distance), cmp.Compare(a.name, b.name))
+	})
+
+	similar := []string{}
+	for _, c := range candidates {
+		if len(similar) == max {
+			break
+		}
+		similar = append(similar, c.name)
+	}
+	return similar, nil
+}
+
+// maxTypos is the edit distance a name can be from a card's name and still be suggested.
+func maxTypos(name string) int {
+	return max(1, len([]rune(name))/4)
+}
+
+// nameDistance is the edit distance between name and a card name, or the
+// closest of its faces: "Delver of Secrets" is 0 from "Delver of Secrets // Insectile Aberration".
+// Both are folded with foldCardName first, accents and punctuation are not typos.
+func nameDistance(name, cardName string) int {
+	name, cardName = foldCardName(name), foldCardName(cardName)
+	distance := levenshtein(name, cardName)
+	if strings.Contains(cardName, " // ") {
+		for _, face := range strings.Split(cardName, " // ") {
+			distance = min(distance, levenshtein(name, face))
+		}
+	}
+	return distance
+}
+
+// levenshtein counts the single letter insertions, deletions and substitutions
+// needed to turn a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// CardNotFoundError is returned when a card name cannot be found in the cache
+// or on Scryfall, with names the user may have meant.
+//
+//	var notFound *scryball.CardNotFoundError
+//	if errors.As(err, &notFound) {
+//		fmt.Println("Did you mean:", strings.Join(notFound.Suggestions, ", "))
+//	}
+type CardNotFoundError struct {
+	Name        string   // Name as it was searched for
+	Suggestions []string // Up to 5 similar card names, closest first, empty if none
+}
+
+func (e *CardNotFoundError) Error() string {
+	if len(e.Suggestions) == 0 {
+		return fmt.Sprintf("card not found: %s", e.Name)
+	}
+	return fmt.Sprintf("card not found: %s, did you mean %s?", e.Name, strings.Join(e.Suggestions, ", "))
+}
+
+// maxSuggestions is the number of names a CardNotFoundError suggests at most.
+const maxSuggestions = 5
+
+// cardNotFoundError returns a *CardNotFoundError for cardName, suggesting
+// similar cached names, or Scryfall's autocompletions when none are cached.
+func (s *Scryball) cardNotFoundError(ctx context.Context, cardName string) error {
+	notFound := &CardNotFoundError{Name: cardName, Suggestions: []string{}}
+	if similar, err := s.SimilarCardNames(ctx, cardName, maxSuggestions); err == nil && len(similar) > 0 {
+		notFound.Suggestions = similar
+		return notFound
+	}
+	if names, err := s.client.AutocompleteCardNames(cardName); err == nil {
+		notFound.Suggestions = names[:min(len(names), maxSuggestions)]
+	}
+	return notFound
+}
@@ -0,0 +1,99 @@
+package scryball
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"bolt", "bolt", 0},
+		{"bolt", "blot", 2},
+		{"tarmogoyf", "tarmogof", 1},
+		{"", "opt", 3},
+		{"lim-dûl", "lim-dul", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.expected {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
+
+func TestSimilarCardNames(t *testing.T) {
+	sb := testLocalQueryDB(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		max      int
+		expected []string
+	}{
+		{"Lightning Blot", 5, []string{"Lightning Bolt"}},
+		{"tarmogof", 5, []string{"Tarmogoyf"}},
+		{"Delver of Secret", 5, []string{"Delver of Secrets // Insectile Aberration"}},
+		{"Insectile Abberation", 5, []string{"Delver of Secrets // Insectile Aberration"}},
+		{"Counterspell", 5, []string{}},
+		{"Sol Rang", 0, []string{}},
+	}
+	for _, tt := range tests {
+		names, err := sb.SimilarCardNames(ctx, tt.name, tt.max)
+		if err != nil {
+			t.Fatalf("SimilarCardNames(%q) error: %v", tt.name, err)
+		}
+		if !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("SimilarCardNames(%q) = %v, want %v", tt.name, names, tt.expected)
+		}
+	}
+}
+
+func TestCardNotFoundErrorSuggestions(t *testing.T) {
+	var autocompleted []string
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cards/autocomplete":
+			autocompleted = append(autocompleted, r.URL.Query().Get("q"))
+			w.Write([]byte(`{"object": "catalog", "total_values": 6, "data": ["Counterspell", "Counterbalance", "Counterflux", "Counterlash", "Countersquall", "Counterbore"]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	insertTestCard(t, sb, testAPICard("serra", "serra-1", "Serra Angel", "Creature — Angel"))
+	ctx := context.Background()
+
+	var notFound *CardNotFoundError
+	err := sb.cardNotFoundError(ctx, "Serra Angle")
+	if !errors.As(err, &notFound) || !reflect.DeepEqual(notFound.Suggestions, []string{"Serra Angel"}) {
+		t.Errorf("Expected the cached Serra Angel as the only suggestion, got %v", err)
+	}
+	if expected := "card not found: Serra Angle, did you mean Serra Angel?"; err.Error() != expected {
+		t.Errorf("got %q, want %q", err, expected)
+	}
+	if len(autocompleted) != 0 {
+		t.Errorf("Expected no autocomplete request when a cached name is close, got %v", autocompleted)
+	}
+
+	// nothing close is cached, Scryfall's autocompletions are suggested
+	_, err = sb.QueryCard("Counter")
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected QueryCard to return a *CardNotFoundError, got %v", err)
+	}
+	if notFound.Name != "Counter" || len(notFound.Suggestions) != 5 || notFound.Suggestions[0] != "Counterspell" {
+		t.Errorf("Expected 5 autocompleted suggestions, got %+v", notFound)
+	}
+
+	_, problems, err := sb.ParseDecklistLenient("4 Serra Angle\n")
+	if err != nil || len(problems) != 1 {
+		t.Fatalf("Expected 1 problem, got %v %v", problems, err)
+	}
+	if !errors.As(problems[0], &notFound) || notFound.Suggestions[0] != "Serra Angel" {
+		t.Errorf("Expected the line error to wrap a *CardNotFoundError, got %v", problems[0])
+	}
+}
@@ -0,0 +1,339 @@
+package scryball
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ninesl/scryball/internal/client"
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// ImageSize is one of the image versions Scryfall serves for every printing.
+type ImageSize string
+
+const (
+	ImageSmall      ImageSize = "small"       // 146x204 JPEG
+	ImageNormal     ImageSize = "normal"      // 488x680 JPEG
+	ImageLarge      ImageSize = "large"       // 672x936 JPEG
+	ImagePNG        ImageSize = "png"         // 745x1040 PNG with transparent rounded corners
+	ImageArtCrop    ImageSize = "art_crop"    // JPEG of the art only
+	ImageBorderCrop ImageSize = "border_crop" // 480x680 JPEG with the border cropped off
+)
+
+// imageURIForSize turns a Scryfall image URI of any size into the URI of size.
+//
+// Scryfall image URIs differ only in the first path segment and the file
+// extension: https://cards.scryfall.io/normal/front/6/d/6da0.jpg?1562
+func imageURIForSize(uri string, size ImageSize) (string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", fmt.Errorf("invalid image URI %s: %v", uri, err)
+	}
+	segments := strings.Split(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if len(segments) < 2 {
+		return "", fmt.Errorf("not a Scryfall image URI: %s", uri)
+	}
+
+	segments[0] = string(size)
+	file := segments[len(segments)-1]
+	ext := ".jpg"
+	if size == ImagePNG {
+		ext = ".png"
+	}
+	if dot := strings.LastIndex(file, "."); dot != -1 {
+		segments[len(segments)-1] = file[:dot] + ext
+	}
+
+	parsed.Path = "/" + strings.Join(segments, "/")
+	return parsed.String(), nil
+}
+
+// apiFaceImageURIs returns the image URIs of each face of a double-sided
+// printing from the API, front first. nil for printings with a single image.
+func apiFaceImageURIs(apiCard *client.Card) []map[string]string {
+	var faces []map[string]string
+	for _, face := range apiCard.CardFaces {
+		if face.ImageURIs != nil {
+			faces = append(faces, face.ImageURIs)
+		}
+	}
+	return faces
+}
+
+// upsertPrintingFaces stores the face image URIs of a double-sided printing
+// from the API, as Scryfall lists them. Does nothing for other printings.
+func upsertPrintingFaces(ctx context.Context, queries *scryfall.Queries, apiCard *client.Card) error {
+	faces := apiFaceImageURIs(apiCard)
+	if len(faces) == 0 {
+		return nil
+	}
+	facesJSON, err := json.Marshal(faces)
+	if err != nil {
+		return err
+	}
+	return queries.UpsertPrintingFaces(ctx, scryfall.UpsertPrintingFacesParams{
+		PrintingID: apiCard.ID,
+		ImageUris:  string(facesJSON),
+	})
+}
+
+// getPrintingFacesFromDB returns the stored face image URIs of a card's double-sided printings, by printing ID.
+func (s *Scryball) getPrintingFacesFromDB(ctx context.Context, oracleID string) (map[string][]map[string]string, error) {
+	rows, err := s.queries.ListPrintingFacesByOracleID(ctx, oracleID)
+	if err != nil {
+		return nil, err
+	}
+	faces := make(map[string][]map[string]string, len(rows))
+	for _, row := range rows {
+		var uris []map[string]string
+		if err := json.Unmarshal([]byte(row.ImageUris), &uris); err == nil {
+			faces[row.PrintingID] = uris
+		}
+	}
+	return faces, nil
+}
+
+// CardBackImageURI returns the URI of the image of the printing's card back in size,
+// "" if the printing has no CardBackID. Double-sided printings have no card back.
+//
+// Card backs are only served as JPEGs, ImagePNG returns the large JPEG.
+func (p Printing) CardBackImageURI(size ImageSize) string {
+	id := p.CardBackID
+	if len(id) < 2 {
+		return ""
+	}
+	if size == ImagePNG {
+		size = ImageLarge
+	}
+	return fmt.Sprintf("https://backs.scryfall.io/%s/%c/%c/%s.jpg", size, id[0], id[1], id)
+}
+
+// ImageURIForSize returns the URI of the printing's front face image in size.
+//
+// Uses the sizes Scryfall listed for the printing, or for the front face of
+// double-faced printings, when it has them, otherwise derives the URI from ImageURI.
+// Returns "" if the printing has no image.
+func (p Printing) ImageURIForSize(size ImageSize) string {
+	if uri := p.ImageURIs[string(size)]; uri != "" {
+		return uri
+	}
+	if len(p.FaceImageURIs) > 0 {
+		if uri := p.FaceImageURIs[0][string(size)]; uri != "" {
+			return uri
+		}
+	}
+	if p.ImageURI != "" {
+		if uri, err := imageURIForSize(p.ImageURI, size); err == nil {
+			return uri
+		}
+	}
+	return ""
+}
+
+// doubleSidedLayouts are the layouts printed with a separate image on each side of the card.
+var doubleSidedLayouts = []string{"transform", "modal_dfc", "double_faced_token", "reversible_card", "art_series"}
+
+// FaceImageURI returns the image URI of one face of a printing of the card.
+//
+// Behavior:
+//   - face 0 is the front, the same image as printing.ImageURIForSize
+//   - face 1 is the back of double-sided layouts (transform, modal_dfc, ...).
+//     Split, flip and adventure cards have a single image for both faces
+//   - Uses the face image URIs of the card itself when printing is the card's own printing
+//
+// Returns:
+//   - error: The card has no such face, or the printing has no image
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Delver of Secrets")
+//	back, err := card.FaceImageURI(card.Printings[0], 1, scryball.ImageLarge)
+func (card *MagicCard) FaceImageURI(printing Printing, face int, size ImageSize) (string, error) {
+	doubleSided := card.Card != nil && slices.Contains(doubleSidedLayouts, card.Layout)
+	if face < 0 || face > 1 || (face == 1 && !doubleSided) {
+		return "", fmt.Errorf("%s has no face %d", card.Name, face)
+	}
+
+	if card.Card != nil && card.ID == printing.ID && face < len(card.CardFaces) {
+		if uri := card.CardFaces[face].ImageURIs[string(size)]; uri != "" {
+			return uri, nil
+		}
+	}
+
+	var uri string
+	if face == 0 {
+		uri = printing.ImageURIForSize(size)
+	} else if face < len(printing.FaceImageURIs) {
+		uri = printing.FaceImageURIs[face][string(size)]
+	}
+	if uri == "" {
+		return "", fmt.Errorf("printing %s of %s has no image", printing.ID, card.Name)
+	}
+	return uri, nil
+}
+
+// FetchImage returns the image of a printing, downloading it only the first time.
+//
+// Behavior:
+//   - Images are cached as blobs in this instance's database, so they survive
+//     restarts when the database is on disk, or as files in ScryballConfig.ImageDir
+//   - The cache is keyed by the full image URI, which changes when Scryfall
+//     updates an image, so updated printings are downloaded again
+//   - Fetches the front face, see MagicCard.FaceImageURI for the back of double-faced cards
+//
+// Returns:
+//   - []byte: The image file, JPEG for every size except ImagePNG
+//   - error: Printings without an image, download or database errors
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Lightning Bolt")
+//	img, err := sb.FetchImage(ctx, card.Printings[0], scryball.ImageLarge)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("bolt.jpg", img, 0o644)
+func (s *Scryball) FetchImage(ctx context.Context, printing Printing, size ImageSize) ([]byte, error) {
+	uri := printing.ImageURIForSize(size)
+	if uri == "" {
+		return nil, fmt.Errorf("printing %s has no image", printing.ID)
+	}
+	return s.fetchImage(ctx, uri)
+}
+
+// SetIcon returns the SVG icon of a set, downloading it only the first time,
+// so deck UIs can render set symbols offline.
+//
+// Behavior:
+//   - The set code is case insensitive ("NEO" and "neo" are the same set)
+//   - The first call looks the set up on Scryfall for its icon URI, later calls
+//     never query API
+//   - The SVG is cached like FetchImage caches images: in this instance's
+//     database, or as a file in ScryballConfig.ImageDir
+//
+// Returns:
+//   - []byte: The icon as an SVG document
+//   - error: Unknown sets, download or database errors
+//
+// Example:
+//
+//	svg, err := sb.SetIcon(ctx, "neo")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	os.WriteFile("neo.svg", svg, 0o644)
+func (s *Scryball) SetIcon(ctx context.Context, code string) ([]byte, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		return nil, errors.New("set code must not be empty")
+	}
+
+	uri, err := s.queries.GetSetIconURI(ctx, code)
+	if err == nil {
+		return s.fetchImage(ctx, uri)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not read icon of set %s: %v", code, err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	set, err := s.client.GetSet(code)
+	if errors.Is(err, client.ErrNotFound) {
+		return nil, fmt.Errorf("unknown set %s", code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	uri = set.IconSVGURI.String()
+	if uri == "" {
+		return nil, fmt.Errorf("set %s has no icon", code)
+	}
+
+	data, err := s.fetchImage(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.queries.UpsertSetIcon(ctx, scryfall.UpsertSetIconParams{Code: code, IconUri: uri}); err != nil {
+		return nil, fmt.Errorf("could not cache icon of set %s: %v", code, err)
+	}
+	return data, nil
+}
+
+// fetchImage returns the image at uri from the image cache, downloading and caching it on a miss.
+func (s *Scryball) fetchImage(ctx context.Context, uri string) ([]byte, error) {
+	data, err := s.cachedImage(ctx, uri)
+	if err == nil {
+		return data, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("could not read image cache: %v", err)
+	}
+
+	data, err = s.client.FetchImage(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cacheImage(ctx, uri, data); err != nil {
+		return nil, fmt.Errorf("could not cache image %s: %v", uri, err)
+	}
+	return data, nil
+}
+
+// cachedImage reads an image from the image directory or database.
+// Returns sql.ErrNoRows if it has not been downloaded.
+func (s *Scryball) cachedImage(ctx context.Context, uri string) ([]byte, error) {
+	if s.imageDir == "" {
+		return s.queries.GetCachedImage(ctx, uri)
+	}
+	data, err := os.ReadFile(s.imageFile(uri))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, sql.ErrNoRows
+	}
+	return data, err
+}
+
+// cacheImage stores a downloaded image in the image directory or database.
+func (s *Scryball) cacheImage(ctx context.Context, uri string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.imageDir == "" {
+		return s.queries.UpsertCachedImage(ctx, scryfall.UpsertCachedImageParams{Uri: uri, Data: data})
+	}
+
+	// write then rename, so a partial download is never read back
+	file := s.imageFile(uri)
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+// imageFile returns the file an image is stored in within the image directory.
+// Files are named by a hash of the full URI, so updated images get a new file.
+func (s *Scryball) imageFile(uri string) string {
+	ext := ".jpg"
+	if parsed, err := url.Parse(uri); err == nil && filepath.Ext(parsed.Path) != "" {
+		ext = filepath.Ext(parsed.Path)
+	}
+	sum := sha256.Sum256([]byte(uri))
+	return filepath.Join(s.imageDir, hex.EncodeToString(sum[:16])+ext)
+}
@@ -0,0 +1,242 @@
+package scryball
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestImageURIForSize(t *testing.T) {
+	normal := "https://cards.scryfall.io/normal/front/6/d/6da045f8-6278-4c84-9d39-025adf0789c1.jpg?1562404626"
+	tests := []struct {
+		size ImageSize
+		want string
+	}{
+		{ImageNormal, normal},
+		{ImageLarge, "https://cards.scryfall.io/large/front/6/d/6da045f8-6278-4c84-9d39-025adf0789c1.jpg?1562404626"},
+		{ImagePNG, "https://cards.scryfall.io/png/front/6/d/6da045f8-6278-4c84-9d39-025adf0789c1.png?1562404626"},
+		{ImageArtCrop, "https://cards.scryfall.io/art_crop/front/6/d/6da045f8-6278-4c84-9d39-025adf0789c1.jpg?1562404626"},
+	}
+	for _, tt := range tests {
+		got, err := imageURIForSize(normal, tt.size)
+		if err != nil || got != tt.want {
+			t.Errorf("imageURIForSize(%s) = %s, %v, want %s", tt.size, got, err, tt.want)
+		}
+	}
+
+	if _, err := imageURIForSize("https://cards.scryfall.io/", ImageLarge); err == nil {
+		t.Error("Expected an error for a URI without an image path")
+	}
+}
+
+func TestFetchImageCaches(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+
+	printing := Printing{ID: "bolt-1", ImageURI: server.URL + "/normal/front/b/o/bolt.jpg?123"}
+	for range 3 {
+		img, err := sb.FetchImage(t.Context(), printing, ImagePNG)
+		if err != nil {
+			t.Fatalf("FetchImage failed: %v", err)
+		}
+		if string(img) != "/png/front/b/o/bolt.png" {
+			t.Errorf("Expected the png image, got %q", img)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected 1 download, got %d", got)
+	}
+
+	if _, err := sb.FetchImage(t.Context(), printing, ImageLarge); err != nil || requests.Load() != 2 {
+		t.Errorf("Expected a different size to be downloaded separately, got %v after %d requests", err, requests.Load())
+	}
+
+	if _, err := sb.FetchImage(t.Context(), Printing{ID: "no-image"}, ImageLarge); err == nil {
+		t.Error("Expected an error for a printing without an image")
+	}
+}
+
+func TestSetIconCaches(t *testing.T) {
+	var setRequests, iconRequests atomic.Int32
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sets/neo":
+			setRequests.Add(1)
+			w.Write([]byte(`{"object": "set", "code": "neo", "name": "Kamigawa: Neon Dynasty", "icon_svg_uri": "https://svgs.scryfall.io/sets/neo.svg?1700"}`))
+		case "/sets/neo.svg":
+			iconRequests.Add(1)
+			w.Write([]byte("<svg></svg>"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	for _, code := range []string{"neo", "NEO", " Neo "} {
+		svg, err := sb.SetIcon(t.Context(), code)
+		if err != nil {
+			t.Fatalf("SetIcon(%q) failed: %v", code, err)
+		}
+		if string(svg) != "<svg></svg>" {
+			t.Errorf("SetIcon(%q) = %q", code, svg)
+		}
+	}
+	if setRequests.Load() != 1 || iconRequests.Load() != 1 {
+		t.Errorf("Expected 1 set lookup and 1 download, got %d and %d", setRequests.Load(), iconRequests.Load())
+	}
+
+	if _, err := sb.SetIcon(t.Context(), "xyz"); err == nil {
+		t.Error("Expected an error for an unknown set")
+	}
+	if _, err := sb.SetIcon(t.Context(), ""); err == nil {
+		t.Error("Expected an error for an empty set code")
+	}
+}
+
+func TestPrintingImageURIs(t *testing.T) {
+	const id = "6da045f8-6278-4c84-9d39-025adf0789c1"
+	listed := Printing{
+		ID:        id,
+		ImageURI:  "https://cards.scryfall.io/normal/front/6/d/" + id + ".jpg?1562404626",
+		ImageURIs: map[string]string{"art_crop": "https://cards.scryfall.io/art_crop/front/6/d/" + id + ".jpg?1562404626"},
+	}
+	if got := listed.ImageURIForSize(ImageArtCrop); got != listed.ImageURIs["art_crop"] {
+		t.Errorf("Expected the listed art_crop URI, got %s", got)
+	}
+	if got := listed.ImageURIForSize(ImageSmall); got != "https://cards.scryfall.io/small/front/6/d/"+id+".jpg?1562404626" {
+		t.Errorf("Expected a small URI derived from ImageURI, got %s", got)
+	}
+
+	dfc := Printing{ID: id, FaceImageURIs: []map[string]string{
+		{"png": "https://cards.scryfall.io/png/front/6/d/" + id + ".png?1562404626"},
+		{"large": "https://cards.scryfall.io/large/back/6/d/" + id + ".jpg?1562404626"},
+	}}
+	if got := dfc.ImageURIForSize(ImagePNG); got != dfc.FaceImageURIs[0]["png"] {
+		t.Errorf("Expected the listed png URI of the front face, got %s", got)
+	}
+
+	delver := &MagicCard{Card: testAPICard("delver-oracle", "delver-1", "Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect")}
+	delver.Layout = "transform"
+	back, err := delver.FaceImageURI(dfc, 1, ImageLarge)
+	if err != nil || back != dfc.FaceImageURIs[1]["large"] {
+		t.Errorf("Expected the listed back face URI, got %s, %v", back, err)
+	}
+	if _, err := delver.FaceImageURI(Printing{ID: id}, 1, ImageLarge); err == nil {
+		t.Error("Expected an error for a printing without face images")
+	}
+
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	bolt.Layout = "normal"
+	if _, err := bolt.FaceImageURI(listed, 1, ImageLarge); err == nil {
+		t.Error("Expected an error for the back of a single-faced card")
+	}
+	if front, err := bolt.FaceImageURI(listed, 0, ImageNormal); err != nil || front != listed.ImageURI {
+		t.Errorf("Expected the front face to be the printing's image, got %s, %v", front, err)
+	}
+}
+
+func TestImageStorageSurvivesRestart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("image bytes"))
+	}))
+	printing := Printing{ID: "bolt-1", ImageURI: server.URL + "/normal/front/b/o/bolt.jpg?123"}
+
+	dir := t.TempDir()
+	configs := map[string]ScryballConfig{
+		"database":  {DBPath: filepath.Join(dir, "cache.db")},
+		"image_dir": {DBPath: filepath.Join(dir, "small.db"), ImageDir: filepath.Join(dir, "images")},
+	}
+
+	for _, config := range configs {
+		sb, err := NewWithConfig(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := sb.FetchImage(t.Context(), printing, ImageLarge); err != nil {
+			t.Fatalf("FetchImage failed: %v", err)
+		}
+		sb.db.Close()
+	}
+	server.Close()
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			sb, err := NewWithConfig(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer sb.db.Close()
+
+			img, err := sb.FetchImage(t.Context(), printing, ImageLarge)
+			if err != nil || string(img) != "image bytes" {
+				t.Errorf("Expected the cached image offline, got %q, %v", img, err)
+			}
+		})
+	}
+
+	files, _ := os.ReadDir(filepath.Join(dir, "images"))
+	if len(files) != 1 || filepath.Ext(files[0].Name()) != ".jpg" {
+		t.Errorf("Expected one .jpg file in the image directory, got %v", files)
+	}
+}
+
+func TestCardBackAndFaceImagesFromDB(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	const backID = "0aeebaf5-8c7d-4636-9e82-8c27447861f7"
+	bolt := testAPICard("bolt-oracle", "e3285e6b-3e79-4d7c-bf96-d920f973b80d", "Lightning Bolt", "Instant")
+	bolt.CardBackID = backID
+	insertTestCard(t, sb, bolt)
+
+	delver := testAPICard("delver-oracle", "11bf83bb-c95b-4b4f-9a56-ce7a1816307a", "Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect")
+	delver.Layout = "transform"
+	for _, side := range []string{"front", "back"} {
+		delver.CardFaces = append(delver.CardFaces, client.CardFace{ImageURIs: map[string]string{
+			"normal":   "https://cards.scryfall.io/normal/" + side + "/1/1/11bf83bb-c95b-4b4f-9a56-ce7a1816307a.jpg?1690004867",
+			"art_crop": "https://cards.scryfall.io/art_crop/" + side + "/1/1/11bf83bb-c95b-4b4f-9a56-ce7a1816307a.jpg?1690004867",
+		}})
+	}
+	insertTestCard(t, sb, delver)
+
+	card, err := sb.FetchCardByExactName(t.Context(), "Lightning Bolt")
+	if err != nil {
+		t.Fatalf("FetchCardByExactName failed: %v", err)
+	}
+	printing := card.Printings[0]
+	if printing.CardBackID != backID {
+		t.Errorf("Expected card back %s, got %q", backID, printing.CardBackID)
+	}
+	if got := printing.CardBackImageURI(ImageLarge); got != "https://backs.scryfall.io/large/0/a/"+backID+".jpg" {
+		t.Errorf("Unexpected card back URI %s", got)
+	}
+	if printing.FaceImageURIs != nil {
+		t.Errorf("Expected no face images for a single-faced card, got %v", printing.FaceImageURIs)
+	}
+
+	card, err = sb.FetchCardByExactName(t.Context(), "Delver of Secrets // Insectile Aberration")
+	if err != nil {
+		t.Fatalf("FetchCardByExactName failed: %v", err)
+	}
+	faces := card.Printings[0].FaceImageURIs
+	if len(faces) != 2 || faces[1]["normal"] != "https://cards.scryfall.io/normal/back/1/1/11bf83bb-c95b-4b4f-9a56-ce7a1816307a.jpg?1690004867" {
+		t.Errorf("Expected the stored front and back face images, got %v", faces)
+	}
+	if front := card.Printings[0].ImageURI; front != faces[0]["normal"] {
+		t.Errorf("Expected the front face image as the printing's image, got %s", front)
+	}
+	if back, err := card.FaceImageURI(card.Printings[0], 1, ImageArtCrop); err != nil || back != faces[1]["art_crop"] {
+		t.Errorf("Expected FaceImageURI to use the printing's face images, got %s, %v", back, err)
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"database/sql"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/ninesl/scryball/internal/scryfall"
@@ -22,31 +25,63 @@ const (
 	APIBaseURL       = "https://api.scryfall.com"
 	DefaultUserAgent = "MTGScryfallClient/1.0"
 	DefaultAccept    = "application/json;q=0.9,*/*;q=0.8"
+	DefaultTimeout   = 30 * time.Second
 )
 
+// ErrNotFound is wrapped by the errors of requests for cards Scryfall does not have.
+var ErrNotFound = errors.New("not found")
+
 var (
 	DefaultClientOptions = ClientOptions{
 		APIURL:    APIBaseURL,
 		UserAgent: DefaultUserAgent,
 		Accept:    DefaultAccept,
 		Client:    &http.Client{},
+		Timeout:   DefaultTimeout,
 	}
 )
 
+// Doer sends HTTP requests. *http.Client implements it; wrap one to mock
+// Scryfall in tests or to instrument requests.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 type Client struct {
 	baseURL   string
 	userAgent string
 	accept    string
-	client    *http.Client
+	headers   http.Header
+	timeout   time.Duration
+	client    Doer
 	db        *sql.DB
+
+	rateMu      sync.Mutex // Serializes waitForRateLimit
+	lastRequest time.Time  // When the last request was allowed to start
+}
+
+// requestInterval is the time between requests Scryfall asks for: 50-100ms, 10 requests per second.
+const requestInterval = 100 * time.Millisecond
+
+// waitForRateLimit blocks until requestInterval has passed since the previous
+// request, so concurrent callers share one rate limit budget.
+func (c *Client) waitForRateLimit() {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	if wait := requestInterval - time.Since(c.lastRequest); wait > 0 {
+		time.Sleep(wait)
+	}
+	c.lastRequest = time.Now()
 }
 
 type ClientOptions struct {
-	APIURL    string       // default is "https://api.scryfall.com"
-	UserAgent string       // API docs recomend "{AppName}/1.0"
-	Accept    string       // "application/json;q=0.9,*/*;q=0.8". could be used to take csv? TODO:
-	Client    *http.Client // any http client can be used
-	ProxyURL  string       // optional proxy URL (e.g., "http://proxy:8080")
+	APIURL    string        // default is "https://api.scryfall.com"
+	UserAgent string        // API docs recomend "{AppName}/1.0"
+	Accept    string        // "application/json;q=0.9,*/*;q=0.8". could be used to take csv? TODO:
+	Client    *http.Client  // any http client can be used
+	ProxyURL  string        // optional proxy URL (e.g., "http://proxy:8080")
+	Headers   http.Header   // optional extra headers sent with every API request, not with image or bulk downloads
+	Timeout   time.Duration // limit for each request, including reading the response. 0 for none
 }
 
 // Uses DefaultClientOptions
@@ -62,18 +97,11 @@ func NewClient(appName string) (*Client, error) {
 }
 
 func NewClientWithOptions(co ClientOptions) (*Client, error) {
-	// Initialize database
-	db, err := sql.Open("sqlite", "scryfall.db")
-	if err != nil {
-		return nil, err
-	}
-
 	// Configure HTTP client with proxy if provided
 	client := co.Client
 	if co.ProxyURL != "" {
 		proxyURL, err := url.Parse(co.ProxyURL)
 		if err != nil {
-			db.Close()
 			return nil, fmt.Errorf("invalid proxy URL '%s': %v", co.ProxyURL, err)
 		}
 
@@ -85,27 +113,71 @@ func NewClientWithOptions(co ClientOptions) (*Client, error) {
 		fmt.Printf("Using proxy: %s\n", co.ProxyURL)
 	}
 
+	return NewClientWithDoer(client, co)
+}
+
+// NewClientWithDoer creates a client that sends its requests through doer
+// instead of an *http.Client. co.Client and co.ProxyURL are ignored
+func NewClientWithDoer(doer Doer, co ClientOptions) (*Client, error) {
+	if doer == nil {
+		return nil, errors.New("doer must not be nil")
+	}
+
+	// Initialize database
+	db, err := sql.Open("sqlite", "scryfall.db")
+	if err != nil {
+		return nil, err
+	}
+
 	return &Client{
 		baseURL:   co.APIURL,
 		userAgent: co.UserAgent,
 		accept:    co.Accept,
-		client:    client,
+		headers:   co.Headers.Clone(),
+		timeout:   co.Timeout,
+		client:    doer,
 		db:        db,
 	}, nil
 }
 
+// setHeaders sets the extra headers and the User-Agent on a request to the API.
+// The User-Agent is set last so the extra headers can't override it.
+//
+// Requests to other hosts, like the image and bulk data CDNs, only get the
+// User-Agent: the extra headers may hold a key meant for the API or a proxy in front of it.
+func (c *Client) setHeaders(req *http.Request) {
+	for key, values := range c.headers {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+}
+
+// requestContext derives the context of one request from ctx, limited to the client's timeout.
+// The timeout starts after waiting for the rate limit, so queued requests don't eat into it.
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
 func (c *Client) makeRequest(endpoint string, result interface{}) error {
 	// Respect Scryfall's rate limit: 50-100ms delay between requests (10 requests per second)
-	time.Sleep(100 * time.Millisecond)
+	c.waitForRateLimit()
+
+	ctx, cancel := c.requestContext(context.Background())
+	defer cancel()
 
 	fullURL := c.baseURL + endpoint
 
-	req, err := http.NewRequest("GET", fullURL, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
 	if err != nil {
 		return err
 	}
 
-	req.Header.Set("User-Agent", c.userAgent)
+	c.setHeaders(req)
 	req.Header.Set("Accept", c.accept)
 
 	resp, err := c.client.Do(req)
@@ -114,6 +186,9 @@ func (c *Client) makeRequest(endpoint string, result interface{}) error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("API request failed with status %d: %w", resp.StatusCode, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
 	}
@@ -121,16 +196,50 @@ func (c *Client) makeRequest(endpoint string, result interface{}) error {
 	return json.NewDecoder(resp.Body).Decode(result)
 }
 
+// FetchImage downloads a card image from one of Scryfall's image URIs.
+//
+// Image requests are served by Scryfall's CDN rather than the API, but are
+// still spaced out like API requests to stay polite.
+func (c *Client) FetchImage(ctx context.Context, imageURI string) ([]byte, error) {
+	c.waitForRateLimit()
+
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", imageURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image request failed with status %d", resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
 func (c *Client) GetCard(id string) (*Card, error) {
 	var card Card
 	err := c.makeRequest("/cards/"+url.PathEscape(id), &card)
 	return &card, err
 }
 
-func (c *Client) getSet(code string) (*Set, error) {
+// GetSet returns the set with a Scryfall set code
+// This function uses the /sets/:code endpoint
+// Returns an error wrapping ErrNotFound if Scryfall has no such set
+func (c *Client) GetSet(code string) (*Set, error) {
 	var set Set
-	err := c.makeRequest("/sets/"+url.PathEscape(code), &set)
-	return &set, err
+	if err := c.makeRequest("/sets/"+url.PathEscape(code), &set); err != nil {
+		return nil, fmt.Errorf("failed to find set %s: %w", code, err)
+	}
+	return &set, nil
 }
 
 func (c *Client) SearchCards(query string) (*List, error) {
@@ -162,10 +271,19 @@ func (c *Client) FetchAllPrintings(card *Card) ([]Card, error) {
 		return nil, fmt.Errorf("card has no prints_search_uri: %s", card.Name)
 	}
 
+	printsURI := card.PrintsSearchURI
+	// Scryfall leaves extras like tokens out of searches unless asked for them,
+	// including the prints search of a token
+	if isExtraLayout(card.Layout) {
+		query := printsURI.Query()
+		query.Set("include_extras", "true")
+		printsURI.RawQuery = query.Encode()
+	}
+
 	// Get first page of printings
 	var list List
 	// Use the full URL from PrintsSearchURI directly
-	err := c.makeRequest(card.PrintsSearchURI.RequestURI(), &list)
+	err := c.makeRequest(printsURI.RequestURI(), &list)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch printings for card '%s' from URI '%s': %w", card.Name, card.PrintsSearchURI.String(), err)
 	}
@@ -190,6 +308,20 @@ func (c *Client) FetchAllPrintings(card *Card) ([]Card, error) {
 
 // Helper functions
 
+// extraLayouts are the layouts of the cards Scryfall calls extras, left out of searches by default
+var extraLayouts = []string{"token", "double_faced_token", "emblem"}
+
+// isExtraLayout reports whether cards with layout are tokens or emblems,
+// which Scryfall only searches with include_extras
+func isExtraLayout(layout string) bool {
+	for _, extra := range extraLayouts {
+		if layout == extra {
+			return true
+		}
+	}
+	return false
+}
+
 // Helper function to convert int slice to comma-separated string
 func intsToString(ints []int) string {
 	if len(ints) == 0 {
@@ -1,8 +1,12 @@
 package client
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"strings"
 )
 
 // QueryForCards searches the Scryfall API using a query string and returns ALL matching cards
@@ -43,6 +47,18 @@ func (c *Client) QueryForCards(scryfallQuery string) ([]Card, error) {
 	return allCards, nil
 }
 
+// CountCards returns how many cards match the Scryfall query without fetching them
+// This function reads total_cards from the first page of /cards/search and does not follow pagination
+// Returns an error wrapping ErrNotFound if no cards match
+func (c *Client) CountCards(scryfallQuery string) (int, error) {
+	var list List
+	err := c.makeRequest("/cards/search?q="+url.QueryEscape(scryfallQuery), &list)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count cards with query '%s': %w", scryfallQuery, err)
+	}
+	return list.TotalCards, nil
+}
+
 // QueryForSpecificCard searches the Scryfall API for a specific card by exact name
 // This function uses the /cards/named endpoint to find cards by exact name match
 // Returns a single Card or an error if not found or request fails
@@ -77,3 +93,77 @@ func (c *Client) QueryForSpecificCardByOracleID(oracleID string) (*Card, error)
 	// Return the first card found (all should have the same oracle_id anyway)
 	return &list.Data[0], nil
 }
+
+// QueryForCardInLanguage fetches a single printing by set code and collector number in a language
+// This function uses the /cards/:code/:number/:lang endpoint, lang being a Scryfall language code like "ja"
+// Returns the localized Card or an error if the printing does not exist in that language
+func (c *Client) QueryForCardInLanguage(setCode, collectorNumber, lang string) (*Card, error) {
+	var card Card
+	endpoint := "/cards/" + url.PathEscape(strings.ToLower(setCode)) + "/" + url.PathEscape(collectorNumber) + "/" + url.PathEscape(lang)
+	err := c.makeRequest(endpoint, &card)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find %s #%s in language '%s': %w", setCode, collectorNumber, lang, err)
+	}
+	return &card, nil
+}
+
+// AutocompleteCardNames returns up to 20 full card names that could complete partial
+// This function uses the /cards/autocomplete endpoint, which also forgives some typos
+// Returns an empty array if nothing matches, or an error if the request fails
+func (c *Client) AutocompleteCardNames(partial string) ([]string, error) {
+	var catalog Catalog
+	err := c.makeRequest("/cards/autocomplete?q="+url.QueryEscape(partial), &catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to autocomplete '%s': %w", partial, err)
+	}
+	return catalog.Data, nil
+}
+
+// OpenBulkData starts downloading the bulk data file of bulkType, like "oracle_cards" or "default_cards"
+// This function looks up the file with the /bulk-data/:type endpoint, then streams it from Scryfall's CDN
+// The file is a JSON array of cards, hundreds of megabytes for most types. The caller must close it
+func (c *Client) OpenBulkData(ctx context.Context, bulkType string) (*BulkData, io.ReadCloser, error) {
+	var bulk BulkData
+	if err := c.makeRequest("/bulk-data/"+url.PathEscape(bulkType), &bulk); err != nil {
+		return nil, nil, fmt.Errorf("failed to find bulk data '%s': %w", bulkType, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", bulk.DownloadURI, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download bulk data '%s': %w", bulkType, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, nil, fmt.Errorf("bulk data download failed with status %d", resp.StatusCode)
+	}
+	return &bulk, resp.Body, nil
+}
+
+// ListSets returns every set Scryfall knows, including upcoming ones without cards yet
+// This function uses the /sets endpoint, which is not paginated
+func (c *Client) ListSets() ([]Set, error) {
+	var list struct {
+		Data []Set `json:"data"`
+	}
+	if err := c.makeRequest("/sets", &list); err != nil {
+		return nil, fmt.Errorf("failed to list sets: %w", err)
+	}
+	return list.Data, nil
+}
+
+// GetCardByArenaID returns the printing with an MTG Arena ID
+// This function uses the /cards/arena/:id endpoint
+// Returns an error wrapping ErrNotFound if no printing has the ID
+func (c *Client) GetCardByArenaID(arenaID int) (*Card, error) {
+	var card Card
+	if err := c.makeRequest(fmt.Sprintf("/cards/arena/%d", arenaID), &card); err != nil {
+		return nil, fmt.Errorf("failed to find Arena card %d: %w", arenaID, err)
+	}
+	return &card, nil
+}
@@ -66,6 +66,36 @@ const (
 	Minigame        SetType = "minigame"         // A set that contains minigame card inserts from booster packs
 )
 
+// Catalog objects contain an array of Magic datapoints, like card names.
+type Catalog struct {
+	// A content type for this object, always catalog.
+	Object string `json:"object"`
+
+	// The number of items in the data array.
+	TotalValues int `json:"total_values"`
+
+	// An array of datapoints, as strings.
+	Data []string `json:"data"`
+}
+
+// BulkData objects describe a file of every card Scryfall publishes daily.
+type BulkData struct {
+	// A content type for this object, always bulk_data.
+	Object string `json:"object"`
+
+	// A computer-readable string for the kind of bulk item, like oracle_cards or default_cards.
+	Type string `json:"type"`
+
+	// The time when this file was last updated.
+	UpdatedAt string `json:"updated_at"`
+
+	// The URI that hosts this bulk file for fetching.
+	DownloadURI string `json:"download_uri"`
+
+	// The size of this file in integer bytes.
+	Size int64 `json:"size"`
+}
+
 type Set struct {
 	//A content type for this object, always "set"
 	Object string `json:"object"`
@@ -763,3 +793,57 @@ func (p *CardPreview) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// MarshalJSON implements custom marshalling for Card, writing URL fields as
+// strings so the output can be read back by UnmarshalJSON
+func (c Card) MarshalJSON() ([]byte, error) {
+	type Alias Card
+	return json.Marshal(&struct {
+		PrintsSearchURI string `json:"prints_search_uri"`
+		RulingsURI      string `json:"rulings_uri"`
+		ScryfallURI     string `json:"scryfall_uri"`
+		URI             string `json:"uri"`
+		ScryfallSetURI  string `json:"scryfall_set_uri"`
+		SetSearchURI    string `json:"set_search_uri"`
+		SetURI          string `json:"set_uri"`
+		*Alias
+	}{
+		PrintsSearchURI: c.PrintsSearchURI.String(),
+		RulingsURI:      c.RulingsURI.String(),
+		ScryfallURI:     c.ScryfallURI.String(),
+		URI:             c.URI.String(),
+		ScryfallSetURI:  c.ScryfallSetURI.String(),
+		SetSearchURI:    c.SetSearchURI.String(),
+		SetURI:          c.SetURI.String(),
+		Alias:           (*Alias)(&c),
+	})
+}
+
+// MarshalJSON implements custom marshalling for RelatedCard, writing the URI as a string
+func (r RelatedCard) MarshalJSON() ([]byte, error) {
+	type Alias RelatedCard
+	return json.Marshal(&struct {
+		URI string `json:"uri"`
+		*Alias
+	}{
+		URI:   r.URI.String(),
+		Alias: (*Alias)(&r),
+	})
+}
+
+// MarshalJSON implements custom marshalling for CardPreview, writing the source URI as a string
+func (p CardPreview) MarshalJSON() ([]byte, error) {
+	type Alias CardPreview
+	var sourceURI *string
+	if p.SourceURI != nil {
+		s := p.SourceURI.String()
+		sourceURI = &s
+	}
+	return json.Marshal(&struct {
+		SourceURI *string `json:"source_uri"`
+		*Alias
+	}{
+		SourceURI: sourceURI,
+		Alias:     (*Alias)(&p),
+	})
+}
@@ -48,6 +48,39 @@ type Card struct {
 	TypeLine        string
 }
 
+type CardErratum struct {
+	ErrataID      int64
+	OracleID      string
+	Name          string
+	OldOracleText string
+	NewOracleText string
+	OldTypeLine   string
+	NewTypeLine   string
+	ChangedAt     string
+}
+
+type CardFoldedName struct {
+	FoldedName string
+	OracleID   string
+	IsFace     bool
+}
+
+type Deck struct {
+	Name     string
+	Comments string
+	SavedAt  string
+}
+
+type DeckCard struct {
+	DeckName        string
+	Zone            string
+	Position        int64
+	OracleID        string
+	Quantity        int64
+	SetCode         string
+	CollectorNumber string
+}
+
 type DigitalMechanicCard struct {
 	OracleID        string
 	AddedAt         string
@@ -59,6 +92,34 @@ type EternalArtisanException struct {
 	AddedAt  string
 }
 
+type ImageCache struct {
+	Uri      string
+	Data     []byte
+	CachedAt string
+}
+
+type KnownSet struct {
+	Code     string
+	Released int64
+}
+
+type PriceAlert struct {
+	AlertID         int64
+	OracleID        string
+	Currency        string
+	Threshold       float64
+	Direction       string
+	CreatedAt       string
+	LastTriggeredAt sql.NullString
+}
+
+type PriceHistory struct {
+	HistoryID  int64
+	PrintingID string
+	Prices     string
+	RecordedAt string
+}
+
 type Printing struct {
 	ID                string
 	OracleID          string
@@ -132,6 +193,16 @@ type QueryCache struct {
 	HitCount     int64
 }
 
+type PrintingFace struct {
+	PrintingID string
+	ImageUris  string
+}
+
+type SetIcon struct {
+	Code    string
+	IconUri string
+}
+
 type WatchlistCard struct {
 	OracleID string
 	AddedAt  string
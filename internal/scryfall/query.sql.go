@@ -77,6 +77,77 @@ func (q *Queries) CardExistsByOracleID(ctx context.Context, oracleID string) (in
 	return count, err
 }
 
+const clearPriceAlertTriggered = `-- name: ClearPriceAlertTriggered :exec
+UPDATE price_alerts
+SET last_triggered_at = NULL
+WHERE alert_id = ?
+`
+
+// Re-arm a price alert once no printing is past its threshold
+func (q *Queries) ClearPriceAlertTriggered(ctx context.Context, alertID int64) error {
+	_, err := q.db.ExecContext(ctx, clearPriceAlertTriggered, alertID)
+	return err
+}
+
+const deleteCachedQuery = `-- name: DeleteCachedQuery :execrows
+DELETE FROM query_cache WHERE query_text = ?
+`
+
+// Delete a cached query
+func (q *Queries) DeleteCachedQuery(ctx context.Context, queryText string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCachedQuery, queryText)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCardAlias = `-- name: DeleteCardAlias :execrows
+DELETE FROM card_aliases WHERE alias = ?
+`
+
+// Remove an alias
+func (q *Queries) DeleteCardAlias(ctx context.Context, alias string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCardAlias, alias)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteCardFoldedNames = `-- name: DeleteCardFoldedNames :exec
+DELETE FROM card_folded_names WHERE oracle_id = ?
+`
+
+// Remove the folded names of a card before storing its current ones
+func (q *Queries) DeleteCardFoldedNames(ctx context.Context, oracleID string) error {
+	_, err := q.db.ExecContext(ctx, deleteCardFoldedNames, oracleID)
+	return err
+}
+
+const deleteDeck = `-- name: DeleteDeck :execrows
+DELETE FROM decks WHERE name = ?
+`
+
+// Remove a saved deck
+func (q *Queries) DeleteDeck(ctx context.Context, name string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteDeck, name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteDeckCards = `-- name: DeleteDeckCards :exec
+DELETE FROM deck_cards WHERE deck_name = ?
+`
+
+// Remove every card of a saved deck
+func (q *Queries) DeleteDeckCards(ctx context.Context, deckName string) error {
+	_, err := q.db.ExecContext(ctx, deleteDeckCards, deckName)
+	return err
+}
+
 const deleteOldQueryCache = `-- name: DeleteOldQueryCache :exec
 DELETE FROM query_cache
 WHERE cached_at < ?
@@ -88,6 +159,16 @@ func (q *Queries) DeleteOldQueryCache(ctx context.Context, cachedAt string) erro
 	return err
 }
 
+const deletePriceAlert = `-- name: DeletePriceAlert :exec
+DELETE FROM price_alerts WHERE alert_id = ?
+`
+
+// Remove a price alert
+func (q *Queries) DeletePriceAlert(ctx context.Context, alertID int64) error {
+	_, err := q.db.ExecContext(ctx, deletePriceAlert, alertID)
+	return err
+}
+
 const getAllCategorizedCards = `-- name: GetAllCategorizedCards :many
 SELECT 
     c.oracle_id,
@@ -449,6 +530,48 @@ func (q *Queries) GetBestPrintingForImages(ctx context.Context, oracleID string)
 	return image_uris, err
 }
 
+const getCacheCounts = `-- name: GetCacheCounts :one
+SELECT
+    (SELECT COUNT(*) FROM cards) AS cards,
+    (SELECT COUNT(*) FROM printings) AS printings,
+    (SELECT COUNT(*) FROM query_cache) AS queries,
+    (SELECT COUNT(*) FROM image_cache) AS images
+`
+
+type GetCacheCountsRow struct {
+	Cards     int64
+	Printings int64
+	Queries   int64
+	Images    int64
+}
+
+// Count the rows of each cache table
+func (q *Queries) GetCacheCounts(ctx context.Context) (GetCacheCountsRow, error) {
+	row := q.db.QueryRowContext(ctx, getCacheCounts)
+	var i GetCacheCountsRow
+	err := row.Scan(
+		&i.Cards,
+		&i.Printings,
+		&i.Queries,
+		&i.Images,
+	)
+	return i, err
+}
+
+const getCachedImage = `-- name: GetCachedImage :one
+SELECT data
+FROM image_cache
+WHERE uri = ?
+`
+
+// Get a cached image by its URI
+func (q *Queries) GetCachedImage(ctx context.Context, uri string) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, getCachedImage, uri)
+	var data []byte
+	err := row.Scan(&data)
+	return data, err
+}
+
 const getCachedQuery = `-- name: GetCachedQuery :one
 
 SELECT query_id, query_text, oracle_ids, cached_at, last_accessed, hit_count
@@ -473,10 +596,68 @@ func (q *Queries) GetCachedQuery(ctx context.Context, queryText string) (QueryCa
 	return i, err
 }
 
+const getCardAlias = `-- name: GetCardAlias :one
+SELECT card_name
+FROM card_aliases
+WHERE alias = ?
+`
+
+// Get the card name of an alias, ignoring case
+func (q *Queries) GetCardAlias(ctx context.Context, alias string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getCardAlias, alias)
+	var card_name string
+	err := row.Scan(&card_name)
+	return card_name, err
+}
+
+const getCardByFaceName = `-- name: GetCardByFaceName :one
+SELECT oracle_id, name, layout, cmc, color_identity, colors, mana_cost, oracle_text, type_line, power, toughness
+FROM cards
+WHERE name LIKE ?1 || ' // %' ESCAPE '\'
+   OR name LIKE '% // ' || ?1 ESCAPE '\'
+   OR name LIKE '% // ' || ?1 || ' // %' ESCAPE '\'
+ORDER BY name NOT LIKE ?1 || ' // %' ESCAPE '\', name
+LIMIT 1
+`
+
+type GetCardByFaceNameRow struct {
+	OracleID      string
+	Name          string
+	Layout        string
+	Cmc           float64
+	ColorIdentity string
+	Colors        sql.NullString
+	ManaCost      sql.NullString
+	OracleText    sql.NullString
+	TypeLine      string
+	Power         sql.NullString
+	Toughness     sql.NullString
+}
+
+// Get a card by the name of one of its faces, front faces first
+func (q *Queries) GetCardByFaceName(ctx context.Context, face string) (GetCardByFaceNameRow, error) {
+	row := q.db.QueryRowContext(ctx, getCardByFaceName, face)
+	var i GetCardByFaceNameRow
+	err := row.Scan(
+		&i.OracleID,
+		&i.Name,
+		&i.Layout,
+		&i.Cmc,
+		&i.ColorIdentity,
+		&i.Colors,
+		&i.ManaCost,
+		&i.OracleText,
+		&i.TypeLine,
+		&i.Power,
+		&i.Toughness,
+	)
+	return i, err
+}
+
 const getCardByName = `-- name: GetCardByName :one
 SELECT oracle_id, name, layout, cmc, color_identity, colors, mana_cost, oracle_text, type_line, power, toughness
-FROM cards 
-WHERE LOWER(name) = LOWER(?) 
+FROM cards
+WHERE name = ? COLLATE NOCASE
 LIMIT 1
 `
 
@@ -495,8 +676,8 @@ type GetCardByNameRow struct {
 }
 
 // Get a card by exact name
-func (q *Queries) GetCardByName(ctx context.Context, lower string) (GetCardByNameRow, error) {
-	row := q.db.QueryRowContext(ctx, getCardByName, lower)
+func (q *Queries) GetCardByName(ctx context.Context, name string) (GetCardByNameRow, error) {
+	row := q.db.QueryRowContext(ctx, getCardByName, name)
 	var i GetCardByNameRow
 	err := row.Scan(
 		&i.OracleID,
@@ -555,6 +736,49 @@ func (q *Queries) GetCardByOracleID(ctx context.Context, oracleID string) (GetCa
 	return i, err
 }
 
+const getCardDetailsByOracleID = `-- name: GetCardDetailsByOracleID :one
+SELECT all_parts, card_faces, edhrec_rank, game_changer, keywords, legalities, penny_rank, reserved
+FROM cards
+WHERE oracle_id = ?
+LIMIT 1
+`
+
+type GetCardDetailsByOracleIDRow struct {
+	AllParts    sql.NullString
+	CardFaces   sql.NullString
+	EdhrecRank  sql.NullInt64
+	GameChanger sql.NullBool
+	Keywords    string
+	Legalities  string
+	PennyRank   sql.NullInt64
+	Reserved    bool
+}
+
+// Get the oracle-level card fields not covered by GetCardByOracleID
+func (q *Queries) GetCardDetailsByOracleID(ctx context.Context, oracleID string) (GetCardDetailsByOracleIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getCardDetailsByOracleID, oracleID)
+	var i GetCardDetailsByOracleIDRow
+	err := row.Scan(&i.AllParts, &i.CardFaces, &i.EdhrecRank, &i.GameChanger, &i.Keywords, &i.Legalities, &i.PennyRank, &i.Reserved)
+	return i, err
+}
+
+const getCardNameByFoldedName = `-- name: GetCardNameByFoldedName :one
+SELECT c.name
+FROM card_folded_names f
+JOIN cards c ON f.oracle_id = c.oracle_id
+WHERE f.folded_name = ?
+ORDER BY f.is_face, c.name
+LIMIT 1
+`
+
+// Get the name of the card a folded name belongs to, whole names before face names
+func (q *Queries) GetCardNameByFoldedName(ctx context.Context, foldedName string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getCardNameByFoldedName, foldedName)
+	var name string
+	err := row.Scan(&name)
+	return name, err
+}
+
 const getCardsWithPrintings = `-- name: GetCardsWithPrintings :many
 SELECT 
     c.oracle_id,
@@ -641,6 +865,63 @@ func (q *Queries) GetCardsWithPrintings(ctx context.Context) ([]GetCardsWithPrin
 	return items, nil
 }
 
+const getDeck = `-- name: GetDeck :one
+SELECT name, comments, saved_at FROM decks WHERE name = ?
+`
+
+// Get a saved deck
+func (q *Queries) GetDeck(ctx context.Context, name string) (Deck, error) {
+	row := q.db.QueryRowContext(ctx, getDeck, name)
+	var i Deck
+	err := row.Scan(&i.Name, &i.Comments, &i.SavedAt)
+	return i, err
+}
+
+const getDeckCards = `-- name: GetDeckCards :many
+SELECT zone, oracle_id, quantity, set_code, collector_number
+FROM deck_cards
+WHERE deck_name = ?
+ORDER BY zone, position
+`
+
+type GetDeckCardsRow struct {
+	Zone            string
+	OracleID        string
+	Quantity        int64
+	SetCode         string
+	CollectorNumber string
+}
+
+// Get the cards of a saved deck in the order they were saved
+func (q *Queries) GetDeckCards(ctx context.Context, deckName string) ([]GetDeckCardsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDeckCards, deckName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDeckCardsRow
+	for rows.Next() {
+		var i GetDeckCardsRow
+		if err := rows.Scan(
+			&i.Zone,
+			&i.OracleID,
+			&i.Quantity,
+			&i.SetCode,
+			&i.CollectorNumber,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getDigitalMechanicCards = `-- name: GetDigitalMechanicCards :many
 SELECT 
     c.oracle_id,
@@ -827,6 +1108,145 @@ func (q *Queries) GetEternalArtisanCards(ctx context.Context) ([]GetEternalArtis
 	return items, nil
 }
 
+const getOracleIDByArenaID = `-- name: GetOracleIDByArenaID :one
+SELECT oracle_id
+FROM printings
+WHERE arena_id = ?
+LIMIT 1
+`
+
+// Get the oracle_id of a printing by its MTG Arena id
+func (q *Queries) GetOracleIDByArenaID(ctx context.Context, arenaID sql.NullInt64) (string, error) {
+	row := q.db.QueryRowContext(ctx, getOracleIDByArenaID, arenaID)
+	var oracle_id string
+	err := row.Scan(&oracle_id)
+	return oracle_id, err
+}
+
+const getOracleIDByPrintingID = `-- name: GetOracleIDByPrintingID :one
+SELECT oracle_id
+FROM printings
+WHERE id = ?
+LIMIT 1
+`
+
+// Get the oracle_id of a printing by its Scryfall id
+func (q *Queries) GetOracleIDByPrintingID(ctx context.Context, id string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getOracleIDByPrintingID, id)
+	var oracle_id string
+	err := row.Scan(&oracle_id)
+	return oracle_id, err
+}
+
+const getPriceAlerts = `-- name: GetPriceAlerts :many
+SELECT 
+    pa.alert_id,
+    pa.oracle_id,
+    c.name,
+    pa.currency,
+    pa.threshold,
+    pa.direction,
+    pa.created_at,
+    pa.last_triggered_at
+FROM price_alerts pa
+JOIN cards c ON pa.oracle_id = c.oracle_id
+ORDER BY pa.alert_id
+`
+
+type GetPriceAlertsRow struct {
+	AlertID         int64
+	OracleID        string
+	Name            string
+	Currency        string
+	Threshold       float64
+	Direction       string
+	CreatedAt       string
+	LastTriggeredAt sql.NullString
+}
+
+// Get every registered price alert with its card name
+func (q *Queries) GetPriceAlerts(ctx context.Context) ([]GetPriceAlertsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPriceAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPriceAlertsRow
+	for rows.Next() {
+		var i GetPriceAlertsRow
+		if err := rows.Scan(
+			&i.AlertID,
+			&i.OracleID,
+			&i.Name,
+			&i.Currency,
+			&i.Threshold,
+			&i.Direction,
+			&i.CreatedAt,
+			&i.LastTriggeredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPrintingPricesByOracleID = `-- name: GetPrintingPricesByOracleID :many
+SELECT 
+    id,
+    "set" as set_code,
+    set_name,
+    collector_number,
+    prices
+FROM printings
+WHERE oracle_id = ?
+ORDER BY released_at DESC
+`
+
+type GetPrintingPricesByOracleIDRow struct {
+	ID              string
+	SetCode         string
+	SetName         string
+	CollectorNumber string
+	Prices          string
+}
+
+// Get the cached prices of every printing of a card
+func (q *Queries) GetPrintingPricesByOracleID(ctx context.Context, oracleID string) ([]GetPrintingPricesByOracleIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPrintingPricesByOracleID, oracleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPrintingPricesByOracleIDRow
+	for rows.Next() {
+		var i GetPrintingPricesByOracleIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SetCode,
+			&i.SetName,
+			&i.CollectorNumber,
+			&i.Prices,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPrintingsByOracleID = `-- name: GetPrintingsByOracleID :many
 SELECT 
     id,
@@ -839,7 +1259,19 @@ SELECT
     artist,
     collector_number,
     released_at,
-    scryfall_uri
+    scryfall_uri,
+    mtgo_id,
+    prices,
+    card_back_id,
+    booster,
+    arena_id,
+    set_type,
+    (
+        SELECT ph.prices FROM price_history ph
+        WHERE ph.printing_id = printings.id
+        ORDER BY ph.history_id DESC
+        LIMIT 1
+    ) AS previous_prices
 FROM printings
 WHERE oracle_id = ?
 ORDER BY released_at DESC
@@ -857,6 +1289,13 @@ type GetPrintingsByOracleIDRow struct {
 	CollectorNumber string
 	ReleasedAt      string
 	ScryfallUri     string
+	MtgoID          sql.NullInt64
+	Prices          string
+	CardBackID      string
+	Booster         bool
+	ArenaID         sql.NullInt64
+	SetType         string
+	PreviousPrices  sql.NullString
 }
 
 // Get printings by oracle_id
@@ -881,6 +1320,13 @@ func (q *Queries) GetPrintingsByOracleID(ctx context.Context, oracleID string) (
 			&i.CollectorNumber,
 			&i.ReleasedAt,
 			&i.ScryfallUri,
+			&i.MtgoID,
+			&i.Prices,
+			&i.CardBackID,
+			&i.Booster,
+			&i.ArenaID,
+			&i.SetType,
+			&i.PreviousPrices,
 		); err != nil {
 			return nil, err
 		}
@@ -917,6 +1363,18 @@ func (q *Queries) GetQueryCacheStats(ctx context.Context) (GetQueryCacheStatsRow
 	return i, err
 }
 
+const getSetIconURI = `-- name: GetSetIconURI :one
+SELECT icon_uri FROM set_icons WHERE code = ?
+`
+
+// Get the icon URI of a set
+func (q *Queries) GetSetIconURI(ctx context.Context, code string) (string, error) {
+	row := q.db.QueryRowContext(ctx, getSetIconURI, code)
+	var icon_uri string
+	err := row.Scan(&icon_uri)
+	return icon_uri, err
+}
+
 const getWatchlistCards = `-- name: GetWatchlistCards :many
 SELECT 
     c.oracle_id,
@@ -1010,6 +1468,78 @@ func (q *Queries) GetWatchlistCards(ctx context.Context) ([]GetWatchlistCardsRow
 	return items, nil
 }
 
+const insertCardFoldedName = `-- name: InsertCardFoldedName :exec
+INSERT OR IGNORE INTO card_folded_names (folded_name, oracle_id, is_face)
+VALUES (?, ?, ?)
+`
+
+type InsertCardFoldedNameParams struct {
+	FoldedName string
+	OracleID   string
+	IsFace     bool
+}
+
+// Store a folded name of a card
+func (q *Queries) InsertCardFoldedName(ctx context.Context, arg InsertCardFoldedNameParams) error {
+	_, err := q.db.ExecContext(ctx, insertCardFoldedName, arg.FoldedName, arg.OracleID, arg.IsFace)
+	return err
+}
+
+const insertDeckCard = `-- name: InsertDeckCard :exec
+INSERT INTO deck_cards (deck_name, zone, position, oracle_id, quantity, set_code, collector_number)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type InsertDeckCardParams struct {
+	DeckName        string
+	Zone            string
+	Position        int64
+	OracleID        string
+	Quantity        int64
+	SetCode         string
+	CollectorNumber string
+}
+
+// Add a card to a saved deck
+func (q *Queries) InsertDeckCard(ctx context.Context, arg InsertDeckCardParams) error {
+	_, err := q.db.ExecContext(ctx, insertDeckCard,
+		arg.DeckName,
+		arg.Zone,
+		arg.Position,
+		arg.OracleID,
+		arg.Quantity,
+		arg.SetCode,
+		arg.CollectorNumber,
+	)
+	return err
+}
+
+const insertPriceAlert = `-- name: InsertPriceAlert :one
+INSERT INTO price_alerts (oracle_id, currency, threshold, direction)
+VALUES (?, ?, ?, ?)
+RETURNING alert_id
+`
+
+type InsertPriceAlertParams struct {
+	OracleID  string
+	Currency  string
+	Threshold float64
+	Direction string
+}
+
+// Register a new price alert
+func (q *Queries) InsertPriceAlert(ctx context.Context, arg InsertPriceAlertParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertPriceAlert,
+		arg.OracleID,
+		arg.Currency,
+		arg.Threshold,
+		arg.Direction,
+	)
+	var alert_id int64
+	err := row.Scan(&alert_id)
+	return alert_id, err
+}
+
 const insertQueryCache = `-- name: InsertQueryCache :exec
 INSERT INTO query_cache (query_text, oracle_ids)
 VALUES (?, ?)
@@ -1026,11 +1556,383 @@ func (q *Queries) InsertQueryCache(ctx context.Context, arg InsertQueryCachePara
 	return err
 }
 
-const removeArenaOnlyEACard = `-- name: RemoveArenaOnlyEACard :exec
-DELETE FROM arena_only_ea_cards WHERE oracle_id = ?
+const listBoosterOracleIDsBySet = `-- name: ListBoosterOracleIDsBySet :many
+SELECT DISTINCT oracle_id
+FROM printings
+WHERE "set" = ? AND booster = 1
+ORDER BY oracle_id
 `
 
-// Remove oracle_id from arena only EA cards table
+// List the Oracle ID of every card with a cached booster printing in a set
+func (q *Queries) ListBoosterOracleIDsBySet(ctx context.Context, set string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listBoosterOracleIDsBySet, set)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var oracle_id string
+		if err := rows.Scan(&oracle_id); err != nil {
+			return nil, err
+		}
+		items = append(items, oracle_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCachedQueryTexts = `-- name: ListCachedQueryTexts :many
+SELECT query_text FROM query_cache ORDER BY query_text
+`
+
+// List the text of every cached query
+func (q *Queries) ListCachedQueryTexts(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listCachedQueryTexts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var query_text string
+		if err := rows.Scan(&query_text); err != nil {
+			return nil, err
+		}
+		items = append(items, query_text)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardAliases = `-- name: ListCardAliases :many
+SELECT alias, card_name
+FROM card_aliases
+ORDER BY alias
+`
+
+type ListCardAliasesRow struct {
+	Alias    string
+	CardName string
+}
+
+// Get every alias
+func (q *Queries) ListCardAliases(ctx context.Context) ([]ListCardAliasesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCardAliases)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCardAliasesRow
+	for rows.Next() {
+		var i ListCardAliasesRow
+		if err := rows.Scan(&i.Alias, &i.CardName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardErrata = `-- name: ListCardErrata :many
+SELECT errata_id, oracle_id, name, old_oracle_text, new_oracle_text, old_type_line, new_type_line, changed_at
+FROM card_errata
+WHERE changed_at >= ?
+ORDER BY changed_at DESC, errata_id DESC
+`
+
+// List the oracle text and type line changes recorded since a time, newest first
+func (q *Queries) ListCardErrata(ctx context.Context, changedAt string) ([]CardErratum, error) {
+	rows, err := q.db.QueryContext(ctx, listCardErrata, changedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CardErratum
+	for rows.Next() {
+		var i CardErratum
+		if err := rows.Scan(
+			&i.ErrataID,
+			&i.OracleID,
+			&i.Name,
+			&i.OldOracleText,
+			&i.NewOracleText,
+			&i.OldTypeLine,
+			&i.NewTypeLine,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardNames = `-- name: ListCardNames :many
+SELECT name
+FROM cards
+ORDER BY name
+`
+
+// Get the name of every cached card
+func (q *Queries) ListCardNames(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listCardNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardNamesByFoldedRange = `-- name: ListCardNamesByFoldedRange :many
+SELECT DISTINCT c.name
+FROM card_folded_names f
+JOIN cards c ON f.oracle_id = c.oracle_id
+WHERE f.folded_name >= ? AND f.folded_name < ?
+ORDER BY c.name
+LIMIT ?
+`
+
+type ListCardNamesByFoldedRangeParams struct {
+	FromFoldedName string
+	ToFoldedName   string
+	Limit          int64
+}
+
+// Get the names of the cards with a folded name or face name in a range, for prefix matches
+func (q *Queries) ListCardNamesByFoldedRange(ctx context.Context, arg ListCardNamesByFoldedRangeParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listCardNamesByFoldedRange, arg.FromFoldedName, arg.ToFoldedName, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCardsWithoutFoldedNames = `-- name: ListCardsWithoutFoldedNames :many
+SELECT oracle_id, name
+FROM cards
+WHERE oracle_id NOT IN (SELECT oracle_id FROM card_folded_names)
+`
+
+type ListCardsWithoutFoldedNamesRow struct {
+	OracleID string
+	Name     string
+}
+
+// Get the cards stored without folded names, by an older version or before the table existed
+func (q *Queries) ListCardsWithoutFoldedNames(ctx context.Context) ([]ListCardsWithoutFoldedNamesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCardsWithoutFoldedNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCardsWithoutFoldedNamesRow
+	for rows.Next() {
+		var i ListCardsWithoutFoldedNamesRow
+		if err := rows.Scan(&i.OracleID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDecks = `-- name: ListDecks :many
+SELECT name, comments, saved_at FROM decks ORDER BY name
+`
+
+// List every saved deck
+func (q *Queries) ListDecks(ctx context.Context) ([]Deck, error) {
+	rows, err := q.db.QueryContext(ctx, listDecks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Deck
+	for rows.Next() {
+		var i Deck
+		if err := rows.Scan(&i.Name, &i.Comments, &i.SavedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listKnownSets = `-- name: ListKnownSets :many
+SELECT code, released FROM known_sets
+`
+
+// List every set seen by CheckNewSets
+func (q *Queries) ListKnownSets(ctx context.Context) ([]KnownSet, error) {
+	rows, err := q.db.QueryContext(ctx, listKnownSets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []KnownSet
+	for rows.Next() {
+		var i KnownSet
+		if err := rows.Scan(&i.Code, &i.Released); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOracleIDs = `-- name: ListOracleIDs :many
+SELECT oracle_id
+FROM cards
+ORDER BY name
+`
+
+// Get the Oracle ID of every cached card
+func (q *Queries) ListOracleIDs(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, listOracleIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var oracle_id string
+		if err := rows.Scan(&oracle_id); err != nil {
+			return nil, err
+		}
+		items = append(items, oracle_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPrintingFacesByOracleID = `-- name: ListPrintingFacesByOracleID :many
+SELECT pf.printing_id, pf.image_uris
+FROM printing_faces pf
+JOIN printings p ON p.id = pf.printing_id
+WHERE p.oracle_id = ?
+`
+
+type ListPrintingFacesByOracleIDRow struct {
+	PrintingID string
+	ImageUris  string
+}
+
+// List the face image URIs of every double-sided printing of a card
+func (q *Queries) ListPrintingFacesByOracleID(ctx context.Context, oracleID string) ([]ListPrintingFacesByOracleIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPrintingFacesByOracleID, oracleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPrintingFacesByOracleIDRow
+	for rows.Next() {
+		var i ListPrintingFacesByOracleIDRow
+		if err := rows.Scan(&i.PrintingID, &i.ImageUris); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markPriceAlertTriggered = `-- name: MarkPriceAlertTriggered :exec
+UPDATE price_alerts
+SET last_triggered_at = CURRENT_TIMESTAMP
+WHERE alert_id = ?
+`
+
+// Record that a price alert fired
+func (q *Queries) MarkPriceAlertTriggered(ctx context.Context, alertID int64) error {
+	_, err := q.db.ExecContext(ctx, markPriceAlertTriggered, alertID)
+	return err
+}
+
+const removeArenaOnlyEACard = `-- name: RemoveArenaOnlyEACard :exec
+DELETE FROM arena_only_ea_cards WHERE oracle_id = ?
+`
+
+// Remove oracle_id from arena only EA cards table
 func (q *Queries) RemoveArenaOnlyEACard(ctx context.Context, oracleID string) error {
 	_, err := q.db.ExecContext(ctx, removeArenaOnlyEACard, oracleID)
 	return err
@@ -1089,6 +1991,25 @@ func (q *Queries) UpdateQueryCacheHit(ctx context.Context, queryText string) err
 	return err
 }
 
+const upsertCachedImage = `-- name: UpsertCachedImage :exec
+INSERT INTO image_cache (uri, data)
+VALUES (?, ?)
+ON CONFLICT(uri) DO UPDATE SET
+    data = excluded.data,
+    cached_at = CURRENT_TIMESTAMP
+`
+
+type UpsertCachedImageParams struct {
+	Uri  string
+	Data []byte
+}
+
+// Store a downloaded image
+func (q *Queries) UpsertCachedImage(ctx context.Context, arg UpsertCachedImageParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCachedImage, arg.Uri, arg.Data)
+	return err
+}
+
 const upsertCard = `-- name: UpsertCard :exec
 INSERT INTO cards (
     oracle_id, name, layout, prints_search_uri, rulings_uri,
@@ -1192,6 +2113,60 @@ func (q *Queries) UpsertCard(ctx context.Context, arg UpsertCardParams) error {
 	return err
 }
 
+const upsertCardAlias = `-- name: UpsertCardAlias :exec
+INSERT INTO card_aliases (alias, card_name)
+VALUES (?, ?)
+ON CONFLICT(alias) DO UPDATE SET
+    card_name = excluded.card_name
+`
+
+type UpsertCardAliasParams struct {
+	Alias    string
+	CardName string
+}
+
+// Add an alias or point an existing one to another card
+func (q *Queries) UpsertCardAlias(ctx context.Context, arg UpsertCardAliasParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCardAlias, arg.Alias, arg.CardName)
+	return err
+}
+
+const upsertDeck = `-- name: UpsertDeck :exec
+INSERT INTO decks (name, comments)
+VALUES (?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    comments = excluded.comments,
+    saved_at = CURRENT_TIMESTAMP
+`
+
+type UpsertDeckParams struct {
+	Name     string
+	Comments string
+}
+
+// Create a saved deck, or update its comments and save time
+func (q *Queries) UpsertDeck(ctx context.Context, arg UpsertDeckParams) error {
+	_, err := q.db.ExecContext(ctx, upsertDeck, arg.Name, arg.Comments)
+	return err
+}
+
+const upsertKnownSet = `-- name: UpsertKnownSet :exec
+INSERT INTO known_sets (code, released)
+VALUES (?, ?)
+ON CONFLICT(code) DO UPDATE SET released = excluded.released
+`
+
+type UpsertKnownSetParams struct {
+	Code     string
+	Released int64
+}
+
+// Insert or update a known set
+func (q *Queries) UpsertKnownSet(ctx context.Context, arg UpsertKnownSetParams) error {
+	_, err := q.db.ExecContext(ctx, upsertKnownSet, arg.Code, arg.Released)
+	return err
+}
+
 const upsertPrinting = `-- name: UpsertPrinting :exec
 INSERT INTO printings (
     id, oracle_id, arena_id, lang, mtgo_id, mtgo_foil_id, multiverse_ids,
@@ -1403,3 +2378,37 @@ func (q *Queries) UpsertPrinting(ctx context.Context, arg UpsertPrintingParams)
 	)
 	return err
 }
+
+const upsertPrintingFaces = `-- name: UpsertPrintingFaces :exec
+INSERT INTO printing_faces (printing_id, image_uris)
+VALUES (?, ?)
+ON CONFLICT(printing_id) DO UPDATE SET image_uris = excluded.image_uris
+`
+
+type UpsertPrintingFacesParams struct {
+	PrintingID string
+	ImageUris  string
+}
+
+// Insert or update the image URIs of each face of a double-sided printing
+func (q *Queries) UpsertPrintingFaces(ctx context.Context, arg UpsertPrintingFacesParams) error {
+	_, err := q.db.ExecContext(ctx, upsertPrintingFaces, arg.PrintingID, arg.ImageUris)
+	return err
+}
+
+const upsertSetIcon = `-- name: UpsertSetIcon :exec
+INSERT INTO set_icons (code, icon_uri)
+VALUES (?, ?)
+ON CONFLICT(code) DO UPDATE SET icon_uri = excluded.icon_uri
+`
+
+type UpsertSetIconParams struct {
+	Code    string
+	IconUri string
+}
+
+// Insert or update the icon URI of a set
+func (q *Queries) UpsertSetIcon(ctx context.Context, arg UpsertSetIconParams) error {
+	_, err := q.db.ExecContext(ctx, upsertSetIcon, arg.Code, arg.IconUri)
+	return err
+}
@@ -0,0 +1,189 @@
+package scryball
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// MarshalJSON encodes the card as its Scryfall card object with an added "printings" array.
+//
+// The output is read back by UnmarshalJSON, so cards can be stored in files or
+// served over REST and restored without a database.
+func (card MagicCard) MarshalJSON() ([]byte, error) {
+	fields := map[string]json.RawMessage{}
+	if card.Card != nil {
+		data, err := json.Marshal(card.Card)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	printings, err := json.Marshal(card.Printings)
+	if err != nil {
+		return nil, err
+	}
+	fields["printings"] = printings
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes a card written by MarshalJSON.
+//
+// A plain Scryfall card object is accepted too, leaving Printings empty.
+func (card *MagicCard) UnmarshalJSON(data []byte) error {
+	var apiCard client.Card
+	if err := json.Unmarshal(data, &apiCard); err != nil {
+		return fmt.Errorf("invalid card JSON: %v", err)
+	}
+
+	var aux struct {
+		Printings []Printing `json:"printings"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return fmt.Errorf("invalid card printings JSON: %v", err)
+	}
+
+	card.Card = &apiCard
+	card.Printings = aux.Printings
+	return nil
+}
+
+// decklistJSON is the JSON form of a Decklist.
+type decklistJSON struct {
+	Name       string              `json:"name,omitempty"`
+	Comments   string              `json:"comments,omitempty"`
+	Maindeck   []decklistEntryJSON `json:"maindeck"`
+	Sideboard  []decklistEntryJSON `json:"sideboard"`
+	Maybeboard []decklistEntryJSON `json:"maybeboard,omitempty"`
+	Commanders []decklistEntryJSON `json:"commanders,omitempty"`
+	Companion  *decklistEntryJSON  `json:"companion,omitempty"`
+}
+
+// decklistEntryJSON is a card in a decklist zone with its quantity and requested printing.
+type decklistEntryJSON struct {
+	Quantity int                `json:"quantity"`
+	Card     *MagicCard         `json:"card"`
+	Printing *RequestedPrinting `json:"printing,omitempty"`
+}
+
+// entry returns the JSON form of card in d.
+func (d *Decklist) entry(qty int, card *MagicCard) decklistEntryJSON {
+	entry := decklistEntryJSON{Quantity: qty, Card: card}
+	if requested, ok := d.Printings[card]; ok {
+		entry.Printing = &requested
+	}
+	return entry
+}
+
+// MarshalJSON encodes the decklist as JSON.
+//
+// Format:
+//
+//	{
+//	  "name": "Burn",
+//	  "maindeck": [{"quantity": 4, "card": {...}, "printing": {"set_code": "STA", "collector_number": "42"}}],
+//	  "sideboard": [{"quantity": 3, "card": {...}}],
+//	  "maybeboard": [...],
+//	  "commanders": [...],
+//	  "companion": {...}
+//	}
+//
+// Cards are written in full (see MagicCard.MarshalJSON) and sorted by name
+// within each zone, so the same deck always produces the same JSON.
+func (d Decklist) MarshalJSON() ([]byte, error) {
+	out := decklistJSON{
+		Name:      d.Name,
+		Comments:  d.Comments,
+		Maindeck:  []decklistEntryJSON{},
+		Sideboard: []decklistEntryJSON{},
+	}
+	for _, card := range sortedCards(d.Maindeck) {
+		out.Maindeck = append(out.Maindeck, d.entry(d.Maindeck[card], card))
+	}
+	for _, card := range sortedCards(d.Sideboard) {
+		out.Sideboard = append(out.Sideboard, d.entry(d.Sideboard[card], card))
+	}
+	for _, card := range sortedCards(d.Maybeboard) {
+		out.Maybeboard = append(out.Maybeboard, d.entry(d.Maybeboard[card], card))
+	}
+	for _, card := range d.Commanders {
+		out.Commanders = append(out.Commanders, d.entry(1, card))
+	}
+	if d.Companion != nil {
+		companion := d.entry(1, d.Companion)
+		out.Companion = &companion
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a decklist written by MarshalJSON.
+//
+// Requested printings are matched against each card's Printings again, so
+// RequestedPrinting.Printing points into the decoded card.
+func (d *Decklist) UnmarshalJSON(data []byte) error {
+	var in decklistJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fmt.Errorf("invalid decklist JSON: %v", err)
+	}
+
+	decoded := Decklist{
+		Name:       in.Name,
+		Comments:   in.Comments,
+		Maindeck:   make(map[*MagicCard]int),
+		Sideboard:  make(map[*MagicCard]int),
+		Maybeboard: make(map[*MagicCard]int),
+	}
+
+	addZone := func(entries []decklistEntryJSON, list map[*MagicCard]int) error {
+		for _, entry := range entries {
+			if entry.Card == nil {
+				return fmt.Errorf("decklist entry without a card")
+			}
+			if entry.Quantity < 1 {
+				return fmt.Errorf("invalid quantity %d for %s", entry.Quantity, entry.Card.Name)
+			}
+			key := addCardToMap(entry.Card, entry.Quantity, list)
+			decoded.requestEntryPrinting(key, entry)
+		}
+		return nil
+	}
+	if err := addZone(in.Maindeck, decoded.Maindeck); err != nil {
+		return err
+	}
+	if err := addZone(in.Sideboard, decoded.Sideboard); err != nil {
+		return err
+	}
+	if err := addZone(in.Maybeboard, decoded.Maybeboard); err != nil {
+		return err
+	}
+
+	for _, entry := range in.Commanders {
+		if entry.Card == nil {
+			return fmt.Errorf("commander entry without a card")
+		}
+		decoded.Commanders = append(decoded.Commanders, entry.Card)
+		decoded.requestEntryPrinting(entry.Card, entry)
+	}
+	if in.Companion != nil {
+		if in.Companion.Card == nil {
+			return fmt.Errorf("companion entry without a card")
+		}
+		decoded.Companion = in.Companion.Card
+		decoded.requestEntryPrinting(decoded.Companion, *in.Companion)
+	}
+
+	*d = decoded
+	return nil
+}
+
+// requestEntryPrinting records the printing of a decoded entry, if it has one.
+func (d *Decklist) requestEntryPrinting(card *MagicCard, entry decklistEntryJSON) {
+	if entry.Printing != nil {
+		d.requestPrinting(card, entry.Printing.SetCode, entry.Printing.CollectorNumber)
+	}
+}
@@ -0,0 +1,108 @@
+package scryball
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestMagicCardJSONRoundTrip(t *testing.T) {
+	card := &MagicCard{
+		Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"),
+		Printings: []Printing{
+			{ID: "bolt-1", SetCode: "sta", CollectorNumber: "42", Games: []string{"paper", "arena"}},
+			{ID: "bolt-2", SetCode: "2xm", CollectorNumber: "117", MTGOID: 78252},
+		},
+	}
+	card.Legalities["modern"] = "legal"
+	uri, _ := url.Parse("https://scryfall.com/card/sta/42/lightning-bolt")
+	card.ScryfallURI = *uri
+
+	data, err := json.Marshal(card)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("Expected a JSON object: %v", err)
+	}
+	if fields["name"] != "Lightning Bolt" || fields["scryfall_uri"] != uri.String() {
+		t.Errorf("Expected Scryfall card fields with string URIs, got name %v, scryfall_uri %v", fields["name"], fields["scryfall_uri"])
+	}
+
+	var decoded MagicCard
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Name != card.Name || *decoded.OracleID != *card.OracleID {
+		t.Errorf("Expected %s (%s), got %s", card.Name, *card.OracleID, decoded.Name)
+	}
+	if decoded.ScryfallURI.String() != uri.String() {
+		t.Errorf("Expected scryfall_uri %s, got %s", uri, decoded.ScryfallURI.String())
+	}
+	if decoded.Legalities["modern"] != "legal" {
+		t.Errorf("Expected legalities to round trip, got %v", decoded.Legalities)
+	}
+	if !reflect.DeepEqual(decoded.Printings, card.Printings) {
+		t.Errorf("Expected printings %+v, got %+v", card.Printings, decoded.Printings)
+	}
+}
+
+func TestDecklistJSONRoundTrip(t *testing.T) {
+	bolt := &MagicCard{
+		Card:      testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"),
+		Printings: []Printing{{ID: "bolt-1", SetCode: "sta", CollectorNumber: "42"}},
+	}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+	lurrus := &MagicCard{Card: testAPICard("lurrus-oracle", "lurrus-1", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare")}
+
+	deck := &Decklist{
+		Name:      "Burn",
+		Comments:  "Fast and cheap",
+		Maindeck:  map[*MagicCard]int{bolt: 4, mountain: 20},
+		Sideboard: map[*MagicCard]int{pyro: 3},
+		Companion: lurrus,
+	}
+	deck.requestPrinting(bolt, "STA", "42")
+
+	data, err := json.Marshal(deck)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var decoded Decklist
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	if decoded.Name != "Burn" || decoded.Comments != "Fast and cheap" {
+		t.Errorf("Expected name and comments to round trip, got %q, %q", decoded.Name, decoded.Comments)
+	}
+	if decoded.String() != deck.String() {
+		t.Errorf("Expected the same decklist, got:\n%s\nwant:\n%s", decoded.String(), deck.String())
+	}
+	if decoded.Companion == nil || decoded.Companion.Name != lurrus.Name {
+		t.Errorf("Expected companion %s, got %v", lurrus.Name, decoded.Companion)
+	}
+
+	for card, requested := range decoded.Printings {
+		if card.Name != "Lightning Bolt" || requested.Printing == nil || requested.Printing.ID != "bolt-1" {
+			t.Errorf("Expected Lightning Bolt's STA printing to be relinked, got %s %+v", card.Name, requested)
+		}
+	}
+
+	again, err := json.Marshal(&decoded)
+	if err != nil {
+		t.Fatalf("Marshal of decoded deck failed: %v", err)
+	}
+	if string(again) != string(data) {
+		t.Error("Expected encoding the decoded deck to produce the same JSON")
+	}
+
+	if err := json.Unmarshal([]byte(`{"maindeck":[{"quantity":0,"card":{"name":"Mountain"}}]}`), &decoded); err == nil {
+		t.Error("Expected an error for a zero quantity")
+	}
+}
@@ -0,0 +1,40 @@
+package scryball
+
+import "strings"
+
+// HasKeyword reports whether the card has a keyword ability or action, ignoring
+// case: "Flying", "flying" and "FLYING" all match. Uses the Keywords Scryfall
+// lists for the card, which include keywords on every face.
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Serra Angel")
+//	card.HasKeyword("flying")    // true
+//	card.HasKeyword("Vigilance") // true
+func (card *MagicCard) HasKeyword(keyword string) bool {
+	if card.Card == nil {
+		return false
+	}
+	for _, k := range card.Keywords {
+		if strings.EqualFold(k, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterByKeyword returns the cards that have keyword (see HasKeyword), in their original order.
+//
+// Example:
+//
+//	cards, _ := scryball.Query("t:creature c:w cmc<=2")
+//	fliers := scryball.FilterByKeyword(cards, "Flying")
+func FilterByKeyword(cards []*MagicCard, keyword string) []*MagicCard {
+	filtered := []*MagicCard{}
+	for _, card := range cards {
+		if card.HasKeyword(keyword) {
+			filtered = append(filtered, card)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,35 @@
+package scryball
+
+import "testing"
+
+func TestFilterByKeyword(t *testing.T) {
+	angel := testAPICard("angel", "angel-1", "Serra Angel", "Creature — Angel")
+	angel.Keywords = []string{"Flying", "Vigilance"}
+	bolt := testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant")
+	cards := []*MagicCard{{Card: angel}, {Card: bolt}}
+
+	if !cards[0].HasKeyword("flying") || !cards[0].HasKeyword("VIGILANCE") || cards[0].HasKeyword("Trample") {
+		t.Errorf("Expected case-insensitive keyword matches, keywords %v", angel.Keywords)
+	}
+
+	filtered := FilterByKeyword(cards, "Flying")
+	if len(filtered) != 1 || filtered[0].Name != "Serra Angel" {
+		t.Errorf("Expected only Serra Angel, got %v", filtered)
+	}
+	if filtered := FilterByKeyword(cards, "Haste"); len(filtered) != 0 {
+		t.Errorf("Expected no cards with haste, got %d", len(filtered))
+	}
+}
+
+func TestKeywordsLoadedFromDB(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	angel := testAPICard("angel", "angel-1", "Serra Angel", "Creature — Angel")
+	angel.Keywords = []string{"Flying", "Vigilance"}
+	card := insertTestCard(t, sb, angel)
+
+	if !card.HasKeyword("Flying") || len(card.Keywords) != 2 {
+		t.Errorf("Expected keywords to be loaded from the cache, got %v", card.Keywords)
+	}
+}
@@ -0,0 +1,171 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// LocalizedPrinting is a printing of a card in a language other than English,
+// with the text printed on it.
+type LocalizedPrinting struct {
+	Printing // The printing in Lang: its set, collector number, images and prices
+
+	Lang            string          // Scryfall language code: "ja", "de", "fr", ...
+	PrintedName     string          // Name printed on the card, "" for multi-faced printings, see Faces
+	PrintedTypeLine string          // Type line printed on the card, "" for multi-faced printings
+	PrintedText     string          // Rules text printed on the card, "" for multi-faced printings
+	Faces           []LocalizedFace // Text of each face of multi-faced printings, front first
+}
+
+// LocalizedFace is the text printed on one face of a LocalizedPrinting.
+type LocalizedFace struct {
+	PrintedName     string
+	PrintedTypeLine string
+	PrintedText     string
+}
+
+// PrintingInLanguage fetches a printing of the card in lang, a Scryfall
+// language code like "ja" or "de", for displaying cards in other languages.
+//
+// Behavior:
+//   - Always queries the API, localized printings are not cached.
+//     Their images are cached by FetchImage like any other
+//   - Finds any printing in lang, use Scryball.FetchPrintingInLanguage
+//     for a specific set and collector number
+//
+// Returns:
+//   - *LocalizedPrinting: The printing with its localized name, type line and text
+//   - error: No printing in lang, network issues
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+//
+// Example:
+//
+//	ja, err := card.PrintingInLanguage("ja")
+//	fmt.Println(ja.PrintedName) // 稲妻
+func (card *MagicCard) PrintingInLanguage(lang string) (*LocalizedPrinting, error) {
+	return card.PrintingInLanguageWithContext(context.Background(), lang)
+}
+
+// PrintingInLanguageWithContext fetches a printing of the card in lang with context support.
+// See PrintingInLanguage.
+func (card *MagicCard) PrintingInLanguageWithContext(ctx context.Context, lang string) (*LocalizedPrinting, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.PrintingInLanguage(ctx, card, lang)
+}
+
+// PrintingInLanguage fetches a printing of card in lang using this instance's
+// client. See MagicCard.PrintingInLanguage.
+func (s *Scryball) PrintingInLanguage(ctx context.Context, card *MagicCard, lang string) (*LocalizedPrinting, error) {
+	if card.Card == nil || card.OracleID == nil {
+		return nil, fmt.Errorf("card has no oracle_id")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	cards, err := s.client.QueryForCards(fmt.Sprintf("oracleid:%s lang:%s", *card.OracleID, lang))
+	if err != nil || len(cards) == 0 {
+		return nil, fmt.Errorf("no printing of %s in language %s", card.Name, lang)
+	}
+	return localizedPrinting(&cards[0]), nil
+}
+
+// FetchPrintingInLanguage fetches printing in lang, a Scryfall language code like "ja".
+//
+// Behavior:
+//   - Always queries the API, localized printings are not cached
+//   - Looks the printing up by its set code and collector number, which every
+//     language of a printing shares
+//
+// Returns:
+//   - *LocalizedPrinting: The printing with its localized name, type line and text
+//   - error: The printing does not exist in lang, network issues
+//
+// Example:
+//
+//	de, err := sb.FetchPrintingInLanguage(ctx, card.Printings[0], "de")
+func (s *Scryball) FetchPrintingInLanguage(ctx context.Context, printing Printing, lang string) (*LocalizedPrinting, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	apiCard, err := s.client.QueryForCardInLanguage(printing.SetCode, printing.CollectorNumber, lang)
+	if err != nil {
+		return nil, err
+	}
+	return localizedPrinting(apiCard), nil
+}
+
+// localizedPrinting converts a card from the API, printed in any language.
+func localizedPrinting(apiCard *client.Card) *LocalizedPrinting {
+	localized := &LocalizedPrinting{
+		Printing:        printingFromAPI(apiCard),
+		Lang:            apiCard.Lang,
+		PrintedName:     derefString(apiCard.PrintedName),
+		PrintedTypeLine: derefString(apiCard.PrintedTypeLine),
+		PrintedText:     derefString(apiCard.PrintedText),
+	}
+	if len(apiCard.CardFaces) > 1 {
+		for _, face := range apiCard.CardFaces {
+			localized.Faces = append(localized.Faces, LocalizedFace{
+				PrintedName:     derefString(face.PrintedName),
+				PrintedTypeLine: derefString(face.PrintedTypeLine),
+				PrintedText:     derefString(face.PrintedText),
+			})
+		}
+	}
+	return localized
+}
+
+// printingFromAPI converts the printing fields of a card from the API, like
+// getPrintingsFromDB does for cached printings.
+func printingFromAPI(apiCard *client.Card) Printing {
+	printing := Printing{
+		ID:              apiCard.ID,
+		SetCode:         apiCard.Set,
+		SetName:         apiCard.SetName,
+		CollectorNumber: apiCard.CollectorNumber,
+		Rarity:          Rarity(apiCard.Rarity),
+		ImageURIs:       apiCard.ImageURIs,
+		CardBackID:      apiCard.CardBackID,
+		ScryfallURI:     apiCard.ScryfallURI.String(),
+		Games:           apiCard.Games,
+		ReleasedAt:      apiCard.ReleasedAt,
+		SetType:         SetType(apiCard.SetType),
+	}
+	if apiCard.MTGOID != nil {
+		printing.MTGOID = *apiCard.MTGOID
+	}
+	for currency, price := range apiCard.Prices {
+		if price == nil {
+			continue
+		}
+		if printing.Prices == nil {
+			printing.Prices = make(map[string]string)
+		}
+		printing.Prices[currency] = *price
+	}
+
+	// Double-faced printings list their images per face, not per printing
+	printing.FaceImageURIs = apiFaceImageURIs(apiCard)
+	printing.ImageURI = preferredImageURI(printing.ImageURIs)
+	if printing.ImageURI == "" && len(printing.FaceImageURIs) > 0 {
+		printing.ImageURI = preferredImageURI(printing.FaceImageURIs[0])
+	}
+	return printing
+}
+
+// preferredImageURI picks the normal image URI, falling back to small or large.
+func preferredImageURI(uris map[string]string) string {
+	for _, size := range []ImageSize{ImageNormal, ImageSmall, ImageLarge} {
+		if uri, ok := uris[string(size)]; ok {
+			return uri
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package scryball
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// redirectTransport sends every request to a test server instead of Scryfall.
+type redirectTransport struct {
+	server *httptest.Server
+}
+
+func (rt redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	target, _ := url.Parse(rt.server.URL)
+	req.URL.Scheme, req.URL.Host = target.Scheme, target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// testAPIServer returns a Scryball whose API requests are answered by handler.
+func testAPIServer(t *testing.T, handler http.HandlerFunc) *Scryball {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	sb, err := NewWithConfig(ScryballConfig{
+		DBPath: filepath.Join(t.TempDir(), "test.db"),
+		Client: &http.Client{Transport: redirectTransport{server}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test Scryball: %v", err)
+	}
+	t.Cleanup(func() { sb.db.Close() })
+	return sb
+}
+
+const testJapaneseBolt = `{
+	"object": "card", "id": "ja-bolt", "oracle_id": "bolt", "lang": "ja", "name": "Lightning Bolt",
+	"printed_name": "稲妻", "printed_type_line": "インスタント", "printed_text": "稲妻は1つを対象とする。",
+	"set": "m10", "set_name": "Magic 2010", "collector_number": "146", "rarity": "common",
+	"image_uris": {"small": "https://cards.scryfall.io/small/front/j/a/ja-bolt.jpg", "normal": "https://cards.scryfall.io/normal/front/j/a/ja-bolt.jpg"},
+	"prices": {"usd": null, "eur": "1.50"}
+}`
+
+func TestFetchPrintingInLanguage(t *testing.T) {
+	var requested string
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.RequestURI()
+		if r.URL.Path != "/cards/m10/146/ja" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testJapaneseBolt))
+	})
+
+	ja, err := sb.FetchPrintingInLanguage(t.Context(), Printing{SetCode: "M10", CollectorNumber: "146"}, "ja")
+	if err != nil {
+		t.Fatalf("FetchPrintingInLanguage failed: %v", err)
+	}
+	if requested != "/cards/m10/146/ja" {
+		t.Errorf("Expected a request for /cards/m10/146/ja, got %s", requested)
+	}
+	if ja.Lang != "ja" || ja.PrintedName != "稲妻" || ja.PrintedTypeLine != "インスタント" || ja.PrintedText == "" {
+		t.Errorf("Expected the Japanese text, got %+v", ja)
+	}
+	if ja.SetCode != "m10" || ja.ImageURI != "https://cards.scryfall.io/normal/front/j/a/ja-bolt.jpg" {
+		t.Errorf("Expected the Japanese printing's set and image, got %s %s", ja.SetCode, ja.ImageURI)
+	}
+	if len(ja.Prices) != 1 || ja.Prices["eur"] != "1.50" {
+		t.Errorf("Expected only the eur price, got %v", ja.Prices)
+	}
+
+	if _, err := sb.FetchPrintingInLanguage(t.Context(), Printing{SetCode: "lea", CollectorNumber: "161"}, "ja"); err == nil {
+		t.Error("Expected an error for a printing that does not exist in Japanese")
+	}
+}
+
+func TestPrintingInLanguage(t *testing.T) {
+	var query string
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		w.Write([]byte(`{"object": "list", "has_more": false, "data": [` + testJapaneseBolt + `]}`))
+	})
+
+	oracleID := "bolt"
+	card := &MagicCard{Card: &client.Card{Name: "Lightning Bolt", OracleID: &oracleID}}
+	ja, err := sb.PrintingInLanguage(t.Context(), card, "ja")
+	if err != nil {
+		t.Fatalf("PrintingInLanguage failed: %v", err)
+	}
+	if query != "oracleid:bolt lang:ja" {
+		t.Errorf("Expected a search by oracle ID and language, got %q", query)
+	}
+	if ja.PrintedName != "稲妻" {
+		t.Errorf("Expected the Japanese name, got %q", ja.PrintedName)
+	}
+
+	if _, err := sb.PrintingInLanguage(t.Context(), &MagicCard{Card: &client.Card{Name: "No ID"}}, "ja"); err == nil {
+		t.Error("Expected an error for a card without an oracle ID")
+	}
+}
@@ -0,0 +1,303 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryLocal searches the cached cards with Scryfall syntax, without any API calls.
+//
+// Behavior:
+//   - Searches every card in the database, including bulk imported ones,
+//     not only the cards returned by earlier queries
+//   - Supports a subset of Scryfall syntax, see below. Unsupported keywords
+//     return an error rather than being ignored
+//   - Terms are combined with spaces (and), "or", "-" for negation and parentheses
+//   - Results are sorted by name
+//
+// Supported keywords:
+//   - Name words, and !"Exact Name"
+//   - t:, type: and o:, oracle: (~ is the card's name) substring matches
+//   - c:, color: and id:, identity: with letters or names, c:c colorless, c:m multicolor,
+//     and the =, !=, <, <=, >, >= operators. c: means >=, id: means <=
+//   - cmc:, mv:, pow:, power:, tou:, toughness: with any operator,
+//     compared to a number or another of them: pow>tou
+//   - r:, rarity: with any operator, r>=rare
+//   - s:, set:, e:, edition: with a set code
+//   - f:, format:, legal:, banned:, restricted: with a format
+//   - k:, keyword: with a keyword ability
+//   - is:reserved, is:gamechanger
+//
+// Regular expressions, like o:/^draw/, are not supported.
+//
+// Returns:
+//   - []*MagicCard: Matching cards (empty array if no matches)
+//   - error: Invalid or unsupported queries, or database errors
+//
+// Example:
+//
+//	cards, err := scryball.QueryLocal(`t:creature c>=rg cmc<=3 -o:"can't block"`)
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func QueryLocal(query string) ([]*MagicCard, error) {
+	return QueryLocalWithContext(context.Background(), query)
+}
+
+// QueryLocalWithContext searches the cached cards with Scryfall syntax with context support.
+// See QueryLocal.
+func QueryLocalWithContext(ctx context.Context, query string) ([]*MagicCard, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.QueryLocalWithContext(ctx, query)
+}
+
+// QueryLocal searches this instance's cached cards with Scryfall syntax. See QueryLocal.
+func (sb *Scryball) QueryLocal(query string) ([]*MagicCard, error) {
+	return sb.QueryLocalWithContext(context.Background(), query)
+}
+
+// QueryLocalWithContext searches this instance's cached cards with Scryfall
+// syntax with context support. See QueryLocal.
+func (sb *Scryball) QueryLocalWithContext(ctx context.Context, query string) ([]*MagicCard, error) {
+	node, err := parseQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %v", err)
+	}
+	where, args, err := node.sql()
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %v", err)
+	}
+
+	return sb.cardsFromQuery(ctx, "SELECT c.oracle_id FROM cards c WHERE "+where+" ORDER BY c.name", args...)
+}
+
+// cardsFromQuery runs an SQL query selecting oracle IDs and returns their cards, in the query's order.
+func (sb *Scryball) cardsFromQuery(ctx context.Context, query string, args ...any) ([]*MagicCard, error) {
+	rows, err := sb.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not search cache: %v", err)
+	}
+	defer rows.Close()
+
+	var oracleIDs []string
+	for rows.Next() {
+		var oracleID string
+		if err := rows.Scan(&oracleID); err != nil {
+			return nil, fmt.Errorf("could not search cache: %v", err)
+		}
+		oracleIDs = append(oracleIDs, oracleID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("could not search cache: %v", err)
+	}
+
+	return sb.FetchCardsByExactOracleIDs(ctx, oracleIDs)
+}
+
+// sql compiles the node to an SQL condition on the cards table, aliased c.
+func (n *queryNode) sql() (string, []any, error) {
+	switch n.kind {
+	case queryTermNode:
+		return n.term.sql()
+	case queryNotNode:
+		where, args, err := n.children[0].sql()
+		return "NOT (" + where + ")", args, err
+	}
+
+	joiner := " AND "
+	if n.kind == queryOrNode {
+		joiner = " OR "
+	}
+	var (
+		conditions []string
+		args       []any
+	)
+	for _, child := range n.children {
+		where, childArgs, err := child.sql()
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, "("+where+")")
+		args = append(args, childArgs...)
+	}
+	return strings.Join(conditions, joiner), args, nil
+}
+
+// numericFields are the card fields numeric keywords compare.
+var numericFields = map[string]string{
+	"cmc":       "c.cmc",
+	"mv":        "c.cmc",
+	"manavalue": "c.cmc",
+	"pow":       "CAST(c.power AS REAL)",
+	"power":     "CAST(c.power AS REAL)",
+	"tou":       "CAST(c.toughness AS REAL)",
+	"toughness": "CAST(c.toughness AS REAL)",
+}
+
+// sqlOps turns a query operator into SQL, ":" being equality.
+var sqlOps = map[string]string{":": "=", "=": "=", "!=": "!=", "<": "<", "<=": "<=", ">": ">", ">=": ">="}
+
+// rarityRankSQL is the Rarity.Rank of a printing's rarity, aliased p.
+const rarityRankSQL = `CASE p.rarity WHEN 'common' THEN 1 WHEN 'uncommon' THEN 2 WHEN 'rare' THEN 3
+	WHEN 'special' THEN 4 WHEN 'mythic' THEN 5 WHEN 'bonus' THEN 6 ELSE 0 END`
+
+// sql compiles a single term to an SQL condition on the cards table.
+func (t queryTerm) sql() (string, []any, error) {
+	if t.regex {
+		return "", nil, fmt.Errorf("regular expressions are not supported: %s", t)
+	}
+	switch t.key {
+	case "":
+		if t.exact {
+			return "c.name = ? COLLATE NOCASE", []any{t.value}, nil
+		}
+		return `c.name LIKE ? ESCAPE '\'`, []any{likeContains(t.value)}, nil
+	case "name":
+		return t.textSQL(`c.name LIKE ? ESCAPE '\'`, likeContains(t.value))
+	case "t", "type":
+		return t.textSQL(`c.type_line LIKE ? ESCAPE '\'`, likeContains(t.value))
+	case "o", "oracle":
+		pattern := `LIKE '%' || replace(?, '~', c.name) || '%' ESCAPE '\'`
+		return t.textSQL(`COALESCE(c.oracle_text, '') `+pattern+
+			` OR EXISTS (SELECT 1 FROM json_each(c.card_faces) f WHERE json_extract(f.value, '$.oracle_text') `+pattern+`)`,
+			escapeLike(t.value), escapeLike(t.value))
+	case "k", "keyword":
+		return t.textSQL(`c.keywords LIKE ? ESCAPE '\'`, `%"`+escapeLike(t.value)+`"%`)
+	case "c", "color":
+		return t.colorSQL(cardColorsSQL, ">=")
+	case "id", "identity", "ci":
+		return t.colorSQL("c.color_identity", "<=")
+	case "cmc", "mv", "manavalue", "pow", "power", "tou", "toughness":
+		return t.numericSQL()
+	case "r", "rarity":
+		rarity, err := ParseRarity(t.value)
+		if err != nil {
+			return "", nil, err
+		}
+		return "EXISTS (SELECT 1 FROM printings p WHERE p.oracle_id = c.oracle_id AND " + rarityRankSQL + " " + sqlOps[t.op] + " ?)",
+			[]any{rarity.Rank()}, nil
+	case "s", "set", "e", "edition":
+		return t.textSQL(`EXISTS (SELECT 1 FROM printings p WHERE p.oracle_id = c.oracle_id AND p."set" = ? COLLATE NOCASE)`, t.value)
+	case "f", "format", "legal", "banned", "restricted":
+		legalities := "'legal', 'restricted'"
+		switch t.key {
+		case "banned":
+			legalities = "'banned'"
+		case "restricted":
+			legalities = "'restricted'"
+		}
+		return t.textSQL("COALESCE(json_extract(c.legalities, ?), '') IN ("+legalities+")", "$."+strings.ToLower(t.value))
+	case "is":
+		switch strings.ToLower(t.value) {
+		case "reserved":
+			return t.textSQL("c.reserved = 1")
+		case "gamechanger", "gc":
+			return t.textSQL("COALESCE(c.game_changer, 0) = 1")
+		}
+		return "", nil, fmt.Errorf("unsupported search is:%s", t.value)
+	}
+	return "", nil, fmt.Errorf("unsupported search keyword %q", t.key)
+}
+
+// textSQL returns the condition of a keyword that only takes ":" and "=".
+func (t queryTerm) textSQL(where string, args ...any) (string, []any, error) {
+	if t.op != ":" && t.op != "=" {
+		return "", nil, fmt.Errorf("%s does not support %s", t.key, t.op)
+	}
+	return where, args, nil
+}
+
+// numericSQL compares a numeric field to a number or another numeric field: pow>tou.
+func (t queryTerm) numericSQL() (string, []any, error) {
+	field := numericFields[t.key]
+	notNull := strings.TrimSuffix(strings.TrimPrefix(field, "CAST("), " AS REAL)") + " IS NOT NULL"
+	if other, ok := numericFields[strings.ToLower(t.value)]; ok {
+		otherNotNull := strings.TrimSuffix(strings.TrimPrefix(other, "CAST("), " AS REAL)") + " IS NOT NULL"
+		return fmt.Sprintf("%s AND %s AND %s %s %s", notNull, otherNotNull, field, sqlOps[t.op], other), nil, nil
+	}
+	n, err := strconv.ParseFloat(t.value, 64)
+	if err != nil {
+		return "", nil, fmt.Errorf("%s needs a number, got %q", t.key, t.value)
+	}
+	return fmt.Sprintf("%s AND %s %s ?", notNull, field, sqlOps[t.op]), []any{n}, nil
+}
+
+// cardColorsSQL is the JSON array of a card's colors. Like cardColors it falls
+// back to the colors of the faces for double-faced cards, which have none of their
+// own: their colors are stored as NULL or a JSON null.
+const cardColorsSQL = `COALESCE(NULLIF(c.colors, 'null'), (SELECT json_group_array(DISTINCT color.value)
+	FROM json_each(c.card_faces) AS face, json_each(face.value, '$.colors') AS color))`
+
+// colorSQL compares a JSON array of colors to the term's colors. ":" means defaultOp.
+func (t queryTerm) colorSQL(column, defaultOp string) (string, []any, error) {
+	switch strings.ToLower(t.value) {
+	case "m", "multicolor":
+		return t.textSQL("json_array_length(" + column + ") >= 2")
+	case "c", "colorless":
+		return t.textSQL("json_array_length(" + column + ") = 0")
+	}
+
+	colors, err := parseColorValue(t.value)
+	if err != nil {
+		return "", nil, err
+	}
+	has := func(color Color) string {
+		return fmt.Sprintf(`instr(%s, '"%s"') > 0`, column, color)
+	}
+	var all, others []string
+	for _, color := range colorOrder[:5] {
+		if colors.Contains(color) {
+			all = append(all, has(color))
+		} else {
+			others = append(others, has(color))
+		}
+	}
+	hasAll := "1"
+	if len(all) > 0 {
+		hasAll = strings.Join(all, " AND ")
+	}
+	hasOther := "0"
+	if len(others) > 0 {
+		hasOther = strings.Join(others, " OR ")
+	}
+
+	op := t.op
+	if op == ":" {
+		op = defaultOp
+	}
+	switch op {
+	case ">=":
+		return hasAll, nil, nil
+	case ">":
+		return fmt.Sprintf("(%s) AND (%s)", hasAll, hasOther), nil, nil
+	case "<=":
+		return fmt.Sprintf("NOT (%s)", hasOther), nil, nil
+	case "<":
+		return fmt.Sprintf("NOT (%s) AND NOT (%s)", hasOther, hasAll), nil, nil
+	case "=":
+		return fmt.Sprintf("(%s) AND NOT (%s)", hasAll, hasOther), nil, nil
+	}
+	return fmt.Sprintf("NOT ((%s) AND NOT (%s))", hasAll, hasOther), nil, nil
+}
+
+// parseColorValue parses the colors of a c: or id: search: "rg", "red" or "{R}{G}".
+func parseColorValue(value string) (Colors, error) {
+	if color, ok := colorNames[strings.ToLower(value)]; ok {
+		return Colors{color}, nil
+	}
+	return ParseColors(value)
+}
+
+// escapeLike escapes the LIKE wildcards in s, for patterns with ESCAPE '\'.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// likeContains returns a LIKE pattern matching values that contain s.
+func likeContains(s string) string {
+	return "%" + escapeLike(s) + "%"
+}
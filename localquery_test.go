@@ -0,0 +1,124 @@
+package scryball
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// testLocalQueryDB returns a Scryball with a handful of varied cards cached.
+func testLocalQueryDB(t *testing.T) *Scryball {
+	t.Helper()
+	sb := testHelper(t)
+	t.Cleanup(func() { sb.db.Close() })
+
+	card := func(name, typeLine, manaCost string, cmc float64, colors []string, oracleText string) *client.Card {
+		c := testAPICard(strings.ToLower(strings.ReplaceAll(name, " ", "-")), name+"-1", name, typeLine)
+		c.ManaCost, c.CMC, c.Colors, c.ColorIdentity = &manaCost, cmc, colors, colors
+		c.OracleText = &oracleText
+		return c
+	}
+	stats := func(c *client.Card, power, toughness string) *client.Card {
+		c.Power, c.Toughness = &power, &toughness
+		return c
+	}
+
+	bolt := card("Lightning Bolt", "Instant", "{R}", 1, []string{"R"}, "Lightning Bolt deals 3 damage to any target.")
+	bolt.Legalities["modern"] = "legal"
+	bolt.Rarity = "common"
+	insertTestCard(t, sb, bolt)
+
+	goyf := stats(card("Tarmogoyf", "Creature — Lhurgoyf", "{1}{G}", 2, []string{"G"}, "Tarmogoyf's power is equal to the number of card types among cards in all graveyards."), "*", "1+*")
+	goyf.Rarity = "mythic"
+	goyf.Keywords = []string{}
+	insertTestCard(t, sb, goyf)
+
+	angel := stats(card("Serra Angel", "Creature — Angel", "{3}{W}{W}", 5, []string{"W"}, "Flying, vigilance"), "4", "4")
+	angel.Keywords = []string{"Flying", "Vigilance"}
+	angel.Rarity = "uncommon"
+	angel.Set = "lea"
+	insertTestCard(t, sb, angel)
+
+	bloodbraid := stats(card("Bloodbraid Elf", "Creature — Elf Berserker", "{2}{R}{G}", 4, []string{"G", "R"}, "Cascade, haste"), "3", "2")
+	bloodbraid.Keywords = []string{"Cascade", "Haste"}
+	bloodbraid.Rarity = "uncommon"
+	bloodbraid.Legalities["modern"] = "banned"
+	insertTestCard(t, sb, bloodbraid)
+
+	sol := card("Sol Ring", "Artifact", "{1}", 1, nil, "{T}: Add {C}{C}.")
+	sol.Rarity = "uncommon"
+	gameChanger := true
+	sol.GameChanger = &gameChanger
+	insertTestCard(t, sb, sol)
+
+	delver := card("Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect", "", 1, []string{"U"}, "")
+	delver.OracleText = nil
+	delver.Colors = nil // Scryfall only lists the colors of each face of transform cards
+	front, back := "At the beginning of your upkeep, look at the top card of your library.", "Flying"
+	delver.CardFaces = []client.CardFace{
+		{Name: "Delver of Secrets", ManaCost: "{U}", OracleText: &front, Colors: []string{"U"}},
+		{Name: "Insectile Aberration", OracleText: &back, Colors: []string{"U"}},
+	}
+	insertTestCard(t, sb, delver)
+
+	return sb
+}
+
+func TestLocalQuery(t *testing.T) {
+	sb := testLocalQueryDB(t)
+
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{"bolt", []string{"Lightning Bolt"}},
+		{`!"serra angel"`, []string{"Serra Angel"}},
+		{"t:creature c:g", []string{"Bloodbraid Elf", "Tarmogoyf"}},
+		{"c=g", []string{"Tarmogoyf"}},
+		{"id<=rg -t:creature", []string{"Lightning Bolt", "Sol Ring"}},
+		{"c:m", []string{"Bloodbraid Elf"}},
+		{"c:c", []string{"Sol Ring"}},
+		{"c:u", []string{"Delver of Secrets // Insectile Aberration"}},
+		{"c=u t:creature", []string{"Delver of Secrets // Insectile Aberration"}},
+		{"cmc>=4", []string{"Bloodbraid Elf", "Serra Angel"}},
+		{"pow>tou", []string{"Bloodbraid Elf"}},
+		{"pow>=4 or o:graveyards", []string{"Serra Angel", "Tarmogoyf"}},
+		{`o:"~ deals 3"`, []string{"Lightning Bolt"}},
+		{"o:upkeep", []string{"Delver of Secrets // Insectile Aberration"}},
+		{"r>=rare", []string{"Tarmogoyf"}},
+		{"r:u t:creature", []string{"Bloodbraid Elf", "Serra Angel"}},
+		{"s:LEA", []string{"Serra Angel"}},
+		{"f:modern", []string{"Lightning Bolt"}},
+		{"banned:modern", []string{"Bloodbraid Elf"}},
+		{"k:flying", []string{"Serra Angel"}},
+		{"is:gamechanger", []string{"Sol Ring"}},
+		{"-(t:creature or t:instant)", []string{"Sol Ring"}},
+		{"t:dragon", []string{}},
+	}
+	for _, tt := range tests {
+		cards, err := sb.QueryLocal(tt.query)
+		if err != nil {
+			t.Errorf("QueryLocal(%q) failed: %v", tt.query, err)
+			continue
+		}
+		names := []string{}
+		for _, card := range cards {
+			names = append(names, card.Name)
+		}
+		if !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("QueryLocal(%q) = %v, expected %v", tt.query, names, tt.expected)
+		}
+	}
+}
+
+func TestLocalQueryErrors(t *testing.T) {
+	sb := testLocalQueryDB(t)
+
+	for _, query := range []string{"", "usd>5", "t>creature", "cmc>=three", "(t:creature", `o:"draw`, "c:purple", "r:legendary", "is:funny", "o:/^draw/", "-t:/elf|goblin/"} {
+		if _, err := sb.QueryLocal(query); err == nil {
+			t.Errorf("Expected an error for %q", query)
+		}
+	}
+}
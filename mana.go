@@ -0,0 +1,96 @@
+package scryball
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ManaPips breaks a mana cost down by symbol, see MagicCard.ManaPips.
+type ManaPips struct {
+	// Color to number of symbols of that color, with ColorColorless for {C}.
+	// Hybrid symbols count towards each of their colors: {W/U} is one W and one U.
+	Colored map[Color]int
+
+	Generic   int  // Generic mana: {3}{R} is 3. The 2 of {2/W} is not counted
+	X         int  // Number of {X}, {Y} and {Z} symbols
+	Snow      int  // Number of {S} symbols
+	Hybrid    bool // Has a hybrid symbol: {W/U}, {2/W} or {G/U/P}
+	Phyrexian bool // Has a Phyrexian symbol: {W/P} or {G/U/P}
+}
+
+// parseManaPips counts the symbols of a mana cost like "{X}{2}{W/U}{R/P}".
+func parseManaPips(cost string) ManaPips {
+	pips := ManaPips{Colored: make(map[Color]int)}
+	for _, symbol := range manaSymbols(cost) {
+		parts := strings.Split(symbol, "/")
+		if i := slices.Index(parts, "P"); i != -1 {
+			pips.Phyrexian = true
+			parts = slices.Delete(parts, i, i+1)
+		}
+		if len(parts) > 1 {
+			pips.Hybrid = true
+		}
+
+		switch symbol {
+		case "X", "Y", "Z":
+			pips.X++
+			continue
+		case "S":
+			pips.Snow++
+			continue
+		}
+		if n, err := strconv.Atoi(symbol); err == nil {
+			pips.Generic += n
+			continue
+		}
+		for _, color := range symbolColors(symbol) {
+			pips.Colored[Color(color)]++
+		}
+	}
+	return pips
+}
+
+// ManaPips breaks the card's mana cost down into colored, generic, X and snow symbols.
+//
+// Behavior:
+//   - Uses the front face's cost for double-faced cards, both halves for split cards
+//   - Hybrid symbols count towards each of their colors
+//   - Phyrexian symbols count towards their color
+//   - Cards without a mana cost have no pips
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Kitchen Finks") // {1}{G/W}{G/W}
+//	pips := card.ManaPips()
+//	fmt.Println(pips.Generic, pips.Colored[scryball.ColorGreen], pips.Hybrid) // 1 2 true
+func (card *MagicCard) ManaPips() ManaPips {
+	if card.Card == nil {
+		return ManaPips{Colored: make(map[Color]int)}
+	}
+	return parseManaPips(cardManaCost(card))
+}
+
+// DevotionTo returns the card's devotion to colors: the number of symbols in
+// its mana cost that are any of colors. A hybrid symbol counts once, even when
+// both of its colors are given.
+//
+// Example:
+//
+//	card.DevotionTo(scryball.ColorBlack)                    // {B}{B}{R} is 2
+//	card.DevotionTo(scryball.ColorBlack, scryball.ColorRed) // {B}{B}{R} is 3
+func (card *MagicCard) DevotionTo(colors ...Color) int {
+	if card.Card == nil {
+		return 0
+	}
+	devotion := 0
+	for _, symbol := range manaSymbols(cardManaCost(card)) {
+		for _, color := range symbolColors(symbol) {
+			if slices.Contains(colors, Color(color)) && color != string(ColorColorless) {
+				devotion++
+				break
+			}
+		}
+	}
+	return devotion
+}
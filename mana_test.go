@@ -0,0 +1,46 @@
+package scryball
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestManaPips(t *testing.T) {
+	tests := []struct {
+		cost     string
+		expected ManaPips
+	}{
+		{"{X}{X}{R}", ManaPips{Colored: map[Color]int{ColorRed: 1}, X: 2}},
+		{"{3}{W}{W}", ManaPips{Colored: map[Color]int{ColorWhite: 2}, Generic: 3}},
+		{"{1}{G/W}{G/W}", ManaPips{Colored: map[Color]int{ColorGreen: 2, ColorWhite: 2}, Generic: 1, Hybrid: true}},
+		{"{2/B}{2/B}", ManaPips{Colored: map[Color]int{ColorBlack: 2}, Hybrid: true}},
+		{"{1}{U/P}", ManaPips{Colored: map[Color]int{ColorBlue: 1}, Generic: 1, Phyrexian: true}},
+		{"{G/U/P}", ManaPips{Colored: map[Color]int{ColorGreen: 1, ColorBlue: 1}, Hybrid: true, Phyrexian: true}},
+		{"{S}{C}", ManaPips{Colored: map[Color]int{ColorColorless: 1}, Snow: 1}},
+		{"", ManaPips{Colored: map[Color]int{}}},
+	}
+	for _, test := range tests {
+		card := testCard("card", "Card", "Instant", withManaCost(test.cost))
+		if got := card.ManaPips(); !reflect.DeepEqual(got, test.expected) {
+			t.Errorf("ManaPips of %q = %+v, expected %+v", test.cost, got, test.expected)
+		}
+	}
+}
+
+func TestDevotionTo(t *testing.T) {
+	card := testCard("card", "Card", "Creature", withManaCost("{2}{B}{B}{R}{B/G}"), withCMC(6))
+	for _, test := range []struct {
+		colors   []Color
+		expected int
+	}{
+		{[]Color{ColorBlack}, 3},
+		{[]Color{ColorRed}, 1},
+		{[]Color{ColorBlack, ColorRed}, 4},
+		{[]Color{ColorBlack, ColorGreen}, 3},
+		{[]Color{ColorWhite}, 0},
+	} {
+		if got := card.DevotionTo(test.colors...); got != test.expected {
+			t.Errorf("DevotionTo(%v) = %d, expected %d", test.colors, got, test.expected)
+		}
+	}
+}
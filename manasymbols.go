@@ -0,0 +1,102 @@
+package scryball
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// ManaStyle is a way RenderMana can display mana symbols.
+type ManaStyle int
+
+const (
+	ManaText  ManaStyle = iota // Unicode text: "{2}{U}{U}" is "②🔵🔵"
+	ManaHTML                   // <img> tags of Scryfall's symbol SVGs
+	ManaEmoji                  // Discord emoji codes: ":mana2::manau::manau:"
+)
+
+// scryfallSymbolHost serves an SVG for every mana and card symbol.
+const scryfallSymbolHost = "https://svgs.scryfall.io/card-symbols"
+
+// manaText is the Unicode text of each mana symbol part.
+var manaText = map[string]string{
+	"W": "⚪", "U": "🔵", "B": "⚫", "R": "🔴", "G": "🟢", "C": "◇",
+	"S": "❄", "X": "Ⓧ", "Y": "Ⓨ", "Z": "Ⓩ", "P": "Φ",
+	"T": "↷", "Q": "↶", "E": "⚡",
+}
+
+// circledNumbers are ⓪ to ⑳, the text of generic mana symbols.
+var circledNumbers = []rune("⓪①②③④⑤⑥⑦⑧⑨⑩⑪⑫⑬⑭⑮⑯⑰⑱⑲⑳")
+
+// RenderMana converts the mana symbols in a cost like "{2}{U}{U}" for display.
+//
+// Behavior:
+//   - ManaText: colored circles for colors, circled numbers for generic mana
+//     up to 20, hybrid parts joined by "/": "{W/U}" is "⚪/🔵"
+//   - ManaHTML: an <img class="mana-symbol"> per symbol with Scryfall's SVG as src
+//     and the symbol as alt text, other text is HTML escaped
+//   - ManaEmoji: ":mana" + the lowercase symbol without slashes + ":", "{W/U}" is
+//     ":manawu:", the emoji names most Magic Discord servers use
+//   - Text between symbols, like the " // " of split cards, is kept
+//
+// Example:
+//
+//	scryball.RenderMana("{1}{G/W}", scryball.ManaHTML)
+//	// <img class="mana-symbol" src="https://svgs.scryfall.io/card-symbols/1.svg" alt="{1}"><img ...>
+func RenderMana(cost string, style ManaStyle) string {
+	var b strings.Builder
+	for cost != "" {
+		start := strings.Index(cost, "{")
+		end := strings.Index(cost, "}")
+		if start == -1 || end < start {
+			b.WriteString(renderManaText(cost, style))
+			break
+		}
+		b.WriteString(renderManaText(cost[:start], style))
+		b.WriteString(renderManaSymbol(cost[start+1:end], style))
+		cost = cost[end+1:]
+	}
+	return b.String()
+}
+
+// RenderManaCost renders the card's mana cost with RenderMana, the front
+// face's cost for double-faced cards. "" if the card has no mana cost.
+func (card *MagicCard) RenderManaCost(style ManaStyle) string {
+	if card.Card == nil {
+		return ""
+	}
+	return RenderMana(cardManaCost(card), style)
+}
+
+// renderManaText renders the text between mana symbols.
+func renderManaText(text string, style ManaStyle) string {
+	if style == ManaHTML {
+		return html.EscapeString(text)
+	}
+	return text
+}
+
+// renderManaSymbol renders one symbol, given without its braces: "W/U".
+func renderManaSymbol(symbol string, style ManaStyle) string {
+	switch style {
+	case ManaHTML:
+		file := strings.ReplaceAll(symbol, "/", "")
+		return fmt.Sprintf(`<img class="mana-symbol" src="%s/%s.svg" alt="{%s}">`,
+			scryfallSymbolHost, html.EscapeString(file), html.EscapeString(symbol))
+	case ManaEmoji:
+		return ":mana" + strings.ToLower(strings.ReplaceAll(symbol, "/", "")) + ":"
+	default:
+		parts := strings.Split(symbol, "/")
+		for i, part := range parts {
+			if n, err := strconv.Atoi(part); err == nil && n >= 0 && n < len(circledNumbers) {
+				parts[i] = string(circledNumbers[n])
+			} else if text, ok := manaText[part]; ok {
+				parts[i] = text
+			} else {
+				parts[i] = "{" + part + "}"
+			}
+		}
+		return strings.Join(parts, "/")
+	}
+}
@@ -0,0 +1,31 @@
+package scryball
+
+import "testing"
+
+func TestRenderMana(t *testing.T) {
+	tests := []struct {
+		cost     string
+		style    ManaStyle
+		expected string
+	}{
+		{"{2}{U}{U}", ManaText, "②🔵🔵"},
+		{"{X}{W/U}{G/P}", ManaText, "Ⓧ⚪/🔵🟢/Φ"},
+		{"{1}{R} // {1}{U}", ManaText, "①🔴 // ①🔵"},
+		{"{25}", ManaText, "{25}"},
+		{"{2}{U}{U}", ManaEmoji, ":mana2::manau::manau:"},
+		{"{W/U}", ManaEmoji, ":manawu:"},
+		{"{2/W}", ManaHTML, `<img class="mana-symbol" src="https://svgs.scryfall.io/card-symbols/2W.svg" alt="{2/W}">`},
+		{"{R} & {G}", ManaHTML, `<img class="mana-symbol" src="https://svgs.scryfall.io/card-symbols/R.svg" alt="{R}"> &amp; <img class="mana-symbol" src="https://svgs.scryfall.io/card-symbols/G.svg" alt="{G}">`},
+		{"", ManaText, ""},
+	}
+	for _, test := range tests {
+		if got := RenderMana(test.cost, test.style); got != test.expected {
+			t.Errorf("RenderMana(%q, %d) = %q, expected %q", test.cost, test.style, got, test.expected)
+		}
+	}
+
+	card := testCard("bolt", "Lightning Bolt", "Instant", withManaCost("{R}"), withCMC(1), withColors("R"))
+	if got := card.RenderManaCost(ManaEmoji); got != ":manar:" {
+		t.Errorf("Expected :manar:, got %q", got)
+	}
+}
@@ -0,0 +1,119 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// normalizeCardName rewrites the ways decklists write the faces of split and
+// double-faced cards to Scryfall's "A // B": "Fire/Ice", "Fire / Ice" and
+// "Fire//Ice" all become "Fire // Ice".
+//
+// Curly quotes become straight ones, as Scryfall writes them.
+func normalizeCardName(name string) string {
+	name = quoteReplacer.Replace(name)
+	if !strings.Contains(name, "/") {
+		return strings.TrimSpace(name)
+	}
+	var faces []string
+	for _, face := range strings.Split(name, "/") {
+		if face = strings.TrimSpace(face); face != "" {
+			faces = append(faces, face)
+		}
+	}
+	return strings.Join(faces, " // ")
+}
+
+// quoteReplacer straightens the quotes word processors and some sites use.
+var quoteReplacer = strings.NewReplacer("‘", "'", "’", "'", "“", `"`, "”", `"`)
+
+// foldedLetters are the accented letters in card names and their plain letters.
+var foldedLetters = map[rune]string{
+	'à': "a", 'á': "a", 'â': "a", 'ä': "a", 'ã': "a", 'å': "a", 'æ': "ae",
+	'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e",
+	'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n",
+	'ò': "o", 'ó': "o", 'ô': "o", 'ö': "o", 'õ': "o", 'ø': "o", 'œ': "oe",
+	'ù': "u", 'ú': "u", 'û': "u", 'ü': "u",
+	'ý': "y", 'ÿ': "y",
+	'ß': "ss",
+}
+
+// foldCardName reduces a name to lowercase letters, digits and single spaces,
+// so "Lim-Dûl's Vault", "Lim-Dul's Vault" and "lim duls vault" all match.
+// Faces are folded separately: "Fire // Ice" is "fire // ice".
+func foldCardName(name string) string {
+	faces := strings.Split(normalizeCardName(name), " // ")
+	for i, face := range faces {
+		var b strings.Builder
+		for _, r := range strings.ToLower(face) {
+			switch {
+			case foldedLetters[r] != "":
+				b.WriteString(foldedLetters[r])
+			case unicode.IsLetter(r) || unicode.IsDigit(r):
+				b.WriteRune(r)
+			case r == '-' || unicode.IsSpace(r):
+				b.WriteRune(' ')
+			}
+		}
+		faces[i] = strings.Join(strings.Fields(b.String()), " ")
+	}
+	return strings.Join(faces, " // ")
+}
+
+// matchFoldedName finds the cached card name that name matches once both are
+// folded with foldCardName, or the card with a face it matches.
+// Returns sql.ErrNoRows if there is none.
+func (s *Scryball) matchFoldedName(ctx context.Context, name string) (string, error) {
+	folded := foldCardName(name)
+	if folded == "" {
+		return "", sql.ErrNoRows
+	}
+	return s.queries.GetCardNameByFoldedName(ctx, folded)
+}
+
+// upsertFoldedNames stores the name of a card and of each of its faces folded
+// with foldCardName, replacing the stored ones, for matchFoldedName.
+func upsertFoldedNames(ctx context.Context, queries *scryfall.Queries, oracleID, name string) error {
+	if err := queries.DeleteCardFoldedNames(ctx, oracleID); err != nil {
+		return err
+	}
+	folded := foldCardName(name)
+	names := []string{folded}
+	if faces := strings.Split(folded, " // "); len(faces) > 1 {
+		names = append(names, faces...)
+	}
+	for i, foldedName := range names {
+		if foldedName == "" {
+			continue
+		}
+		if err := queries.InsertCardFoldedName(ctx, scryfall.InsertCardFoldedNameParams{
+			FoldedName: foldedName,
+			OracleID:   oracleID,
+			IsFace:     i > 0,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fillFoldedNames stores the folded names of the cards that have none, because
+// they were cached by an older version or before the table existed.
+func fillFoldedNames(ctx context.Context, queries *scryfall.Queries) error {
+	cards, err := queries.ListCardsWithoutFoldedNames(ctx)
+	if err != nil {
+		return err
+	}
+	for _, card := range cards {
+		if err := upsertFoldedNames(ctx, queries, card.OracleID, card.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeCardName(t *testing.T) {
+	tests := map[string]string{
+		"Lightning Bolt":                 "Lightning Bolt",
+		" Lightning Bolt ":               "Lightning Bolt",
+		"Fire/Ice":                       "Fire // Ice",
+		"Fire / Ice":                     "Fire // Ice",
+		"Fire//Ice":                      "Fire // Ice",
+		"Fire // Ice":                    "Fire // Ice",
+		"Who/What/When/Where/Why":        "Who // What // When // Where // Why",
+		"Lightning Bolt //  great card ": "Lightning Bolt // great card",
+		"Lim-Dûl’s Vault":                "Lim-Dûl's Vault",
+	}
+	for name, expected := range tests {
+		if got := normalizeCardName(name); got != expected {
+			t.Errorf("normalizeCardName(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+func TestFetchCardByFaceName(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	insertTestCard(t, sb, testAPICard("fire-ice", "fire-ice-1", "Fire // Ice", "Instant // Instant"))
+	insertTestCard(t, sb, testAPICard("fable", "fable-1", "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki", "Enchantment — Saga // Enchantment Creature — Goblin Shaman"))
+	insertTestCard(t, sb, testAPICard("who", "who-1", "Who // What // When // Where // Why", "Instant // Instant // Instant // Instant // Instant"))
+
+	tests := map[string]string{
+		"Fire // Ice":                 "Fire // Ice",
+		"Fire/Ice":                    "Fire // Ice",
+		"fire//ice":                   "Fire // Ice",
+		"Fire":                        "Fire // Ice",
+		"Ice":                         "Fire // Ice",
+		"Fable of the Mirror-Breaker": "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki",
+		"reflection of kiki-jiki":     "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki",
+		"When":                        "Who // What // When // Where // Why",
+	}
+	for name, expected := range tests {
+		card, err := sb.FetchCardByExactName(ctx, name)
+		if err != nil {
+			t.Errorf("FetchCardByExactName(%q) error: %v", name, err)
+			continue
+		}
+		if card.Name != expected {
+			t.Errorf("FetchCardByExactName(%q) = %q, want %q", name, card.Name, expected)
+		}
+	}
+
+	for _, name := range []string{"Fir", "Fire // Water", "Mirror-Breaker", "%"} {
+		if _, err := sb.FetchCardByExactName(ctx, name); err != sql.ErrNoRows {
+			t.Errorf("FetchCardByExactName(%q) = %v, want sql.ErrNoRows", name, err)
+		}
+	}
+}
+
+func TestParseDecklistFaceNames(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	insertTestCard(t, sb, testAPICard("fire-ice", "fire-ice-1", "Fire // Ice", "Instant // Instant"))
+	insertTestCard(t, sb, testAPICard("fable", "fable-1", "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki", "Enchantment — Saga // Enchantment Creature — Goblin Shaman"))
+
+	deck, unresolved, err := sb.ParseDecklistOffline("2 Fire/Ice\n1 Fire // Ice\n4 Fable of the Mirror-Breaker\n")
+	if err != nil {
+		t.Fatalf("ParseDecklistOffline failed: %v", err)
+	}
+	if len(unresolved) != 0 {
+		t.Errorf("Expected every name to resolve, unresolved %v", unresolved)
+	}
+
+	copies := deck.totalCopies()
+	if copies["Fire // Ice"] != 3 {
+		t.Errorf("Expected 3 Fire // Ice, got %d", copies["Fire // Ice"])
+	}
+	if copies["Fable of the Mirror-Breaker // Reflection of Kiki-Jiki"] != 4 {
+		t.Errorf("Expected 4 Fable of the Mirror-Breaker, got %v", copies)
+	}
+}
+
+func TestFoldCardName(t *testing.T) {
+	tests := map[string]string{
+		"Lim-Dûl's Vault":          "lim duls vault",
+		"Lim-Dul’s Vault":          "lim duls vault",
+		"Juzám Djinn":              "juzam djinn",
+		"Lórien Revealed":          "lorien revealed",
+		"Æther Vial":               "aether vial",
+		"Fire/Ice":                 "fire // ice",
+		"Borrowing 100,000 Arrows": "borrowing 100000 arrows",
+		"  Sol   Ring ":            "sol ring",
+	}
+	for name, expected := range tests {
+		if got := foldCardName(name); got != expected {
+			t.Errorf("foldCardName(%q) = %q, want %q", name, got, expected)
+		}
+	}
+}
+
+func TestFetchCardByFoldedName(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	insertTestCard(t, sb, testAPICard("vault", "vault-1", "Lim-Dûl's Vault", "Instant"))
+	insertTestCard(t, sb, testAPICard("juzam", "juzam-1", "Juzám Djinn", "Creature — Djinn"))
+	insertTestCard(t, sb, testAPICard("lorien", "lorien-1", "Lórien Revealed", "Sorcery"))
+	insertTestCard(t, sb, testAPICard("fable", "fable-1", "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki", "Enchantment — Saga // Enchantment Creature — Goblin Shaman"))
+
+	tests := map[string]string{
+		"Lim-Dul's Vault":             "Lim-Dûl's Vault",
+		"Lim-Dûl’s Vault":             "Lim-Dûl's Vault",
+		"lim duls vault":              "Lim-Dûl's Vault",
+		"Juzam Djinn":                 "Juzám Djinn",
+		"LORIEN REVEALED":             "Lórien Revealed",
+		"Fable of the Mirror Breaker": "Fable of the Mirror-Breaker // Reflection of Kiki-Jiki",
+	}
+	for name, expected := range tests {
+		card, err := sb.FetchCardByExactName(ctx, name)
+		if err != nil {
+			t.Errorf("FetchCardByExactName(%q) error: %v", name, err)
+			continue
+		}
+		if card.Name != expected {
+			t.Errorf("FetchCardByExactName(%q) = %q, want %q", name, card.Name, expected)
+		}
+	}
+
+	if _, err := sb.FetchCardByExactName(ctx, "Juzam"); err != sql.ErrNoRows {
+		t.Errorf("FetchCardByExactName(Juzam) = %v, want sql.ErrNoRows", err)
+	}
+
+	deck, unresolved, err := sb.ParseDecklistOffline("1 Lim-Dul's Vault\n1 Juzam Djinn\n")
+	if err != nil {
+		t.Fatalf("ParseDecklistOffline failed: %v", err)
+	}
+	if len(unresolved) != 0 || deck.NumberOfCards() != 2 {
+		t.Errorf("Expected both cards to resolve, got %d cards and unresolved %v", deck.NumberOfCards(), unresolved)
+	}
+}
+
+func TestFoldedNamesFilledOnOpen(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	sb, err := NewWithConfig(ScryballConfig{DBPath: dbPath})
+	if err != nil {
+		t.Fatalf("Failed to create test Scryball: %v", err)
+	}
+	insertTestCard(t, sb, testAPICard("vault", "vault-1", "Lim-Dûl's Vault", "Instant"))
+
+	// a card cached by a version without folded names
+	if _, err := sb.db.Exec("DELETE FROM card_folded_names"); err != nil {
+		t.Fatalf("Failed to clear folded names: %v", err)
+	}
+	if _, err := sb.FetchCardByExactName(ctx, "Lim-Dul's Vault"); err != sql.ErrNoRows {
+		t.Fatalf("Expected no folded match without folded names, got %v", err)
+	}
+	sb.Close()
+
+	sb, err = NewWithConfig(ScryballConfig{DBPath: dbPath})
+	if err != nil {
+		t.Fatalf("Failed to reopen database: %v", err)
+	}
+	defer sb.Close()
+	if card, err := sb.FetchCardByExactName(ctx, "Lim-Dul's Vault"); err != nil || card.Name != "Lim-Dûl's Vault" {
+		t.Errorf("Expected the reopened database to fold cached names, got %v", err)
+	}
+}
+
+func TestFetchCardByExactNameIgnoresCase(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+
+	for _, name := range []string{"Lightning Bolt", "lightning bolt", "LIGHTNING BOLT", "lIgHtNiNg BoLt"} {
+		card, err := sb.FetchCardByExactName(ctx, name)
+		if err != nil {
+			t.Errorf("Expected %q to be found in the cache, got %v", name, err)
+			continue
+		}
+		if card.Name != "Lightning Bolt" {
+			t.Errorf("Expected %q to find Lightning Bolt, got %s", name, card.Name)
+		}
+	}
+
+	// The lookup is served by the NOCASE index instead of scanning every card
+	rows, err := sb.db.QueryContext(ctx, "EXPLAIN QUERY PLAN SELECT oracle_id FROM cards WHERE name = ? COLLATE NOCASE LIMIT 1", "lightning bolt")
+	if err != nil {
+		t.Fatalf("Failed to explain name lookup: %v", err)
+	}
+	defer rows.Close()
+	var plan string
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		if err := rows.Scan(&id, &parent, &unused, &detail); err != nil {
+			t.Fatalf("Failed to read query plan: %v", err)
+		}
+		plan += detail
+	}
+	if !strings.Contains(plan, "idx_cards_name_nocase") {
+		t.Errorf("Expected name lookups to use idx_cards_name_nocase, got plan %q", plan)
+	}
+}
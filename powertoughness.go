@@ -0,0 +1,57 @@
+package scryball
+
+import (
+	"strconv"
+	"strings"
+)
+
+// PowerValue returns the card's power as a number.
+//
+// Behavior:
+//   - "3" is 3, true and "-1" is -1, true
+//   - Variable parts count as 0 and return false: "*" is 0, "1+*" is 1,
+//     "X" is 0, "7-*" is 7
+//   - Values that are not numbers at all ("∞", "?") are 0, false
+//   - Double-faced cards use the front face, cards without power return 0, false
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Tarmogoyf") // */1+*
+//	power, fixed := card.PowerValue()         // 0, false
+func (card *MagicCard) PowerValue() (int, bool) {
+	if card.Card == nil {
+		return 0, false
+	}
+	return parseStat(card.Front().Power)
+}
+
+// ToughnessValue returns the card's toughness as a number, see PowerValue.
+func (card *MagicCard) ToughnessValue() (int, bool) {
+	if card.Card == nil {
+		return 0, false
+	}
+	return parseStat(card.Front().Toughness)
+}
+
+// parseStat parses a power or toughness like "2", "*" or "1+*".
+// Returns the fixed part and whether there is no variable part.
+func parseStat(stat *string) (int, bool) {
+	if stat == nil {
+		return 0, false
+	}
+	s := strings.TrimSpace(*stat)
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+
+	// a number followed by a variable part: "1+*", "7-*"
+	end := 0
+	if end < len(s) && (s[end] == '-' || s[end] == '+') {
+		end++
+	}
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n, false
+}
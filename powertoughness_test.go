@@ -0,0 +1,39 @@
+package scryball
+
+import "testing"
+
+func TestPowerToughnessValue(t *testing.T) {
+	tests := []struct {
+		stat  string
+		value int
+		fixed bool
+	}{
+		{"3", 3, true},
+		{"0", 0, true},
+		{"-1", -1, true},
+		{"*", 0, false},
+		{"1+*", 1, false},
+		{"7-*", 7, false},
+		{"X", 0, false},
+		{"∞", 0, false},
+		{"3.5", 3, false},
+	}
+	for _, tt := range tests {
+		card := testAPICard("card", "card-1", "Card", "Creature")
+		stat := tt.stat
+		card.Power, card.Toughness = &stat, &stat
+		magicCard := &MagicCard{Card: card}
+
+		if value, fixed := magicCard.PowerValue(); value != tt.value || fixed != tt.fixed {
+			t.Errorf("PowerValue of %q = %d, %v, expected %d, %v", tt.stat, value, fixed, tt.value, tt.fixed)
+		}
+		if value, fixed := magicCard.ToughnessValue(); value != tt.value || fixed != tt.fixed {
+			t.Errorf("ToughnessValue of %q = %d, %v, expected %d, %v", tt.stat, value, fixed, tt.value, tt.fixed)
+		}
+	}
+
+	bolt := &MagicCard{Card: testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant")}
+	if value, fixed := bolt.PowerValue(); value != 0 || fixed {
+		t.Errorf("Expected no power for an instant, got %d, %v", value, fixed)
+	}
+}
@@ -0,0 +1,188 @@
+package scryball
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ninesl/scryball/internal/client"
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// previewQuery is the query RefreshPreviews runs and caches for a set.
+func previewQuery(setCode string) string {
+	return "set:" + strings.ToLower(setCode) + " include:extras"
+}
+
+// UpcomingSets returns the codes of the sets Scryfall lists with a release
+// date in the future, soonest first, for following their previews.
+//
+// Behavior:
+//   - Makes one API call to list Scryfall's sets
+//   - Sets without a release date are not listed
+func (s *Scryball) UpcomingSets(ctx context.Context) ([]string, error) {
+	sets, err := s.client.ListSets()
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	upcoming := []client.Set{}
+	for _, set := range sets {
+		if set.ReleasedAt != nil && *set.ReleasedAt > today {
+			upcoming = append(upcoming, set)
+		}
+	}
+	slices.SortStableFunc(upcoming, func(a, b client.Set) int {
+		return strings.Compare(*a.ReleasedAt, *b.ReleasedAt)
+	})
+
+	codes := make([]string, len(upcoming))
+	for i, set := range upcoming {
+		codes[i] = set.Code
+	}
+	return codes, nil
+}
+
+// RefreshPreviews fetches every card of a set again, including extras like
+// tokens and promos, and returns the cards added since the last refresh, for
+// following previews of an upcoming set.
+//
+// Behavior:
+//   - Always searches Scryfall for "set:<code> include:extras", bypassing the cache
+//   - Cards not cached before are inserted with all their printings, like Query;
+//     cached cards are updated from the search results with no extra API calls
+//   - The results are cached as the query "set:<code> include:extras", which
+//     remembers what was seen: the first refresh returns every card
+//   - Cards are compared by Oracle ID, so a new printing of a card already
+//     seen in the set is not reported
+//
+// Returns:
+//   - []*MagicCard: Cards added since the last refresh, in Scryfall's order (empty if none)
+//   - error: Network errors, API errors, or database errors. A set without cards
+//     yet is not an error
+func (s *Scryball) RefreshPreviews(ctx context.Context, setCode string) ([]*MagicCard, error) {
+	query := previewQuery(setCode)
+
+	seen := map[string]bool{}
+	if cached, err := s.queries.GetCachedQuery(ctx, query); err == nil {
+		var oracleIDs []string
+		if err := json.Unmarshal([]byte(cached.OracleIds), &oracleIDs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal oracle IDs: %v", err)
+		}
+		for _, oracleID := range oracleIDs {
+			seen[oracleID] = true
+		}
+	}
+
+	apiCards, err := s.client.QueryForCards(query)
+	if errors.Is(err, client.ErrNotFound) {
+		return []*MagicCard{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	added := []*MagicCard{}
+	oracleIDs := []string{}
+	inResults := map[string]bool{}
+	for i := range apiCards {
+		apiCard := &apiCards[i]
+		if apiCard.OracleID == nil || inResults[*apiCard.OracleID] {
+			continue
+		}
+		oracleID := *apiCard.OracleID
+		inResults[oracleID] = true
+		oracleIDs = append(oracleIDs, oracleID)
+
+		if _, err := s.queries.GetCardByOracleID(ctx, oracleID); err == nil {
+			if err := s.upsertAPICards(ctx, []*client.Card{apiCard}); err != nil {
+				return nil, err
+			}
+		} else if _, err := s.InsertCardFromAPI(ctx, apiCard); err != nil {
+			return nil, err
+		}
+
+		if !seen[oracleID] {
+			card, err := s.FetchCardByExactOracleID(ctx, oracleID)
+			if err != nil {
+				return nil, err
+			}
+			added = append(added, card)
+		}
+	}
+
+	if err := s.recacheQuery(ctx, query, oracleIDs); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// WatchPreviews calls RefreshPreviews for setCode every interval until ctx is
+// done, calling onRefresh with the added cards or the error of every refresh
+// that added cards or failed. Errors don't stop the watch.
+//
+// Returns:
+//   - error: The context error, once ctx is done
+//
+// Example:
+//
+//	upcoming, _ := sb.UpcomingSets(ctx)
+//	sb.WatchPreviews(ctx, upcoming[0], 15*time.Minute, func(cards []*scryball.MagicCard, err error) {
+//	    for _, card := range cards {
+//	        fmt.Println("New preview:", card.Name)
+//	    }
+//	})
+func (s *Scryball) WatchPreviews(ctx context.Context, setCode string, interval time.Duration, onRefresh func(cards []*MagicCard, err error)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		cards, err := s.RefreshPreviews(ctx, setCode)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil || len(cards) > 0 {
+			onRefresh(cards, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// recacheQuery replaces the cached oracleIDs of query.
+func (s *Scryball) recacheQuery(ctx context.Context, query string, oracleIDs []string) error {
+	oracleIDsJSON, err := json.Marshal(oracleIDs)
+	if err != nil {
+		return fmt.Errorf("could not marshal oracle IDs: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not cache query: %v", err)
+	}
+	defer tx.Rollback()
+	queries := s.queries.WithTx(tx)
+
+	if _, err := queries.DeleteCachedQuery(ctx, query); err != nil {
+		return fmt.Errorf("could not cache query: %v", err)
+	}
+	err = queries.InsertQueryCache(ctx, scryfall.InsertQueryCacheParams{
+		QueryText: query,
+		OracleIds: string(oracleIDsJSON),
+	})
+	if err != nil {
+		return fmt.Errorf("could not cache query: %v", err)
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,63 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRefreshPreviews(t *testing.T) {
+	card := func(id, oracleID, name string) string {
+		return fmt.Sprintf(`{"object": "card", "id": %q, "oracle_id": %q, "name": %q, "lang": "en",
+			"type_line": "Creature", "set": "new", "rarity": "rare", "released_at": "2999-01-01"}`, id, oracleID, name)
+	}
+	previews := []string{card("a-1", "a", "Alpha Preview"), card("b-1", "b", "Beta Preview")}
+	var queries []string
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/cards/search" {
+			http.NotFound(w, r)
+			return
+		}
+		queries = append(queries, r.URL.Query().Get("q"))
+		if len(previews) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"object": "error", "status": 404}`)
+			return
+		}
+		fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s]}`, strings.Join(previews, ", "))
+	})
+	ctx := context.Background()
+
+	added, err := sb.RefreshPreviews(ctx, "NEW")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"Alpha Preview", "Beta Preview"}; !reflect.DeepEqual(cardNames(added), expected) {
+		t.Errorf("Expected %v on the first refresh, got %v", expected, cardNames(added))
+	}
+	if queries[0] != "set:new include:extras" {
+		t.Errorf("Expected a search for set:new include:extras, got %q", queries[0])
+	}
+
+	previews = append(previews, card("a-2", "a", "Alpha Preview"), card("c-1", "c", "Gamma Preview"))
+	added, err = sb.RefreshPreviews(ctx, "new")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"Gamma Preview"}; !reflect.DeepEqual(cardNames(added), expected) {
+		t.Errorf("Expected only %v to be new, got %v", expected, cardNames(added))
+	}
+
+	cached, err := sb.FetchCardsByQuery(ctx, "set:new include:extras")
+	if err != nil || len(cached) != 3 {
+		t.Errorf("Expected the 3 previews to be cached, got %d, %v", len(cached), err)
+	}
+
+	previews = nil
+	if added, err := sb.RefreshPreviews(ctx, "empty"); err != nil || len(added) != 0 {
+		t.Errorf("Expected no cards for a set without previews, got %v, %v", cardNames(added), err)
+	}
+}
@@ -0,0 +1,239 @@
+package scryball
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// PriceDirection controls which side of a threshold triggers a PriceAlert.
+type PriceDirection string
+
+const (
+	PriceBelow PriceDirection = "below" // Triggers when a price drops below the threshold
+	PriceAbove PriceDirection = "above" // Triggers when a price rises above the threshold
+)
+
+// PriceAlert is a stored price threshold for a card.
+//
+// Any printing of the card can trigger the alert. Currency is a key of
+// Scryfall's prices object: "usd", "usd_foil", "usd_etched", "eur", "eur_foil" or "tix".
+//
+// An alert triggers once when its threshold is crossed, and is re-armed once
+// no printing is past the threshold anymore.
+type PriceAlert struct {
+	ID              int64
+	OracleID        string
+	CardName        string
+	Currency        string
+	Threshold       Price
+	Direction       PriceDirection
+	CreatedAt       string
+	LastTriggeredAt string // Empty while the alert is armed: not triggered yet, or re-armed since
+}
+
+// TriggeredAlert is a PriceAlert whose threshold was crossed, along with
+// the printing that crossed it.
+//
+// When several printings cross the threshold, the one furthest past it is
+// reported (the cheapest for PriceBelow, the most expensive for PriceAbove).
+type TriggeredAlert struct {
+	Alert           PriceAlert
+	PrintingID      string
+	SetCode         string
+	SetName         string
+	CollectorNumber string
+	Price           Price
+}
+
+// AddPriceAlert registers a price alert for a card, stored in the database.
+//
+// Behavior:
+//   - Resolves cardName like QueryCard (cache first, then API)
+//   - Alert persists in the database until removed with RemovePriceAlert
+//   - Evaluated by CheckPriceAlerts against cached printing prices
+//
+// Returns:
+//   - *PriceAlert: The stored alert
+//   - error: Invalid direction/threshold, card lookup failures, or database errors
+//
+// Example:
+//
+//	// notify when any printing of Ragavan drops below $40
+//	alert, err := sb.AddPriceAlert(ctx, "Ragavan, Nimble Pilferer", "usd", 4000, scryball.PriceBelow)
+func (s *Scryball) AddPriceAlert(ctx context.Context, cardName, currency string, threshold Price, direction PriceDirection) (*PriceAlert, error) {
+	if direction != PriceBelow && direction != PriceAbove {
+		return nil, fmt.Errorf("invalid price direction %q, must be %q or %q", direction, PriceBelow, PriceAbove)
+	}
+	if threshold < 0 {
+		return nil, fmt.Errorf("price threshold must not be negative, got %s", threshold)
+	}
+	if currency == "" {
+		currency = "usd"
+	}
+
+	card, err := s.findCard(ctx, cardName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, err := s.queries.InsertPriceAlert(ctx, scryfall.InsertPriceAlertParams{
+		OracleID:  *card.OracleID,
+		Currency:  currency,
+		Threshold: threshold.Float64(),
+		Direction: string(direction),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not store price alert for %s: %v", card.Name, err)
+	}
+
+	return &PriceAlert{
+		ID:        id,
+		OracleID:  *card.OracleID,
+		CardName:  card.Name,
+		Currency:  currency,
+		Threshold: threshold,
+		Direction: direction,
+	}, nil
+}
+
+// PriceAlerts returns every registered price alert, oldest first.
+func (s *Scryball) PriceAlerts(ctx context.Context) ([]PriceAlert, error) {
+	rows, err := s.queries.GetPriceAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price alerts: %v", err)
+	}
+
+	alerts := make([]PriceAlert, 0, len(rows))
+	for _, row := range rows {
+		alerts = append(alerts, PriceAlert{
+			ID:              row.AlertID,
+			OracleID:        row.OracleID,
+			CardName:        row.Name,
+			Currency:        row.Currency,
+			Threshold:       Price(math.Round(row.Threshold * 100)),
+			Direction:       PriceDirection(row.Direction),
+			CreatedAt:       row.CreatedAt,
+			LastTriggeredAt: row.LastTriggeredAt.String,
+		})
+	}
+	return alerts, nil
+}
+
+// RemovePriceAlert deletes a registered price alert by ID.
+func (s *Scryball) RemovePriceAlert(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.queries.DeletePriceAlert(ctx, id); err != nil {
+		return fmt.Errorf("could not remove price alert %d: %v", id, err)
+	}
+	return nil
+}
+
+// CheckPriceAlerts evaluates every registered alert against cached prices.
+//
+// Behavior:
+//   - Only checks database cache, never queries API
+//   - Call after prices are refreshed (re-fetching a card updates all its printings)
+//   - Printings without a price in the alert's currency are ignored
+//   - Each crossing is reported once: an alert that already triggered is not
+//     reported again until a check finds no printing past its threshold, which
+//     re-arms it and clears its last triggered time
+//
+// Returns:
+//   - []TriggeredAlert: Alerts whose threshold was crossed since the last check (empty if none)
+//   - error: Database errors
+func (s *Scryball) CheckPriceAlerts(ctx context.Context) ([]TriggeredAlert, error) {
+	alerts, err := s.PriceAlerts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	triggered := []TriggeredAlert{}
+	for _, alert := range alerts {
+		printings, err := s.queries.GetPrintingPricesByOracleID(ctx, alert.OracleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get prices for %s: %v", alert.CardName, err)
+		}
+
+		var best *TriggeredAlert
+		for _, printing := range printings {
+			price, ok := priceFromJSON(printing.Prices, alert.Currency)
+			if !ok || !alert.crossedBy(price) {
+				continue
+			}
+			if best == nil || alert.furtherThan(price, best.Price) {
+				best = &TriggeredAlert{
+					Alert:           alert,
+					PrintingID:      printing.ID,
+					SetCode:         printing.SetCode,
+					SetName:         printing.SetName,
+					CollectorNumber: printing.CollectorNumber,
+					Price:           price,
+				}
+			}
+		}
+		if best == nil {
+			if alert.LastTriggeredAt != "" {
+				s.mu.Lock()
+				err = s.queries.ClearPriceAlertTriggered(ctx, alert.ID)
+				s.mu.Unlock()
+				if err != nil {
+					return nil, fmt.Errorf("could not re-arm price alert %d: %v", alert.ID, err)
+				}
+			}
+			continue
+		}
+		if alert.LastTriggeredAt != "" {
+			// still past the threshold since it was reported
+			continue
+		}
+
+		s.mu.Lock()
+		err = s.queries.MarkPriceAlertTriggered(ctx, alert.ID)
+		s.mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("could not mark price alert %d as triggered: %v", alert.ID, err)
+		}
+		triggered = append(triggered, *best)
+	}
+
+	return triggered, nil
+}
+
+func (a PriceAlert) crossedBy(price Price) bool {
+	if a.Direction == PriceAbove {
+		return price > a.Threshold
+	}
+	return price < a.Threshold
+}
+
+// reports whether price is further past the threshold than other
+func (a PriceAlert) furtherThan(price, other Price) bool {
+	if a.Direction == PriceAbove {
+		return price > other
+	}
+	return price < other
+}
+
+// priceFromJSON reads a single currency out of a printing's prices JSON object.
+func priceFromJSON(pricesJSON, currency string) (Price, bool) {
+	var prices map[string]*string
+	if err := json.Unmarshal([]byte(pricesJSON), &prices); err != nil {
+		return 0, false
+	}
+	raw, ok := prices[currency]
+	if !ok || raw == nil {
+		return 0, false
+	}
+	price, err := ParsePrice(*raw)
+	if err != nil {
+		return 0, false
+	}
+	return price, true
+}
@@ -0,0 +1,105 @@
+package scryball
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCheckPriceAlerts(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	price := func(p string) *string { return &p }
+
+	original := testAPICard("ragavan-oracle", "ragavan-mh2", "Ragavan, Nimble Pilferer", "Legendary Creature — Monkey Pirate")
+	original.Set = "mh2"
+	original.Prices = map[string]*string{"usd": price("55.00"), "usd_foil": price("80.00")}
+
+	reprint := testAPICard("ragavan-oracle", "ragavan-2x2", "Ragavan, Nimble Pilferer", "Legendary Creature — Monkey Pirate")
+	reprint.Set = "2x2"
+	reprint.Prices = map[string]*string{"usd": price("38.50"), "usd_foil": nil}
+
+	insertTestCard(t, sb, original, reprint)
+
+	below, err := sb.AddPriceAlert(ctx, "Ragavan, Nimble Pilferer", "usd", 4000, PriceBelow)
+	if err != nil {
+		t.Fatalf("AddPriceAlert failed: %v", err)
+	}
+	if _, err := sb.AddPriceAlert(ctx, "Ragavan, Nimble Pilferer", "usd_foil", 10000, PriceAbove); err != nil {
+		t.Fatalf("AddPriceAlert failed: %v", err)
+	}
+	if _, err := sb.AddPriceAlert(ctx, "Ragavan, Nimble Pilferer", "usd", 4000, "sideways"); err == nil {
+		t.Error("Expected error for invalid direction")
+	}
+
+	triggered, err := sb.CheckPriceAlerts(ctx)
+	if err != nil {
+		t.Fatalf("CheckPriceAlerts failed: %v", err)
+	}
+	if len(triggered) != 1 {
+		t.Fatalf("Expected 1 triggered alert, got %d", len(triggered))
+	}
+	if triggered[0].Alert.ID != below.ID {
+		t.Errorf("Expected alert %d to trigger, got %d", below.ID, triggered[0].Alert.ID)
+	}
+	if triggered[0].SetCode != "2x2" || triggered[0].Price != 3850 {
+		t.Errorf("Expected 2x2 printing at 38.50, got %s at %s", triggered[0].SetCode, triggered[0].Price)
+	}
+	if triggered[0].Alert.Threshold != 4000 {
+		t.Errorf("Expected a threshold of 40.00, got %s", triggered[0].Alert.Threshold)
+	}
+
+	alerts, err := sb.PriceAlerts(ctx)
+	if err != nil {
+		t.Fatalf("PriceAlerts failed: %v", err)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("Expected 2 stored alerts, got %d", len(alerts))
+	}
+	if alerts[0].LastTriggeredAt == "" {
+		t.Error("Expected triggered alert to record last triggered time")
+	}
+	if alerts[1].LastTriggeredAt != "" {
+		t.Error("Expected untriggered alert to have no last triggered time")
+	}
+
+	// still below the threshold, already reported
+	triggered, err = sb.CheckPriceAlerts(ctx)
+	if err != nil {
+		t.Fatalf("CheckPriceAlerts failed: %v", err)
+	}
+	if len(triggered) != 0 {
+		t.Errorf("Expected an alert to be reported once per crossing, got %d", len(triggered))
+	}
+
+	// the price recovers, re-arming the alert, then drops again
+	reprint.Prices["usd"] = price("42.00")
+	insertTestCard(t, sb, original, reprint)
+	if triggered, err = sb.CheckPriceAlerts(ctx); err != nil || len(triggered) != 0 {
+		t.Fatalf("Expected no triggered alerts above the threshold, got %d (%v)", len(triggered), err)
+	}
+	if alerts, err = sb.PriceAlerts(ctx); err != nil || alerts[0].LastTriggeredAt != "" {
+		t.Errorf("Expected the recovered alert to be re-armed, got %+v (%v)", alerts, err)
+	}
+	reprint.Prices["usd"] = price("39.99")
+	insertTestCard(t, sb, original, reprint)
+	triggered, err = sb.CheckPriceAlerts(ctx)
+	if err != nil {
+		t.Fatalf("CheckPriceAlerts failed: %v", err)
+	}
+	if len(triggered) != 1 || triggered[0].Price != 3999 {
+		t.Errorf("Expected the re-armed alert to trigger at 39.99, got %+v", triggered)
+	}
+
+	if err := sb.RemovePriceAlert(ctx, below.ID); err != nil {
+		t.Fatalf("RemovePriceAlert failed: %v", err)
+	}
+	triggered, err = sb.CheckPriceAlerts(ctx)
+	if err != nil {
+		t.Fatalf("CheckPriceAlerts failed: %v", err)
+	}
+	if len(triggered) != 0 {
+		t.Errorf("Expected no triggered alerts after removal, got %d", len(triggered))
+	}
+}
@@ -0,0 +1,99 @@
+package scryball
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Price is an exact amount of money in hundredths of its currency: 25 is $0.25
+// for "usd" prices, 0.25 tix for "tix" prices.
+type Price int64
+
+// ParsePrice parses a Scryfall price like "0.25" or "1234.5".
+// Returns an error for prices with more than two decimals.
+func ParsePrice(s string) (Price, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("invalid price %q: more than two decimals", s)
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+
+	units, err := strconv.ParseUint(whole, 10, 62)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q", s)
+	}
+	hundredths, err := strconv.ParseUint(frac, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q", s)
+	}
+	return Price(units*100 + hundredths), nil
+}
+
+// String returns the price with two decimals, the way Scryfall writes it: "0.25".
+func (p Price) String() string {
+	sign := ""
+	if p < 0 {
+		sign, p = "-", -p
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, p/100, p%100)
+}
+
+// Float64 returns the price in whole units of its currency: 0.25 for Price(25).
+func (p Price) Float64() float64 {
+	return float64(p) / 100
+}
+
+// Price returns the printing's cached price in currency ("usd", "usd_foil", "eur", "tix", ...).
+// Returns false if the printing has no such price.
+func (p Printing) Price(currency string) (Price, bool) {
+	raw, ok := p.Prices[currency]
+	if !ok {
+		return 0, false
+	}
+	price, err := ParsePrice(raw)
+	return price, err == nil
+}
+
+// ReleaseDate returns the date the printing was released, the zero time if it is unknown.
+func (p Printing) ReleaseDate() time.Time {
+	return parseReleaseDate(p.ReleasedAt)
+}
+
+// Price returns the card's price in currency, falling back to its most recent
+// printing with that price for cards loaded from the database.
+// Returns false if no printing has the price.
+//
+// Example:
+//
+//	card, _ := scryball.QueryCard("Lightning Bolt")
+//	if price, ok := card.Price("usd"); ok {
+//	    fmt.Printf("$%s\n", price) // $1.25
+//	}
+func (card *MagicCard) Price(currency string) (Price, bool) {
+	raw := cardPrice(card, currency)
+	if raw == "" {
+		return 0, false
+	}
+	price, err := ParsePrice(raw)
+	return price, err == nil
+}
+
+// ReleaseDate returns the date the card's printing was released, or its most
+// recent printing for cards loaded from the database. Zero if it is unknown.
+func (card *MagicCard) ReleaseDate() time.Time {
+	if card.Card != nil && card.ReleasedAt != "" {
+		return parseReleaseDate(card.ReleasedAt)
+	}
+	return csvPrinting(card).ReleaseDate()
+}
+
+// parseReleaseDate parses a Scryfall date like "2021-09-24", the zero time if it is invalid.
+func parseReleaseDate(date string) time.Time {
+	released, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}
+	}
+	return released
+}
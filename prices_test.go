@@ -0,0 +1,61 @@
+package scryball
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePrice(t *testing.T) {
+	for input, expected := range map[string]Price{"0.25": 25, "12": 1200, "1.5": 150, "1234.56": 123456, "0.00": 0} {
+		price, err := ParsePrice(input)
+		if err != nil || price != expected {
+			t.Errorf("ParsePrice(%q) = %d, %v, expected %d", input, price, err, expected)
+		}
+	}
+	for _, input := range []string{"", "abc", "1.234", "-1.00", "1.x"} {
+		if _, err := ParsePrice(input); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+
+	if Price(25).String() != "0.25" || Price(123456).String() != "1234.56" || Price(-5).String() != "-0.05" {
+		t.Errorf("Unexpected price strings %s, %s, %s", Price(25), Price(123456), Price(-5))
+	}
+	if Price(150).Float64() != 1.5 {
+		t.Errorf("Expected 1.5, got %v", Price(150).Float64())
+	}
+}
+
+func TestCardPriceAndReleaseDate(t *testing.T) {
+	printing := Printing{ReleasedAt: "2021-09-24", Prices: map[string]string{"usd": "0.25", "tix": "0.02"}}
+	if price, ok := printing.Price("usd"); !ok || price != 25 {
+		t.Errorf("Expected usd price 25, got %d, %v", price, ok)
+	}
+	if _, ok := printing.Price("eur"); ok {
+		t.Error("Expected no eur price")
+	}
+	if released := printing.ReleaseDate(); !released.Equal(time.Date(2021, 9, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 2021-09-24, got %v", released)
+	}
+
+	card := &MagicCard{Card: testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"), Printings: []Printing{printing}}
+	if price, ok := card.Price("tix"); !ok || price.String() != "0.02" {
+		t.Errorf("Expected the tix price of the card's printing, got %s, %v", price, ok)
+	}
+	usd := "1.25"
+	card.Prices["usd"] = &usd
+	if price, _ := card.Price("usd"); price != 125 {
+		t.Errorf("Expected the card's own usd price, got %s", price)
+	}
+	if card.ReleaseDate().Year() != 2020 {
+		t.Errorf("Expected the card's own release date, got %v", card.ReleaseDate())
+	}
+	card.ReleasedAt = ""
+	if card.ReleaseDate().Year() != 2021 {
+		t.Errorf("Expected the release date of the card's printing, got %v", card.ReleaseDate())
+	}
+
+	if !(Printing{ReleasedAt: "soon"}).ReleaseDate().IsZero() {
+		t.Error("Expected an invalid date to be the zero time")
+	}
+}
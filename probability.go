@@ -0,0 +1,71 @@
+package scryball
+
+import "math"
+
+// HypergeometricExactly returns the chance of drawing exactly k successes.
+//
+// population is the number of cards drawn from (deck size), successes the
+// number of those that count (copies of a card), draws the number of cards
+// drawn. Returns 0 for impossible or invalid arguments.
+func HypergeometricExactly(population, successes, draws, k int) float64 {
+	if population < 0 || successes < 0 || successes > population || draws < 0 || draws > population {
+		return 0
+	}
+	if k < 0 || k > successes || k > draws || draws-k > population-successes {
+		return 0
+	}
+	return math.Exp(logChoose(successes, k) + logChoose(population-successes, draws-k) - logChoose(population, draws))
+}
+
+// HypergeometricAtLeast returns the chance of drawing atLeast or more successes.
+//
+// See HypergeometricExactly for the arguments.
+//
+// Example:
+//
+//	// chance of at least 1 of 4 copies in the top 10 of a 60 card deck ≈ 0.5277
+//	p := scryball.HypergeometricAtLeast(60, 4, 10, 1)
+func HypergeometricAtLeast(population, successes, draws, atLeast int) float64 {
+	if population < 0 || successes < 0 || successes > population || draws < 0 || draws > population {
+		return 0
+	}
+
+	atLeast = max(atLeast, 0)
+	p := 0.0
+	for k := atLeast; k <= min(successes, draws); k++ {
+		p += HypergeometricExactly(population, successes, draws, k)
+	}
+	return min(p, 1)
+}
+
+// logChoose returns the natural log of n choose k.
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// DrawProbability returns the chance of at least atLeast copies of card in the top draws cards of the maindeck.
+//
+// Copies are matched by oracle ID. Example:
+//
+//	// chance of a Lightning Bolt in the opening hand plus first draw
+//	p := deck.DrawProbability(bolt, 1, 8)
+func (d *Decklist) DrawProbability(card *MagicCard, atLeast, draws int) float64 {
+	key, ok := doesCardExistInMap(card, d.Maindeck)
+	if !ok {
+		return HypergeometricAtLeast(d.NumberOfCards(), 0, draws, atLeast)
+	}
+	return HypergeometricAtLeast(d.NumberOfCards(), d.Maindeck[key], draws, atLeast)
+}
+
+// DrawProbabilityOf returns the chance of at least atLeast of copies cards in the top draws cards of the maindeck.
+//
+// Use it for groups of cards, like lands or every one-drop:
+//
+//	// chance of 3 or more lands in the opening hand
+//	p := deck.DrawProbabilityOf(deck.LandCount(), 3, 7)
+func (d *Decklist) DrawProbabilityOf(copies, atLeast, draws int) float64 {
+	return HypergeometricAtLeast(d.NumberOfCards(), copies, draws, atLeast)
+}
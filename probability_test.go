@@ -0,0 +1,55 @@
+package scryball
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHypergeometric(t *testing.T) {
+	tests := []struct {
+		name                                  string
+		population, successes, draws, atLeast int
+		expected                              float64
+	}{
+		{"one of 4 in top 10 of 60", 60, 4, 10, 1, 0.52774},
+		{"one of 4 in opening hand", 60, 4, 7, 1, 0.39949},
+		{"two of 4 in opening hand", 60, 4, 7, 2, 0.06322},
+		{"at least 0", 60, 4, 7, 0, 1},
+		{"more than drawn", 60, 4, 2, 3, 0},
+		{"every card drawn", 60, 4, 60, 4, 1},
+		{"no copies", 60, 0, 7, 1, 0},
+		{"invalid draws", 60, 4, 61, 1, 0},
+	}
+
+	for _, tt := range tests {
+		got := HypergeometricAtLeast(tt.population, tt.successes, tt.draws, tt.atLeast)
+		if math.Abs(got-tt.expected) > 1e-4 {
+			t.Errorf("%s: expected %.5f, got %.5f", tt.name, tt.expected, got)
+		}
+	}
+
+	if p := HypergeometricExactly(60, 4, 7, 0); math.Abs(p-0.60051) > 1e-4 {
+		t.Errorf("Expected 0.60051 chance of no copies in opening hand, got %.5f", p)
+	}
+}
+
+func TestDecklistDrawProbability(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	boltReprint := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-2", "Lightning Bolt", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 56)
+
+	if p := deck.DrawProbability(boltReprint, 1, 10); math.Abs(p-0.52774) > 1e-4 {
+		t.Errorf("Expected 0.52774 for a Lightning Bolt in the top 10, got %.5f", p)
+	}
+	if p := deck.DrawProbability(pyro, 1, 10); p != 0 {
+		t.Errorf("Expected 0 for a card not in the maindeck, got %.5f", p)
+	}
+	if p := deck.DrawProbabilityOf(deck.LandCount(), 1, 7); p != 1 {
+		t.Errorf("Expected a land in every opening hand with 56 lands, got %.5f", p)
+	}
+}
@@ -0,0 +1,364 @@
+package scryball
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// ProxyFormat is the output format of Decklist.RenderProxies.
+type ProxyFormat int
+
+const (
+	ProxyPDF ProxyFormat = iota // Letter sized PDF, one page per 9 cards
+	ProxyPNG                    // A single Letter sized page as a PNG image
+)
+
+// ProxiesPerPage is the number of cards on a proxy sheet, in a 3x3 grid.
+const ProxiesPerPage = 9
+
+// Proxy sheet dimensions, in points (1/72 inch). Cards are printed at their real
+// size of 63x88mm on a US Letter page.
+const (
+	proxyPageWidth  = 612.0
+	proxyPageHeight = 792.0
+	proxyCardWidth  = 63 / 25.4 * 72
+	proxyCardHeight = 88 / 25.4 * 72
+)
+
+// ProxyOptions controls Decklist.RenderProxies.
+type ProxyOptions struct {
+	Format    ProxyFormat // ProxyPDF (default) or ProxyPNG
+	Sideboard bool        // Also print sideboard cards
+	Page      int         // Page to render for ProxyPNG, 0-based
+	DPI       int         // Resolution of ProxyPNG output, 300 if unset
+	Scryball  *Scryball   // Instance used to download images, nil for the global instance
+}
+
+// proxyCards returns the image URI of every card to print, one per copy.
+func (d *Decklist) proxyCards(sideboard bool) ([]string, error) {
+	var uris []string
+	var missing []string
+	add := func(qty int, card *MagicCard) {
+		uri := d.proxyImageURI(card)
+		if uri == "" {
+			missing = append(missing, card.Name)
+			return
+		}
+		for range qty {
+			uris = append(uris, uri)
+		}
+	}
+
+	for _, card := range d.Commanders {
+		add(1, card)
+	}
+	if d.Companion != nil {
+		add(1, d.Companion)
+	}
+	for _, card := range sortedCards(d.Maindeck) {
+		add(d.Maindeck[card], card)
+	}
+	if sideboard {
+		for _, card := range sortedCards(d.Sideboard) {
+			add(d.Sideboard[card], card)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no image for: %s", strings.Join(missing, ", "))
+	}
+	return uris, nil
+}
+
+// proxyImageURI picks the large image to print for a card: its requested printing,
+// then the card's own image, then its most recent cached printing with an image.
+// Only the front face of double-faced cards is printed.
+func (d *Decklist) proxyImageURI(card *MagicCard) string {
+	if requested := d.Printings[card].Printing; requested != nil {
+		if uri := requested.ImageURIForSize(ImageLarge); uri != "" {
+			return uri
+		}
+	}
+	if card.Card != nil {
+		if uri := card.ImageURIs[string(ImageLarge)]; uri != "" {
+			return uri
+		}
+		if len(card.CardFaces) > 0 {
+			if uri := card.CardFaces[0].ImageURIs[string(ImageLarge)]; uri != "" {
+				return uri
+			}
+		}
+	}
+	for _, printing := range card.Printings {
+		if uri := printing.ImageURIForSize(ImageLarge); uri != "" {
+			return uri
+		}
+	}
+	return ""
+}
+
+// ProxyPageCount returns the number of pages RenderProxies produces as a PDF.
+func (d *Decklist) ProxyPageCount(opts ProxyOptions) int {
+	cards := d.NumberOfCards() + len(d.Commanders)
+	if d.Companion != nil {
+		cards++
+	}
+	if opts.Sideboard {
+		cards += d.NumberOfSideboardCards()
+	}
+	return (cards + ProxiesPerPage - 1) / ProxiesPerPage
+}
+
+// RenderProxies downloads card images and lays them out 9 per page for playtest proxies.
+//
+// Behavior:
+//   - Prints one image per copy: commanders, companion, then the maindeck sorted by
+//     name, and the sideboard when opts.Sideboard is set
+//   - Uses each card's requested printing (see Decklist.Printings) when it has one
+//   - Cards are printed at their real size, 63x88mm, in a 3x3 grid on US Letter pages
+//   - ProxyPDF writes every page; ProxyPNG writes the single page opts.Page
+//     (see ProxyPageCount)
+//   - Images are downloaded once and kept in the image cache (see Scryball.FetchImage)
+//
+// Returns:
+//   - error: Cards without an image, download or decode failures, a page out of
+//     range, or write errors. Nothing is written on error.
+//
+// Note: Uses the global Scryball instance unless opts.Scryball is set.
+//
+// Example:
+//
+//	f, _ := os.Create("proxies.pdf")
+//	defer f.Close()
+//	if err := deck.RenderProxies(f, scryball.ProxyOptions{Sideboard: true}); err != nil {
+//	    log.Fatal(err)
+//	}
+func (d *Decklist) RenderProxies(w io.Writer, opts ProxyOptions) error {
+	ctx := context.Background()
+	return d.RenderProxiesWithContext(ctx, w, opts)
+}
+
+// RenderProxiesWithContext renders proxies like RenderProxies with context support for image downloads.
+func (d *Decklist) RenderProxiesWithContext(ctx context.Context, w io.Writer, opts ProxyOptions) error {
+	sb := opts.Scryball
+	if sb == nil {
+		var err error
+		if sb, err = ensureCurrentScryball(); err != nil {
+			return fmt.Errorf("failed to initialize scryball %v", err)
+		}
+	}
+
+	uris, err := d.proxyCards(opts.Sideboard)
+	if err != nil {
+		return err
+	}
+	if len(uris) == 0 {
+		return fmt.Errorf("decklist has no cards to print")
+	}
+
+	if opts.Format == ProxyPNG {
+		start := opts.Page * ProxiesPerPage
+		if opts.Page < 0 || start >= len(uris) {
+			return fmt.Errorf("page %d out of range, deck has %d pages", opts.Page, (len(uris)+ProxiesPerPage-1)/ProxiesPerPage)
+		}
+		uris = uris[start:min(start+ProxiesPerPage, len(uris))]
+	}
+
+	images := make(map[string][]byte)
+	for _, uri := range uris {
+		if _, ok := images[uri]; ok {
+			continue
+		}
+		data, err := sb.fetchImage(ctx, uri)
+		if err != nil {
+			return fmt.Errorf("failed to download %s: %v", uri, err)
+		}
+		images[uri] = data
+	}
+
+	var out bytes.Buffer
+	switch opts.Format {
+	case ProxyPNG:
+		err = renderProxyPNG(&out, uris, images, opts.DPI)
+	default:
+		err = renderProxyPDF(&out, uris, images)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = out.WriteTo(w)
+	return err
+}
+
+// proxySlot returns the lower left corner, in points from the bottom left of
+// the page, of the i-th card on a page.
+func proxySlot(i int) (x, y float64) {
+	marginX := (proxyPageWidth - 3*proxyCardWidth) / 2
+	marginY := (proxyPageHeight - 3*proxyCardHeight) / 2
+	col, row := i%3, i/3
+	return marginX + float64(col)*proxyCardWidth, proxyPageHeight - marginY - float64(row+1)*proxyCardHeight
+}
+
+// renderProxyPNG draws one page of cards at dpi, scaling each image to card size.
+func renderProxyPNG(w io.Writer, uris []string, images map[string][]byte, dpi int) error {
+	if dpi <= 0 {
+		dpi = 300
+	}
+	scale := float64(dpi) / 72
+	page := image.NewRGBA(image.Rect(0, 0, int(proxyPageWidth*scale), int(proxyPageHeight*scale)))
+	draw.Draw(page, page.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	decoded := make(map[string]image.Image)
+	for i, uri := range uris {
+		img, ok := decoded[uri]
+		if !ok {
+			var err error
+			if img, _, err = image.Decode(bytes.NewReader(images[uri])); err != nil {
+				return fmt.Errorf("failed to decode %s: %v", uri, err)
+			}
+			decoded[uri] = img
+		}
+
+		x, y := proxySlot(i)
+		top := proxyPageHeight - y - proxyCardHeight
+		dst := image.Rect(int(x*scale), int(top*scale), int((x+proxyCardWidth)*scale), int((top+proxyCardHeight)*scale))
+		scaleInto(page, dst, img)
+	}
+
+	return png.Encode(w, page)
+}
+
+// scaleInto draws src stretched over dst with nearest neighbor sampling.
+func scaleInto(page *image.RGBA, dst image.Rectangle, src image.Image) {
+	sb := src.Bounds()
+	for y := dst.Min.Y; y < dst.Max.Y; y++ {
+		sy := sb.Min.Y + (y-dst.Min.Y)*sb.Dy()/dst.Dy()
+		for x := dst.Min.X; x < dst.Max.X; x++ {
+			sx := sb.Min.X + (x-dst.Min.X)*sb.Dx()/dst.Dx()
+			page.Set(x, y, src.At(sx, sy))
+		}
+	}
+}
+
+// renderProxyPDF writes a PDF with one page per 9 cards. JPEG images are embedded
+// as they are, other images are converted to JPEG first.
+func renderProxyPDF(w *bytes.Buffer, uris []string, images map[string][]byte) error {
+	pdf := &pdfWriter{buf: w}
+	pdf.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// objects 1 and 2 are the catalog and page tree, written last
+	pdf.offsets = make([]int, 2)
+
+	imageIDs := make(map[string]int)
+	for _, uri := range uris {
+		if _, ok := imageIDs[uri]; ok {
+			continue
+		}
+		data, config, err := pdfJPEG(images[uri])
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %v", uri, err)
+		}
+		colorSpace := "/DeviceRGB"
+		if config.ColorModel == color.GrayModel {
+			colorSpace = "/DeviceGray"
+		}
+		imageIDs[uri] = pdf.object(fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>\nstream\n%s\nendstream",
+			config.Width, config.Height, colorSpace, len(data), data))
+	}
+
+	var pageIDs []int
+	for start := 0; start < len(uris); start += ProxiesPerPage {
+		page := uris[start:min(start+ProxiesPerPage, len(uris))]
+
+		var content, resources strings.Builder
+		used := make(map[int]bool)
+		for i, uri := range page {
+			id := imageIDs[uri]
+			x, y := proxySlot(i)
+			fmt.Fprintf(&content, "q %.2f 0 0 %.2f %.2f %.2f cm /Im%d Do Q\n", proxyCardWidth, proxyCardHeight, x, y, id)
+			if !used[id] {
+				used[id] = true
+				fmt.Fprintf(&resources, "/Im%d %d 0 R ", id, id)
+			}
+		}
+
+		contentID := pdf.object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+		pageIDs = append(pageIDs, pdf.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /XObject << %s>> >> /Contents %d 0 R >>",
+			proxyPageWidth, proxyPageHeight, resources.String(), contentID)))
+	}
+
+	kids := make([]string, len(pageIDs))
+	for i, id := range pageIDs {
+		kids[i] = fmt.Sprintf("%d 0 R", id)
+	}
+	pdf.objectAt(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	pdf.objectAt(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pageIDs)))
+
+	pdf.finish(1)
+	return nil
+}
+
+// pdfJPEG returns image data as a baseline RGB or grayscale JPEG, converting other formats.
+func pdfJPEG(data []byte) ([]byte, image.Config, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, image.Config{}, err
+	}
+	if format == "jpeg" && (config.ColorModel == color.YCbCrModel || config.ColorModel == color.GrayModel) {
+		return data, config, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, image.Config{}, err
+	}
+	// flatten transparency onto white, as the card would be printed
+	flat := image.NewRGBA(img.Bounds())
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, flat, &jpeg.Options{Quality: 95}); err != nil {
+		return nil, image.Config{}, err
+	}
+	return out.Bytes(), image.Config{ColorModel: color.YCbCrModel, Width: flat.Bounds().Dx(), Height: flat.Bounds().Dy()}, nil
+}
+
+// pdfWriter writes numbered PDF objects and the cross-reference table.
+type pdfWriter struct {
+	buf     *bytes.Buffer
+	offsets []int // byte offset of each object, offsets[0] is object 1
+}
+
+// object writes a new object and returns its number.
+func (p *pdfWriter) object(body string) int {
+	p.offsets = append(p.offsets, 0)
+	id := len(p.offsets)
+	p.objectAt(id, body)
+	return id
+}
+
+// objectAt writes the object with a number reserved earlier.
+func (p *pdfWriter) objectAt(id int, body string) {
+	p.offsets[id-1] = p.buf.Len()
+	fmt.Fprintf(p.buf, "%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+// finish writes the cross-reference table and trailer.
+func (p *pdfWriter) finish(root int) {
+	xref := p.buf.Len()
+	fmt.Fprintf(p.buf, "xref\n0 %d\n0000000000 65535 f \n", len(p.offsets)+1)
+	for _, offset := range p.offsets {
+		fmt.Fprintf(p.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(p.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.offsets)+1, root, xref)
+}
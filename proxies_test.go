@@ -0,0 +1,120 @@
+package scryball
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRenderProxies(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	red := image.NewRGBA(image.Rect(0, 0, 61, 85))
+	for y := range 85 {
+		for x := range 61 {
+			red.Set(x, y, color.RGBA{R: 200, A: 255})
+		}
+	}
+	var redJPEG, redPNG bytes.Buffer
+	if err := jpeg.Encode(&redJPEG, red, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(&redPNG, red); err != nil {
+		t.Fatal(err)
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if strings.Contains(r.URL.Path, "mountain") { // PNG served as .jpg, converted for the PDF
+			w.Write(redPNG.Bytes())
+			return
+		}
+		w.Write(redJPEG.Bytes())
+	}))
+	defer server.Close()
+
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	bolt.ImageURIs = map[string]string{"large": server.URL + "/large/bolt.jpg"}
+	mountain := &MagicCard{
+		Card:      testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"),
+		Printings: []Printing{{SetCode: "m21", ImageURI: server.URL + "/normal/mountain.png"}},
+	}
+	pyro := &MagicCard{
+		Card:      testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant"),
+		Printings: []Printing{{SetCode: "ema", ImageURI: server.URL + "/normal/pyro.jpg"}},
+	}
+
+	deck := &Decklist{
+		Maindeck:  map[*MagicCard]int{bolt: 4, mountain: 6},
+		Sideboard: map[*MagicCard]int{pyro: 3},
+	}
+
+	t.Run("pdf", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := deck.RenderProxies(&out, ProxyOptions{Scryball: sb, Sideboard: true}); err != nil {
+			t.Fatalf("RenderProxies failed: %v", err)
+		}
+		pdf := out.String()
+		if !strings.HasPrefix(pdf, "%PDF-1.4") || !strings.HasSuffix(pdf, "%%EOF\n") {
+			t.Error("Expected a complete PDF")
+		}
+		if !strings.Contains(pdf, "/Type /Pages /Kids [") || !strings.Contains(pdf, "/Count 2") {
+			t.Error("Expected 13 cards on 2 pages")
+		}
+		if got := strings.Count(pdf, "/Subtype /Image"); got != 3 {
+			t.Errorf("Expected 3 distinct images, got %d", got)
+		}
+		if got := strings.Count(pdf, " Do Q"); got != 13 {
+			t.Errorf("Expected 13 cards drawn, got %d", got)
+		}
+		if got := requests.Load(); got != 3 {
+			t.Errorf("Expected each image to be downloaded once, got %d requests", got)
+		}
+		if deck.ProxyPageCount(ProxyOptions{Sideboard: true}) != 2 {
+			t.Errorf("Expected ProxyPageCount 2, got %d", deck.ProxyPageCount(ProxyOptions{Sideboard: true}))
+		}
+	})
+
+	t.Run("png", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := deck.RenderProxies(&out, ProxyOptions{Scryball: sb, Format: ProxyPNG, Page: 1, DPI: 72}); err != nil {
+			t.Fatalf("RenderProxies failed: %v", err)
+		}
+		img, err := png.Decode(&out)
+		if err != nil {
+			t.Fatalf("Expected a PNG: %v", err)
+		}
+		if img.Bounds().Dx() != 612 || img.Bounds().Dy() != 792 {
+			t.Errorf("Expected a 612x792 page at 72 DPI, got %v", img.Bounds())
+		}
+		// page 2 holds Mountain in the first slot, the center of the page is empty
+		if r, g, b, _ := img.At(120, 120).RGBA(); r>>8 < 150 || g>>8 > 60 || b>>8 > 60 {
+			t.Errorf("Expected a card in the first slot, got %d,%d,%d", r>>8, g>>8, b>>8)
+		}
+		if r, g, b, _ := img.At(306, 396).RGBA(); r>>8 != 255 || g>>8 != 255 || b>>8 != 255 {
+			t.Errorf("Expected an empty slot to be white, got %d,%d,%d", r>>8, g>>8, b>>8)
+		}
+
+		if err := deck.RenderProxies(&out, ProxyOptions{Scryball: sb, Format: ProxyPNG, Page: 2}); err == nil {
+			t.Error("Expected an error for a page out of range")
+		}
+	})
+
+	t.Run("missing_image", func(t *testing.T) {
+		deck.Maindeck[&MagicCard{Card: testAPICard("lotus-oracle", "lotus-1", "Black Lotus", "Artifact")}] = 1
+		var out bytes.Buffer
+		err := deck.RenderProxies(&out, ProxyOptions{Scryball: sb})
+		if err == nil || !strings.Contains(err.Error(), "Black Lotus") || out.Len() != 0 {
+			t.Errorf("Expected an error naming Black Lotus and nothing written, got %v", err)
+		}
+	})
+}
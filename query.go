@@ -33,7 +33,10 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/ninesl/scryball/internal/client"
 	"github.com/ninesl/scryball/internal/scryfall"
@@ -66,12 +69,18 @@ func (s *Scryball) InsertCardFromAPI(ctx context.Context, apiCard *client.Card)
 	if err != nil {
 		return nil, fmt.Errorf("could not upsert card %s: %v", apiCard.Name, err)
 	}
+	if err := upsertFoldedNames(ctx, s.queries, cardParams.OracleID, cardParams.Name); err != nil {
+		return nil, fmt.Errorf("could not upsert folded names of %s: %v", apiCard.Name, err)
+	}
 
 	// Insert the initial printing
 	err = s.queries.UpsertPrinting(ctx, printingParams)
 	if err != nil {
 		return nil, fmt.Errorf("could not upsert printing for %s: %v", apiCard.Name, err)
 	}
+	if err := upsertPrintingFaces(ctx, s.queries, apiCard); err != nil {
+		return nil, fmt.Errorf("could not upsert face images for %s: %v", apiCard.Name, err)
+	}
 
 	// Fetch ALL printings for this card and store them
 	if apiCard.OracleID != nil {
@@ -98,6 +107,7 @@ func (s *Scryball) InsertCardFromAPI(ctx context.Context, apiCard *client.Card)
 				if err != nil {
 					continue // Skip failed printings
 				}
+				upsertPrintingFaces(ctx, s.queries, &printing)
 			}
 		}
 	}
@@ -132,12 +142,24 @@ func (sb *Scryball) cacheQuery(ctx context.Context, query string, oracleIDs []st
 
 // returns the cards every card found. will insert each card it finds (including pages/List see scryfall docs)
 func (sb *Scryball) findQuery(ctx context.Context, query string) ([]*MagicCard, error) {
+	return sb.findQueryShared(ctx, query, nil)
+}
+
+// findQuery sharing cards between queries: cards already in shared (by oracle_id)
+// are reused instead of fetched again, and found cards are added to it. shared may be nil
+func (sb *Scryball) findQueryShared(ctx context.Context, query string, shared map[string]*MagicCard) ([]*MagicCard, error) {
 	cachedCards, err := sb.FetchCardsByQuery(ctx, query)
 	if err == nil {
-		var oracleIDs = make([]string, len(cachedCards))
-		for i, card := range cachedCards {
-			if card.OracleID != nil {
-				oracleIDs[i] = *card.OracleID
+		if shared != nil {
+			for i, card := range cachedCards {
+				if card.OracleID == nil {
+					continue
+				}
+				if known, ok := shared[*card.OracleID]; ok {
+					cachedCards[i] = known
+				} else {
+					shared[*card.OracleID] = card
+				}
 			}
 		}
 		return cachedCards, nil
@@ -153,34 +175,34 @@ func (sb *Scryball) findQuery(ctx context.Context, query string) ([]*MagicCard,
 		return nil, err
 	}
 
-	// Group cards by oracle_id - skip cards with null oracle_id
-	oracleMap := make(map[string]*client.Card)
+	// Process each unique card (by oracle_id) in the order Scryfall returned
+	// them, which honors order: and dir: in the query. Keep the first card we
+	// see for each oracle_id and skip cards with null oracle_id.
+	magicCards := make([]*MagicCard, 0, len(apiCards))
+	oracleIDs := make([]string, 0, len(apiCards))
+	seen := make(map[string]bool, len(apiCards))
+
 	for i := range apiCards {
-		card := &apiCards[i]
-		if card.OracleID == nil {
-			// Skip cards with null oracle_id
+		sampleCard := &apiCards[i]
+		if sampleCard.OracleID == nil || seen[*sampleCard.OracleID] {
 			continue
 		}
-		oracleID := *card.OracleID
-		// Keep the first card we see for this oracle_id
-		if _, exists := oracleMap[oracleID]; !exists {
-			oracleMap[oracleID] = card
-		}
-	}
-
-	// Process each unique card (by oracle_id) and ensure ALL printings are fetched
-	magicCards := make([]*MagicCard, 0, len(oracleMap))
-	oracleIDs := make([]string, 0, len(oracleMap))
-
-	for oracleID, sampleCard := range oracleMap {
-		// InsertCardFromAPI already fetches and stores ALL printings for the card
-		magicCard, err := sb.InsertCardFromAPI(ctx, sampleCard)
-		if err != nil {
-			return nil, err
+		seen[*sampleCard.OracleID] = true
+
+		magicCard, ok := shared[*sampleCard.OracleID]
+		if !ok {
+			// InsertCardFromAPI already fetches and stores ALL printings for the card
+			magicCard, err = sb.InsertCardFromAPI(ctx, sampleCard)
+			if err != nil {
+				return nil, err
+			}
+			if shared != nil {
+				shared[*sampleCard.OracleID] = magicCard
+			}
 		}
 
 		magicCards = append(magicCards, magicCard)
-		oracleIDs = append(oracleIDs, oracleID)
+		oracleIDs = append(oracleIDs, *sampleCard.OracleID)
 	}
 
 	// Cache the query with oracle IDs from API fetch
@@ -193,6 +215,7 @@ func (sb *Scryball) findQuery(ctx context.Context, query string) ([]*MagicCard,
 
 // look for the card within the database, if not found will fetch from the scryfall API
 func (sb *Scryball) findCard(ctx context.Context, cardQuery string) (*MagicCard, error) {
+	cardQuery = sb.resolveAlias(ctx, cardQuery)
 
 	magicCard, err := sb.FetchCardByExactName(ctx, cardQuery)
 	if err == nil {
@@ -205,6 +228,9 @@ func (sb *Scryball) findCard(ctx context.Context, cardQuery string) (*MagicCard,
 	// card does not exist, fetch from API
 
 	apiCard, err := sb.client.QueryForSpecificCard(cardQuery)
+	if errors.Is(err, client.ErrNotFound) {
+		return nil, sb.cardNotFoundError(ctx, cardQuery)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -253,6 +279,8 @@ func (sb *Scryball) findCardOracleID(ctx context.Context, oracleID string) (*Mag
 //   - Cache misses make single API call per unique card
 //   - Each card fetched includes all printings across all sets
 //   - All results cached to prevent repeated API calls
+//   - Cards are in Scryfall's order, which honors order: and dir: in the query,
+//     for both fresh and cached results
 //
 // Returns:
 //   - []*MagicCard: Array of cards matching the query (empty array if no matches)
@@ -276,6 +304,8 @@ func Query(query string) ([]*MagicCard, error) {
 //   - Cache misses make single API call per unique card
 //   - Each card fetched includes all printings across all sets
 //   - All results cached to prevent repeated API calls
+//   - Cards are in Scryfall's order, which honors order: and dir: in the query,
+//     for both fresh and cached results
 //   - Respects context cancellation and timeouts
 //
 // Returns:
@@ -300,6 +330,8 @@ func QueryWithContext(ctx context.Context, query string) ([]*MagicCard, error) {
 //   - Cache misses make single API call per unique card
 //   - Each card fetched includes all printings across all sets
 //   - All results cached to prevent repeated API calls
+//   - Cards are in Scryfall's order, which honors order: and dir: in the query,
+//     for both fresh and cached results
 //
 // Returns:
 //   - []*MagicCard: Array of cards matching the query (empty array if no matches)
@@ -318,6 +350,8 @@ func (sb *Scryball) Query(query string) ([]*MagicCard, error) {
 //   - Cache misses make single API call per unique card
 //   - Each card fetched includes all printings across all sets
 //   - All results cached to prevent repeated API calls
+//   - Cards are in Scryfall's order, which honors order: and dir: in the query,
+//     for both fresh and cached results
 //   - Respects context cancellation and timeouts
 //
 // Returns:
@@ -329,6 +363,148 @@ func (sb *Scryball) QueryWithContext(ctx context.Context, query string) ([]*Magi
 	return sb.findQuery(ctx, query)
 }
 
+// QueryErrors maps each query of a QueryMany call that failed to its error.
+type QueryErrors map[string]error
+
+// Error lists every failed query, sorted.
+func (e QueryErrors) Error() string {
+	queries := make([]string, 0, len(e))
+	for query := range e {
+		queries = append(queries, query)
+	}
+	sort.Strings(queries)
+
+	messages := make([]string, len(queries))
+	for i, query := range queries {
+		messages[i] = fmt.Sprintf("query %q: %v", query, e[query])
+	}
+	return strings.Join(messages, "; ")
+}
+
+// QueryMany runs several queries like Query, for dashboards and bots that
+// load many searches at once.
+//
+// Behavior:
+//   - Queries run one after another, sharing the client's rate limit with every other call
+//   - Cards matched by several queries are fetched once and shared: the same
+//     *MagicCard appears in each result
+//   - Repeated queries run once
+//   - A failed query doesn't stop the others; queries not started when ctx
+//     is done fail with the context error
+//
+// Returns:
+//   - map[string][]*MagicCard: Cards of every query that succeeded, by query
+//   - error: nil if every query succeeded, otherwise QueryErrors with the error of each failed query
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+// Query syntax: https://scryfall.com/docs/syntax
+func QueryMany(ctx context.Context, queries []string) (map[string][]*MagicCard, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.QueryMany(ctx, queries)
+}
+
+// QueryMany runs several queries using this instance's database. See QueryMany.
+//
+// Example:
+//
+//	results, err := sb.QueryMany(ctx, []string{"t:goblin f:modern", "t:elf f:modern"})
+//	var failed scryball.QueryErrors
+//	if errors.As(err, &failed) {
+//		for query, err := range failed {
+//			log.Printf("%s: %v", query, err)
+//		}
+//	}
+//	goblins := results["t:goblin f:modern"]
+func (sb *Scryball) QueryMany(ctx context.Context, queries []string) (map[string][]*MagicCard, error) {
+	results := make(map[string][]*MagicCard, len(queries))
+	failed := QueryErrors{}
+	shared := map[string]*MagicCard{}
+
+	for _, query := range queries {
+		if _, ok := results[query]; ok {
+			continue
+		}
+		if _, ok := failed[query]; ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			failed[query] = err
+			continue
+		}
+
+		cards, err := sb.findQueryShared(ctx, query, shared)
+		if err != nil {
+			failed[query] = err
+			continue
+		}
+		results[query] = cards
+	}
+
+	if len(failed) > 0 {
+		return results, failed
+	}
+	return results, nil
+}
+
+// QueryCount returns how many cards match a query using Scryfall query syntax.
+//
+// Behavior:
+//   - Cached queries are counted with zero API calls
+//   - Otherwise makes a single API call for the first page of results,
+//     without following pagination or caching any cards
+//   - Counts unique cards like Query, so a query matching 0 cards returns 0
+//
+// Returns:
+//   - int: Number of cards matching the query
+//   - error: Network errors, API errors, or database errors
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+// Query syntax: https://scryfall.com/docs/syntax
+func QueryCount(query string) (int, error) {
+	return QueryCountWithContext(context.Background(), query)
+}
+
+// QueryCountWithContext returns how many cards match a query with context support.
+// See QueryCount.
+func QueryCountWithContext(ctx context.Context, query string) (int, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return 0, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.QueryCountWithContext(ctx, query)
+}
+
+// QueryCount returns how many cards match a query using this instance's database.
+// See QueryCount.
+func (sb *Scryball) QueryCount(query string) (int, error) {
+	return sb.QueryCountWithContext(context.Background(), query)
+}
+
+// QueryCountWithContext returns how many cards match a query using this
+// instance's database with context support. See QueryCount.
+func (sb *Scryball) QueryCountWithContext(ctx context.Context, query string) (int, error) {
+	queryCache, err := sb.queries.GetCachedQuery(ctx, query)
+	if err == nil {
+		var oracleIDs []string
+		if err := json.Unmarshal([]byte(queryCache.OracleIds), &oracleIDs); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal oracle IDs: %v", err)
+		}
+		return len(oracleIDs), nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to get cached query: %v", err)
+	}
+
+	count, err := sb.client.CountCards(query)
+	if errors.Is(err, client.ErrNotFound) {
+		return 0, nil
+	}
+	return count, err
+}
+
 // QueryCard fetches a single Magic card by exact name match.
 //
 // Behavior:
@@ -336,10 +512,11 @@ func (sb *Scryball) QueryWithContext(ctx context.Context, query string) ([]*Magi
 //   - Cache misses make single API call that fetches all printings
 //   - All card data cached for future requests
 //   - Name matching is case-insensitive but otherwise exact
+//   - Aliases (see Scryball.AddAlias) are replaced by their card names first
 //
 // Returns:
 //   - *MagicCard: The card with exact name match
-//   - error: Returns error if card not found, network issues, or database errors
+//   - error: *CardNotFoundError with suggestions if card not found, network issues, or database errors
 //
 // Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
 func QueryCard(cardQuery string) (*MagicCard, error) {
@@ -359,11 +536,12 @@ func QueryCard(cardQuery string) (*MagicCard, error) {
 //   - Cache misses make single API call that fetches all printings
 //   - All card data cached for future requests
 //   - Name matching is case-insensitive but otherwise exact
+//   - Aliases (see Scryball.AddAlias) are replaced by their card names first
 //   - Respects context cancellation and timeouts
 //
 // Returns:
 //   - *MagicCard: The card with exact name match
-//   - error: Returns error if card not found, context cancelled, or database errors
+//   - error: *CardNotFoundError with suggestions if card not found, context cancelled, or database errors
 //
 // Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
 func QueryCardWithContext(ctx context.Context, cardQuery string) (*MagicCard, error) {
@@ -381,10 +559,11 @@ func QueryCardWithContext(ctx context.Context, cardQuery string) (*MagicCard, er
 //   - Cache misses make single API call that fetches all printings
 //   - All card data cached for future requests
 //   - Name matching is case-insensitive but otherwise exact
+//   - Aliases (see Scryball.AddAlias) are replaced by their card names first
 //
 // Returns:
 //   - *MagicCard: The card with exact name match
-//   - error: Returns error if card not found, network issues, or database errors
+//   - error: *CardNotFoundError with suggestions if card not found, network issues, or database errors
 //
 // Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
 func (sb *Scryball) QueryCard(cardQuery string) (*MagicCard, error) {
@@ -399,11 +578,12 @@ func (sb *Scryball) QueryCard(cardQuery string) (*MagicCard, error) {
 //   - Cache misses make single API call that fetches all printings
 //   - All card data cached for future requests
 //   - Name matching is case-insensitive but otherwise exact (see scryfall docs)
+//   - Aliases (see Scryball.AddAlias) are replaced by their card names first
 //   - Respects context cancellation and timeouts
 //
 // Returns:
 //   - *MagicCard: The card with exact name match
-//   - error: Returns error if card not found, context cancelled, or database errors
+//   - error: *CardNotFoundError with suggestions if card not found, context cancelled, or database errors
 //
 // Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
 func (sb *Scryball) QueryCardWithContext(ctx context.Context, cardQuery string) (*MagicCard, error) {
@@ -269,8 +269,91 @@ LIMIT 1;
 -- Get a card by exact name
 -- name: GetCardByName :one
 SELECT oracle_id, name, layout, cmc, color_identity, colors, mana_cost, oracle_text, type_line, power, toughness
-FROM cards 
-WHERE LOWER(name) = LOWER(?) 
+FROM cards
+WHERE name = ? COLLATE NOCASE
+LIMIT 1;
+
+-- Get a card by the name of one of its faces, front faces first
+-- name: GetCardByFaceName :one
+SELECT oracle_id, name, layout, cmc, color_identity, colors, mana_cost, oracle_text, type_line, power, toughness
+FROM cards
+WHERE name LIKE ?1 || ' // %' ESCAPE '\'
+   OR name LIKE '% // ' || ?1 ESCAPE '\'
+   OR name LIKE '% // ' || ?1 || ' // %' ESCAPE '\'
+ORDER BY name NOT LIKE ?1 || ' // %' ESCAPE '\', name
+LIMIT 1;
+
+-- Get the name of the card a folded name belongs to, whole names before face names
+-- name: GetCardNameByFoldedName :one
+SELECT c.name
+FROM card_folded_names f
+JOIN cards c ON f.oracle_id = c.oracle_id
+WHERE f.folded_name = ?
+ORDER BY f.is_face, c.name
+LIMIT 1;
+
+-- Get the names of the cards with a folded name or face name in a range, for prefix matches
+-- name: ListCardNamesByFoldedRange :many
+SELECT DISTINCT c.name
+FROM card_folded_names f
+JOIN cards c ON f.oracle_id = c.oracle_id
+WHERE f.folded_name >= ? AND f.folded_name < ?
+ORDER BY c.name
+LIMIT ?;
+
+-- Remove the folded names of a card before storing its current ones
+-- name: DeleteCardFoldedNames :exec
+DELETE FROM card_folded_names WHERE oracle_id = ?;
+
+-- Store a folded name of a card
+-- name: InsertCardFoldedName :exec
+INSERT OR IGNORE INTO card_folded_names (folded_name, oracle_id, is_face)
+VALUES (?, ?, ?);
+
+-- Get the cards stored without folded names, by an older version or before the table existed
+-- name: ListCardsWithoutFoldedNames :many
+SELECT oracle_id, name
+FROM cards
+WHERE oracle_id NOT IN (SELECT oracle_id FROM card_folded_names);
+
+-- Get the name of every cached card
+-- name: ListCardNames :many
+SELECT name
+FROM cards
+ORDER BY name;
+
+-- Get the Oracle ID of every cached card
+-- name: ListOracleIDs :many
+SELECT oracle_id
+FROM cards
+ORDER BY name;
+
+-- List the face image URIs of every double-sided printing of a card
+-- name: ListPrintingFacesByOracleID :many
+SELECT pf.printing_id, pf.image_uris
+FROM printing_faces pf
+JOIN printings p ON p.id = pf.printing_id
+WHERE p.oracle_id = ?;
+
+-- Get the oracle-level card fields not covered by GetCardByOracleID
+-- name: GetCardDetailsByOracleID :one
+SELECT all_parts, card_faces, edhrec_rank, game_changer, keywords, legalities, penny_rank, reserved
+FROM cards
+WHERE oracle_id = ?
+LIMIT 1;
+
+-- Get the oracle_id of a printing by its MTG Arena id
+-- name: GetOracleIDByArenaID :one
+SELECT oracle_id
+FROM printings
+WHERE arena_id = ?
+LIMIT 1;
+
+-- Get the oracle_id of a printing by its Scryfall id
+-- name: GetOracleIDByPrintingID :one
+SELECT oracle_id
+FROM printings
+WHERE id = ?
 LIMIT 1;
 
 -- Get printings by oracle_id
@@ -286,7 +369,19 @@ SELECT
     artist,
     collector_number,
     released_at,
-    scryfall_uri
+    scryfall_uri,
+    mtgo_id,
+    prices,
+    card_back_id,
+    booster,
+    arena_id,
+    set_type,
+    (
+        SELECT ph.prices FROM price_history ph
+        WHERE ph.printing_id = printings.id
+        ORDER BY ph.history_id DESC
+        LIMIT 1
+    ) AS previous_prices
 FROM printings
 WHERE oracle_id = ?
 ORDER BY released_at DESC;
@@ -367,6 +462,14 @@ WHERE query_text = ?;
 DELETE FROM query_cache
 WHERE cached_at < ?;
 
+-- Count the rows of each cache table
+-- name: GetCacheCounts :one
+SELECT
+    (SELECT COUNT(*) FROM cards) AS cards,
+    (SELECT COUNT(*) FROM printings) AS printings,
+    (SELECT COUNT(*) FROM query_cache) AS queries,
+    (SELECT COUNT(*) FROM image_cache) AS images;
+
 -- Get query cache stats
 -- name: GetQueryCacheStats :one
 SELECT 
@@ -455,4 +558,188 @@ ON CONFLICT(id) DO UPDATE SET
     variation_of = excluded.variation_of,
     security_stamp = excluded.security_stamp,
     watermark = excluded.watermark,
-    preview = excluded.preview;
\ No newline at end of file
+    preview = excluded.preview;
+
+-- Insert or update the image URIs of each face of a double-sided printing
+-- name: UpsertPrintingFaces :exec
+INSERT INTO printing_faces (printing_id, image_uris)
+VALUES (?, ?)
+ON CONFLICT(printing_id) DO UPDATE SET image_uris = excluded.image_uris;
+
+-- Price Alert Operations
+
+-- Register a new price alert
+-- name: InsertPriceAlert :one
+INSERT INTO price_alerts (oracle_id, currency, threshold, direction)
+VALUES (?, ?, ?, ?)
+RETURNING alert_id;
+
+-- Get every registered price alert with its card name
+-- name: GetPriceAlerts :many
+SELECT 
+    pa.alert_id,
+    pa.oracle_id,
+    c.name,
+    pa.currency,
+    pa.threshold,
+    pa.direction,
+    pa.created_at,
+    pa.last_triggered_at
+FROM price_alerts pa
+JOIN cards c ON pa.oracle_id = c.oracle_id
+ORDER BY pa.alert_id;
+
+-- Remove a price alert
+-- name: DeletePriceAlert :exec
+DELETE FROM price_alerts WHERE alert_id = ?;
+
+-- Record that a price alert fired
+-- name: MarkPriceAlertTriggered :exec
+UPDATE price_alerts
+SET last_triggered_at = CURRENT_TIMESTAMP
+WHERE alert_id = ?;
+
+-- Re-arm a price alert once no printing is past its threshold
+-- name: ClearPriceAlertTriggered :exec
+UPDATE price_alerts
+SET last_triggered_at = NULL
+WHERE alert_id = ?;
+
+-- Get the cached prices of every printing of a card
+-- name: GetPrintingPricesByOracleID :many
+SELECT 
+    id,
+    "set" as set_code,
+    set_name,
+    collector_number,
+    prices
+FROM printings
+WHERE oracle_id = ?
+ORDER BY released_at DESC;
+
+-- Image Cache Operations
+
+-- Get a cached image by its URI
+-- name: GetCachedImage :one
+SELECT data
+FROM image_cache
+WHERE uri = ?;
+
+-- Store a downloaded image
+-- name: UpsertCachedImage :exec
+INSERT INTO image_cache (uri, data)
+VALUES (?, ?)
+ON CONFLICT(uri) DO UPDATE SET
+    data = excluded.data,
+    cached_at = CURRENT_TIMESTAMP;
+
+-- Card Alias Operations
+
+-- Add an alias or point an existing one to another card
+-- name: UpsertCardAlias :exec
+INSERT INTO card_aliases (alias, card_name)
+VALUES (?, ?)
+ON CONFLICT(alias) DO UPDATE SET
+    card_name = excluded.card_name;
+
+-- Get the card name of an alias, ignoring case
+-- name: GetCardAlias :one
+SELECT card_name
+FROM card_aliases
+WHERE alias = ?;
+
+-- Get every alias
+-- name: ListCardAliases :many
+SELECT alias, card_name
+FROM card_aliases
+ORDER BY alias;
+
+-- Remove an alias
+-- name: DeleteCardAlias :execrows
+DELETE FROM card_aliases WHERE alias = ?;
+
+-- Known Sets Operations
+
+-- List every set seen by CheckNewSets
+-- name: ListKnownSets :many
+SELECT code, released FROM known_sets;
+
+-- Insert or update a known set
+-- name: UpsertKnownSet :exec
+INSERT INTO known_sets (code, released)
+VALUES (?, ?)
+ON CONFLICT(code) DO UPDATE SET released = excluded.released;
+
+-- Get the icon URI of a set
+-- name: GetSetIconURI :one
+SELECT icon_uri FROM set_icons WHERE code = ?;
+
+-- Insert or update the icon URI of a set
+-- name: UpsertSetIcon :exec
+INSERT INTO set_icons (code, icon_uri)
+VALUES (?, ?)
+ON CONFLICT(code) DO UPDATE SET icon_uri = excluded.icon_uri;
+
+-- List the text of every cached query
+-- name: ListCachedQueryTexts :many
+SELECT query_text FROM query_cache ORDER BY query_text;
+
+-- Delete a cached query
+-- name: DeleteCachedQuery :execrows
+DELETE FROM query_cache WHERE query_text = ?;
+
+-- Card Errata Operations
+
+-- List the oracle text and type line changes recorded since a time, newest first
+-- name: ListCardErrata :many
+SELECT errata_id, oracle_id, name, old_oracle_text, new_oracle_text, old_type_line, new_type_line, changed_at
+FROM card_errata
+WHERE changed_at >= ?
+ORDER BY changed_at DESC, errata_id DESC;
+
+-- Booster Operations
+
+-- List the Oracle ID of every card with a cached booster printing in a set
+-- name: ListBoosterOracleIDsBySet :many
+SELECT DISTINCT oracle_id
+FROM printings
+WHERE "set" = ? AND booster = 1
+ORDER BY oracle_id;
+
+-- Deck Operations
+
+-- Create a saved deck, or update its comments and save time
+-- name: UpsertDeck :exec
+INSERT INTO decks (name, comments)
+VALUES (?, ?)
+ON CONFLICT(name) DO UPDATE SET
+    comments = excluded.comments,
+    saved_at = CURRENT_TIMESTAMP;
+
+-- Add a card to a saved deck
+-- name: InsertDeckCard :exec
+INSERT INTO deck_cards (deck_name, zone, position, oracle_id, quantity, set_code, collector_number)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- Remove every card of a saved deck
+-- name: DeleteDeckCards :exec
+DELETE FROM deck_cards WHERE deck_name = ?;
+
+-- Remove a saved deck
+-- name: DeleteDeck :execrows
+DELETE FROM decks WHERE name = ?;
+
+-- Get a saved deck
+-- name: GetDeck :one
+SELECT name, comments, saved_at FROM decks WHERE name = ?;
+
+-- Get the cards of a saved deck in the order they were saved
+-- name: GetDeckCards :many
+SELECT zone, oracle_id, quantity, set_code, collector_number
+FROM deck_cards
+WHERE deck_name = ?
+ORDER BY zone, position;
+
+-- List every saved deck
+-- name: ListDecks :many
+SELECT name, comments, saved_at FROM decks ORDER BY name;
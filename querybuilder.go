@@ -0,0 +1,206 @@
+package scryball
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Comparison is an operator comparing a card's value in a query, like mv<=3.
+type Comparison string
+
+const (
+	Eq Comparison = "="
+	Ne Comparison = "!="
+	Lt Comparison = "<"
+	Le Comparison = "<="
+	Gt Comparison = ">"
+	Ge Comparison = ">="
+)
+
+// QueryBuilder builds a query in Scryfall syntax term by term, quoting and
+// escaping values, for programs that construct queries dynamically.
+//
+// Every method adds a term and returns the builder, terms are all required.
+// Use Or and Not to combine builders.
+//
+// Example:
+//
+//	query := scryball.NewQuery().Color(scryball.ColorRed).Type("instant").CMC(scryball.Eq, 1).Rarity(scryball.RarityCommon).Build()
+//	// c:R t:instant mv=1 r:common
+//	cards, err := scryball.Query(query)
+type QueryBuilder struct {
+	terms []string
+}
+
+// NewQuery returns an empty QueryBuilder.
+func NewQuery() *QueryBuilder {
+	return &QueryBuilder{}
+}
+
+// Build returns the query. Check it with ValidateQuery when values come from users:
+// an empty value builds a term like t:"" which Scryfall rejects.
+func (q *QueryBuilder) Build() string {
+	return strings.Join(q.terms, " ")
+}
+
+// String returns the query, see Build.
+func (q *QueryBuilder) String() string {
+	return q.Build()
+}
+
+// add adds a term and returns the builder.
+func (q *QueryBuilder) add(term string) *QueryBuilder {
+	q.terms = append(q.terms, term)
+	return q
+}
+
+// Name matches cards whose name contains name, ignoring case.
+func (q *QueryBuilder) Name(name string) *QueryBuilder {
+	return q.add("name:" + quoteQueryValue(name))
+}
+
+// ExactName matches the card named name, ignoring case: !"Lightning Bolt".
+func (q *QueryBuilder) ExactName(name string) *QueryBuilder {
+	return q.add("!" + forceQuoteQueryValue(name))
+}
+
+// Color matches cards that are at least every one of colors. ColorColorless matches colorless cards.
+// Without colors no term is added, like Or.
+func (q *QueryBuilder) Color(colors ...Color) *QueryBuilder {
+	if len(colors) == 0 {
+		return q
+	}
+	return q.add("c:" + Colors(colors).String())
+}
+
+// ColorIdentity matches cards whose color identity fits within colors, like a commander's.
+// Without colors no term is added, use ColorColorless for colorless commanders.
+func (q *QueryBuilder) ColorIdentity(colors ...Color) *QueryBuilder {
+	if len(colors) == 0 {
+		return q
+	}
+	return q.add("id:" + Colors(colors).String())
+}
+
+// Type matches cards with typeName in their type line: "instant", "legendary", "elf".
+func (q *QueryBuilder) Type(typeName string) *QueryBuilder {
+	return q.add("t:" + quoteQueryValue(typeName))
+}
+
+// Oracle matches cards whose rules text contains text.
+func (q *QueryBuilder) Oracle(text string) *QueryBuilder {
+	return q.add("o:" + quoteQueryValue(text))
+}
+
+// Keyword matches cards with the keyword ability, like "flying".
+func (q *QueryBuilder) Keyword(keyword string) *QueryBuilder {
+	return q.add("keyword:" + quoteQueryValue(keyword))
+}
+
+// CMC compares cards' mana value.
+func (q *QueryBuilder) CMC(op Comparison, value float64) *QueryBuilder {
+	return q.add("mv" + string(op) + formatQueryNumber(value))
+}
+
+// Power compares creatures' power.
+func (q *QueryBuilder) Power(op Comparison, value float64) *QueryBuilder {
+	return q.add("pow" + string(op) + formatQueryNumber(value))
+}
+
+// Toughness compares creatures' toughness.
+func (q *QueryBuilder) Toughness(op Comparison, value float64) *QueryBuilder {
+	return q.add("tou" + string(op) + formatQueryNumber(value))
+}
+
+// Rarity matches cards printed at rarity.
+func (q *QueryBuilder) Rarity(rarity Rarity) *QueryBuilder {
+	return q.add("r:" + string(rarity))
+}
+
+// Set matches cards printed in the set with the code, like "neo".
+func (q *QueryBuilder) Set(code string) *QueryBuilder {
+	return q.add("e:" + quoteQueryValue(strings.ToLower(code)))
+}
+
+// SetType matches cards printed in sets of setType.
+func (q *QueryBuilder) SetType(setType SetType) *QueryBuilder {
+	return q.add("st:" + string(setType))
+}
+
+// Legal matches cards legal in format.
+func (q *QueryBuilder) Legal(format Format) *QueryBuilder {
+	return q.add("f:" + string(format))
+}
+
+// Is matches cards with a Scryfall is: property, like "reserved" or "commander".
+func (q *QueryBuilder) Is(property string) *QueryBuilder {
+	return q.add("is:" + quoteQueryValue(property))
+}
+
+// Raw adds a term in Scryfall syntax as it is, for keywords the builder has no method for.
+func (q *QueryBuilder) Raw(term string) *QueryBuilder {
+	return q.add(term)
+}
+
+// Or matches cards any one of queries matches. Empty queries are left out.
+//
+// Example:
+//
+//	redOrGreen := scryball.NewQuery().Type("creature").Or(
+//	    scryball.NewQuery().Color(scryball.ColorRed),
+//	    scryball.NewQuery().Color(scryball.ColorGreen),
+//	)
+//	// t:creature (c:R or c:G)
+func (q *QueryBuilder) Or(queries ...*QueryBuilder) *QueryBuilder {
+	var groups []string
+	for _, query := range queries {
+		if len(query.terms) > 0 {
+			groups = append(groups, query.group())
+		}
+	}
+	switch len(groups) {
+	case 0:
+		return q
+	case 1:
+		return q.add(groups[0])
+	}
+	return q.add("(" + strings.Join(groups, " or ") + ")")
+}
+
+// Not matches cards query does not match. An empty query is left out.
+func (q *QueryBuilder) Not(query *QueryBuilder) *QueryBuilder {
+	if len(query.terms) == 0 {
+		return q
+	}
+	return q.add("-" + query.group())
+}
+
+// group returns the query as one term, in parentheses if it has several.
+func (q *QueryBuilder) group() string {
+	if len(q.terms) == 1 {
+		return q.terms[0]
+	}
+	return "(" + q.Build() + ")"
+}
+
+// quoteQueryValue quotes value unless it is only letters and digits.
+func quoteQueryValue(value string) string {
+	if value != "" && strings.IndexFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) == -1 {
+		return value
+	}
+	return forceQuoteQueryValue(value)
+}
+
+// forceQuoteQueryValue puts value in double quotes, escaping the quotes and backslashes in it.
+func forceQuoteQueryValue(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// formatQueryNumber formats a number without trailing zeros: 1, 2.5.
+func formatQueryNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
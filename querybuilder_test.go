@@ -0,0 +1,43 @@
+package scryball
+
+import "testing"
+
+func TestQueryBuilder(t *testing.T) {
+	tests := []struct {
+		query *QueryBuilder
+		want  string
+	}{
+		{NewQuery().Color(ColorRed).Type("instant").CMC(Eq, 1).Rarity(RarityCommon), "c:R t:instant mv=1 r:common"},
+		{NewQuery().ColorIdentity(ColorGreen, ColorWhite, ColorBlue).Legal(FormatCommander).Power(Ge, 4.5), "id:WUG f:commander pow>=4.5"},
+		{NewQuery().Oracle("can't block").Keyword("first strike").Set("NEO"), `o:"can't block" keyword:"first strike" e:neo`},
+		{NewQuery().ExactName(`"Ach! Hans, Run!"`), `!"\"Ach! Hans, Run!\""`},
+		{NewQuery().Oracle(`a \ b`).Toughness(Lt, 2), `o:"a \\ b" tou<2`},
+		{NewQuery().Type("creature").Or(NewQuery().Color(ColorRed), NewQuery().Color(ColorGreen).CMC(Le, 2)), "t:creature (c:R or (c:G mv<=2))"},
+		{NewQuery().Type("land").Not(NewQuery().Is("reserved")).Not(NewQuery()), "t:land -is:reserved"},
+		{NewQuery().Name("bolt").SetType(SetTypeExpansion).Raw("order:released"), "name:bolt st:expansion order:released"},
+		{NewQuery().Or(NewQuery().Type("elf")), "t:elf"},
+		{NewQuery().Type("elf").Color().ColorIdentity(), "t:elf"},
+	}
+	for _, tt := range tests {
+		got := tt.query.Build()
+		if got != tt.want {
+			t.Errorf("Build() = %s, want %s", got, tt.want)
+		}
+		if err := ValidateQuery(got); err != nil {
+			t.Errorf("ValidateQuery(%s) = %v", got, err)
+		}
+	}
+
+	// values come back out of the parser unescaped
+	node, err := parseQuery(NewQuery().Oracle(`say "hi" \ bye`).Build())
+	if err != nil {
+		t.Fatalf("parseQuery failed: %v", err)
+	}
+	if node.term.value != `say "hi" \ bye` {
+		t.Errorf("Expected the escaped value back, got %q", node.term.value)
+	}
+
+	if err := ValidateQuery(NewQuery().Type("").Build()); err == nil {
+		t.Error("Expected ValidateQuery to reject an empty value")
+	}
+}
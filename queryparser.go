@@ -0,0 +1,312 @@
+package scryball
+
+import (
+	"fmt"
+	"strings"
+)
+
+// queryNode is a parsed Scryfall search: a single term, or terms combined
+// with and, or, and negation.
+type queryNode struct {
+	kind     queryNodeKind
+	children []*queryNode // operands of and, or and not
+	term     queryTerm    // the term of a queryTermNode
+}
+
+type queryNodeKind int
+
+const (
+	queryTermNode queryNodeKind = iota
+	queryAndNode
+	queryOrNode
+	queryNotNode
+)
+
+// queryTerm is a single search term like t:creature, cmc>=3 or a bare name word.
+type queryTerm struct {
+	key   string // lowercased keyword: "t", "cmc", ... "" for a name search
+	op    string // ":", "=", "!=", "<", "<=", ">" or ">="
+	value string // unquoted value
+	exact bool   // !"Card Name", an exact name search
+	regex bool   // o:/^draw/, value is a regular expression between slashes
+}
+
+func (t queryTerm) String() string {
+	if t.key == "" {
+		if t.exact {
+			return fmt.Sprintf("!%q", t.value)
+		}
+		return t.value
+	}
+	return t.key + t.op + t.value
+}
+
+// queryOps are the operators between a keyword and its value, longest first.
+var queryOps = []string{"!=", "<=", ">=", ":", "=", "<", ">"}
+
+// parseQuery parses a search in Scryfall syntax: terms separated by spaces are
+// all required, "or" between terms makes either enough, "-" negates a term or
+// a parenthesized group.
+func parseQuery(query string) (*queryNode, error) {
+	tokens, err := tokenizeQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in query %q", p.tokens[p.pos], query)
+	}
+	return node, nil
+}
+
+// tokenizeQuery splits a query into "(", ")", "-" before a group, and terms.
+// Quoted values and regular expressions keep their spaces: o:"draw a card"
+// and o:/draw (a|two) cards?/ are one token each.
+func tokenizeQuery(query string) ([]string, error) {
+	var tokens []string
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case r == ' ' || r == '\t' || r == '\n':
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '(':
+			tokens = append(tokens, "-")
+			i++
+		default:
+			start, quoted, regex := i, false, false
+			for i < len(runes) && (quoted || regex || (runes[i] != ' ' && runes[i] != '\t' && runes[i] != '\n' && runes[i] != ')')) {
+				switch {
+				case regex && runes[i] == '\\' && i+1 < len(runes):
+					i++ // escaped character, like \/ or \(
+				case regex && runes[i] == '/':
+					regex = false
+				case !quoted && runes[i] == '/' && i > start && strings.ContainsRune(":=<>", runes[i-1]):
+					// o:/^draw/ is a regular expression, which may contain spaces and parentheses
+					regex = true
+				case quoted && runes[i] == '\\' && i+1 < len(runes):
+					i++ // escaped character, like \" in o:"\"hi\""
+				case !regex && runes[i] == '"':
+					quoted = !quoted
+				}
+				i++
+			}
+			if quoted {
+				return nil, fmt.Errorf("unterminated quote in query %q", query)
+			}
+			if regex {
+				return nil, fmt.Errorf("unterminated regular expression in query %q", query)
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		}
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *queryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *queryParser) parseOr() (*queryNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	or := &queryNode{kind: queryOrNode, children: []*queryNode{node}}
+	for strings.EqualFold(p.peek(), "or") {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		or.children = append(or.children, next)
+	}
+	if len(or.children) == 1 {
+		return node, nil
+	}
+	return or, nil
+}
+
+func (p *queryParser) parseAnd() (*queryNode, error) {
+	and := &queryNode{kind: queryAndNode}
+	for {
+		token := p.peek()
+		if token == "" || token == ")" || strings.EqualFold(token, "or") {
+			break
+		}
+		if strings.EqualFold(token, "and") {
+			p.pos++
+			continue
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		and.children = append(and.children, node)
+	}
+
+	switch len(and.children) {
+	case 0:
+		if p.peek() == "" {
+			return nil, fmt.Errorf("query ends where a term was expected")
+		}
+		return nil, fmt.Errorf("expected a term before %q", p.peek())
+	case 1:
+		return and.children[0], nil
+	}
+	return and, nil
+}
+
+func (p *queryParser) parseUnary() (*queryNode, error) {
+	token := p.peek()
+	p.pos++
+	switch {
+	case token == "-":
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &queryNode{kind: queryNotNode, children: []*queryNode{node}}, nil
+	case token == "(":
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case strings.HasPrefix(token, "-") && len(token) > 1:
+		term, err := parseQueryTerm(token[1:])
+		if err != nil {
+			return nil, err
+		}
+		return &queryNode{kind: queryNotNode, children: []*queryNode{{kind: queryTermNode, term: term}}}, nil
+	}
+
+	term, err := parseQueryTerm(token)
+	if err != nil {
+		return nil, err
+	}
+	return &queryNode{kind: queryTermNode, term: term}, nil
+}
+
+// parseQueryTerm parses one term: key:value, key>=value, !"exact name" or a name word.
+func parseQueryTerm(token string) (queryTerm, error) {
+	if strings.HasPrefix(token, "!") {
+		return queryTerm{value: unquote(token[1:]), exact: true}, nil
+	}
+
+	// a keyword is letters only, anything else is part of a name
+	keyEnd := 0
+	for keyEnd < len(token) && (token[keyEnd] >= 'a' && token[keyEnd] <= 'z' || token[keyEnd] >= 'A' && token[keyEnd] <= 'Z') {
+		keyEnd++
+	}
+	if keyEnd > 0 {
+		for _, op := range queryOps {
+			if strings.HasPrefix(token[keyEnd:], op) {
+				raw := token[keyEnd+len(op):]
+				value := unquote(raw)
+				if value == "" {
+					return queryTerm{}, fmt.Errorf("missing value in %q", token)
+				}
+				regex := len(raw) >= 2 && strings.HasPrefix(raw, "/") && strings.HasSuffix(raw, "/")
+				return queryTerm{key: strings.ToLower(token[:keyEnd]), op: op, value: value, regex: regex}, nil
+			}
+		}
+	}
+	return queryTerm{value: unquote(token)}, nil
+}
+
+// unquote removes the double quotes around a value, "draw a card", and the
+// backslashes escaping quotes and backslashes within them.
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(value[1 : len(value)-1])
+	}
+	return value
+}
+
+// queryKeys are the keywords of Scryfall's search syntax, lowercased.
+// https://scryfall.com/docs/syntax
+var queryKeys = map[string]bool{
+	"c": true, "color": true, "id": true, "identity": true, "ci": true,
+	"t": true, "type": true, "o": true, "oracle": true, "fo": true, "fulloracle": true,
+	"k": true, "kw": true, "keyword": true, "m": true, "mana": true, "devotion": true, "produces": true,
+	"cmc": true, "mv": true, "manavalue": true, "pow": true, "power": true, "tou": true, "toughness": true,
+	"pt": true, "powtou": true, "loy": true, "loyalty": true,
+	"is": true, "not": true, "has": true, "include": true,
+	"r": true, "rarity": true, "new": true, "in": true,
+	"s": true, "set": true, "e": true, "edition": true, "st": true, "cn": true, "number": true, "b": true, "block": true,
+	"f": true, "format": true, "legal": true, "banned": true, "restricted": true,
+	"usd": true, "eur": true, "tix": true, "cheapest": true,
+	"a": true, "artist": true, "artists": true, "ft": true, "flavor": true, "wm": true, "watermark": true,
+	"illustrations": true, "border": true, "frame": true, "stamp": true, "game": true,
+	"year": true, "date": true, "prints": true, "sets": true, "paperprints": true, "papersets": true,
+	"art": true, "atag": true, "arttag": true, "function": true, "otag": true, "oracletag": true,
+	"name": true, "lang": true, "language": true, "oracleid": true, "cube": true,
+	"unique": true, "order": true, "direction": true, "dir": true, "display": true, "prefer": true,
+}
+
+// ValidateQuery checks a query in Scryfall syntax without sending it, so
+// mistakes are reported right away instead of as an API error.
+//
+// Behavior:
+//   - Reports unbalanced quotes, parentheses and regular expressions
+//   - Reports keywords Scryfall does not know, like tpye:creature
+//   - Reports keywords without a value, like t: or o:""
+//   - Never queries API: values are not checked, so e:xyz passes even if no such set exists
+//
+// Returns:
+//   - error: nil if the query is well formed, otherwise what is wrong with it
+//
+// Example:
+//
+//	if err := scryball.ValidateQuery(`t:creature (c:r or c:g`); err != nil {
+//	    fmt.Println(err) // invalid query: missing closing parenthesis
+//	}
+func ValidateQuery(query string) error {
+	node, err := parseQuery(query)
+	if err != nil {
+		return fmt.Errorf("invalid query: %v", err)
+	}
+	if err := node.validateKeys(); err != nil {
+		return fmt.Errorf("invalid query: %v", err)
+	}
+	return nil
+}
+
+// validateKeys reports the first term whose keyword is not in queryKeys.
+func (n *queryNode) validateKeys() error {
+	if n.kind == queryTermNode {
+		if n.term.key != "" && !queryKeys[n.term.key] {
+			return fmt.Errorf("unknown keyword %q in %s", n.term.key, n.term)
+		}
+		return nil
+	}
+	for _, child := range n.children {
+		if err := child.validateKeys(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
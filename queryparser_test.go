@@ -0,0 +1,45 @@
+package scryball
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateQuery(t *testing.T) {
+	valid := []string{
+		"t:creature",
+		`t:creature (c:r or c:g) -o:"can't block" cmc<=3`,
+		`!"Fire // Ice"`,
+		"oracleid:4457ed35-7c10-48c8-9776-456485fdf070 unique:prints",
+		`o:/\(\w+\)/ include:extras`,
+		"o:/draw (a|two) cards?/ f:modern",
+		"lightning bolt",
+	}
+	for _, query := range valid {
+		if err := ValidateQuery(query); err != nil {
+			t.Errorf("ValidateQuery(%q) = %v, want nil", query, err)
+		}
+	}
+
+	invalid := []struct {
+		query string
+		want  string
+	}{
+		{`o:"draw a card`, "unterminated quote"},
+		{"t:creature (c:r or c:g", "missing closing parenthesis"},
+		{"t:creature c:r)", `unexpected ")"`},
+		{"o:/draw", "unterminated regular expression"},
+		{"tpye:creature", `unknown keyword "tpye"`},
+		{"-(c:r or colour:g)", `unknown keyword "colour"`},
+		{"t: c:r", "missing value"},
+		{`o:""`, "missing value"},
+		{"", "empty query"},
+		{"t:elf or", "query ends where a term was expected"},
+	}
+	for _, tt := range invalid {
+		err := ValidateQuery(tt.query)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ValidateQuery(%q) = %v, want an error containing %q", tt.query, err, tt.want)
+		}
+	}
+}
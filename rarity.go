@@ -0,0 +1,51 @@
+package scryball
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Rarity is the rarity of a printing, as Scryfall names it.
+type Rarity string
+
+const (
+	RarityCommon   Rarity = "common"
+	RarityUncommon Rarity = "uncommon"
+	RarityRare     Rarity = "rare"
+	RaritySpecial  Rarity = "special" // Timeshifted and other out-of-set printings
+	RarityMythic   Rarity = "mythic"
+	RarityBonus    Rarity = "bonus" // Bonus sheet printings like The List
+)
+
+// rarityOrder is every rarity from lowest to highest.
+var rarityOrder = []Rarity{RarityCommon, RarityUncommon, RarityRare, RaritySpecial, RarityMythic, RarityBonus}
+
+// ParseRarity parses a rarity name ("Mythic", "rare") or its first letter ("m", "R").
+func ParseRarity(s string) (Rarity, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, rarity := range rarityOrder {
+		if s == string(rarity) || (len(s) == 1 && s[0] == rarity[0]) {
+			return rarity, nil
+		}
+	}
+	return "", fmt.Errorf("unknown rarity %q", s)
+}
+
+// Rank returns the position of the rarity from lowest to highest:
+// common 1, uncommon 2, rare 3, special 4, mythic 5, bonus 6. Unknown rarities are 0.
+func (r Rarity) Rank() int {
+	return slices.Index(rarityOrder, r) + 1
+}
+
+// Compare returns -1 if r is lower than other, 1 if it is higher and 0 if
+// they are the same, for use with slices.SortFunc.
+func (r Rarity) Compare(other Rarity) int {
+	return cmp.Compare(r.Rank(), other.Rank())
+}
+
+// AtLeast reports whether r is other or higher: RarityMythic.AtLeast(RarityRare) is true.
+func (r Rarity) AtLeast(other Rarity) bool {
+	return r.Rank() >= other.Rank() && other.Rank() > 0
+}
@@ -0,0 +1,32 @@
+package scryball
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRarityOrder(t *testing.T) {
+	rarities := []Rarity{RarityMythic, RarityCommon, RarityRare, RarityUncommon}
+	slices.SortFunc(rarities, Rarity.Compare)
+	if expected := []Rarity{RarityCommon, RarityUncommon, RarityRare, RarityMythic}; !slices.Equal(rarities, expected) {
+		t.Errorf("Expected %v, got %v", expected, rarities)
+	}
+
+	if !RarityMythic.AtLeast(RarityRare) || RarityUncommon.AtLeast(RarityRare) || !RarityRare.AtLeast(RarityRare) {
+		t.Error("Expected AtLeast to compare rarities")
+	}
+	if Rarity("unknown").Rank() != 0 || Rarity("unknown").AtLeast(RarityCommon) {
+		t.Error("Expected unknown rarities to rank below common")
+	}
+}
+
+func TestParseRarity(t *testing.T) {
+	for input, expected := range map[string]Rarity{"Mythic": RarityMythic, "r": RarityRare, " common ": RarityCommon, "U": RarityUncommon} {
+		if got, err := ParseRarity(input); err != nil || got != expected {
+			t.Errorf("ParseRarity(%q) = %q, %v, expected %q", input, got, err, expected)
+		}
+	}
+	if _, err := ParseRarity("legendary"); err == nil {
+		t.Error("Expected an error for an unknown rarity")
+	}
+}
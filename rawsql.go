@@ -0,0 +1,72 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// QueryRows runs a custom read-only SQL query against the cache and calls scan
+// with the result rows, for queries the Scryball methods don't cover.
+//
+// Behavior:
+//   - Only reads the database, never queries API
+//   - Statements that write (INSERT, UPDATE, DELETE, CREATE, ...) fail with an error,
+//     so the cache can't be corrupted by accident
+//   - rows are closed after scan returns, so scan must not keep them
+//   - scan must not call other Scryball methods, the connection is reserved until it returns
+//
+// The tables are described in schema.sql. The main ones are cards (one row per
+// Oracle ID), printings (one row per printing, joined on oracle_id) and
+// query_cache. Card fields holding lists, like colors and keywords, are JSON arrays.
+//
+// Returns:
+//   - error: SQL errors, errors from scan, or database errors
+//
+// Example:
+//
+//	err := sb.QueryRows(ctx, `SELECT set_name, COUNT(*) FROM printings GROUP BY set_name`,
+//	    func(rows *sql.Rows) error {
+//	        for rows.Next() {
+//	            var set string
+//	            var count int
+//	            if err := rows.Scan(&set, &count); err != nil {
+//	                return err
+//	            }
+//	            fmt.Println(set, count)
+//	        }
+//	        return rows.Err()
+//	    })
+func (s *Scryball) QueryRows(ctx context.Context, query string, scan func(rows *sql.Rows) error, args ...any) (err error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get database connection: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return fmt.Errorf("failed to make connection read-only: %v", err)
+	}
+	defer func() {
+		// The connection goes back to the pool, so it must be writable again.
+		// Discard it if that fails rather than break later writes.
+		if _, resetErr := conn.ExecContext(context.Background(), "PRAGMA query_only = OFF"); resetErr != nil {
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+			if err == nil {
+				err = fmt.Errorf("failed to make connection writable: %v", resetErr)
+			}
+		}
+	}()
+
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to run query: %v", err)
+	}
+	defer rows.Close()
+
+	if err := scan(rows); err != nil {
+		return err
+	}
+	return rows.Err()
+}
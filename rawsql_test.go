@@ -0,0 +1,49 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+func TestRawQueryRows(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-2", "Lightning Bolt", "Instant"))
+
+	var name string
+	var printings int
+	err := sb.QueryRows(ctx, `SELECT c.name, COUNT(*) FROM cards c JOIN printings p ON p.oracle_id = c.oracle_id
+		WHERE c.oracle_id = ? GROUP BY c.oracle_id`, func(rows *sql.Rows) error {
+		for rows.Next() {
+			if err := rows.Scan(&name, &printings); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, "bolt")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name != "Lightning Bolt" || printings != 2 {
+		t.Errorf("Expected Lightning Bolt with 2 printings, got %q with %d", name, printings)
+	}
+
+	err = sb.QueryRows(ctx, "DELETE FROM cards", func(rows *sql.Rows) error {
+		for rows.Next() {
+		}
+		return nil
+	})
+	if err == nil {
+		t.Error("Expected an error for a write")
+	}
+	if _, err := sb.FetchCardByExactName(ctx, "Lightning Bolt"); err != nil {
+		t.Errorf("Expected the card to survive the write, got %v", err)
+	}
+
+	// The connection is writable again for the cache.
+	insertTestCard(t, sb, testAPICard("ring", "ring-1", "Sol Ring", "Artifact"))
+}
@@ -0,0 +1,151 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+)
+
+// RelatedComponent is the role a related card plays, as Scryfall names it in all_parts.
+type RelatedComponent string
+
+const (
+	ComponentToken      RelatedComponent = "token"       // A token or emblem the card makes
+	ComponentMeldPart   RelatedComponent = "meld_part"   // One of the two cards that meld
+	ComponentMeldResult RelatedComponent = "meld_result" // The back face the meld parts form
+	ComponentComboPiece RelatedComponent = "combo_piece" // A card named by or naming the card
+)
+
+// RelatedCard is a card related to another one, resolved from Scryfall's all_parts.
+type RelatedCard struct {
+	Card      *MagicCard
+	Component RelatedComponent // Role of Card in the relationship
+}
+
+// RelatedCards resolves the card's related cards (Scryfall's all_parts) into
+// full MagicCards: the tokens it makes, the cards it melds with and into, and
+// the cards it names or is named by.
+//
+// Behavior:
+//   - Related cards are looked up in the cache first, then the API, and cached like any card
+//   - The card itself, which Scryfall lists among its own parts, is left out
+//   - Each related card is listed once, in the order Scryfall lists them
+//   - A nil sb uses the global Scryball instance
+//
+// Returns:
+//   - []RelatedCard: The related cards with their roles, empty if there are none
+//   - error: Network errors, API errors, or database errors
+//
+// Example:
+//
+//	bruna, _ := scryball.QueryCard("Bruna, the Fading Light")
+//	related, err := bruna.RelatedCards(ctx, nil)
+//	for _, part := range related {
+//		fmt.Println(part.Component, part.Card.Name) // meld_part Gisela, the Broken Blade ...
+//	}
+func (card *MagicCard) RelatedCards(ctx context.Context, sb *Scryball) ([]RelatedCard, error) {
+	if sb == nil {
+		var err error
+		sb, err = ensureCurrentScryball()
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize scryball %v", err)
+		}
+	}
+
+	related := []RelatedCard{}
+	if card.Card == nil {
+		return related, nil
+	}
+
+	ownPrintings := make(map[string]bool, len(card.Printings)+1)
+	ownPrintings[card.ID] = true
+	for _, printing := range card.Printings {
+		ownPrintings[printing.ID] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, part := range card.AllParts {
+		if ownPrintings[part.ID] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		relatedCard, err := sb.findCardByPrintingID(ctx, part.ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not get %s %s for %s: %v", part.Component, part.Name, card.Name, err)
+		}
+
+		// different printings of the same card share an oracle ID
+		key := relatedCard.Name
+		if relatedCard.OracleID != nil {
+			key = *relatedCard.OracleID
+			if card.OracleID != nil && key == *card.OracleID {
+				continue
+			}
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		related = append(related, RelatedCard{Card: relatedCard, Component: RelatedComponent(part.Component)})
+	}
+	return related, nil
+}
+
+// MeldPartner returns the card this card melds with, like Gisela, the Broken
+// Blade for Bruna, the Fading Light.
+//
+// Built on RelatedCards, see there for how cards are looked up; a nil sb uses the global Scryball instance.
+//
+// Returns:
+//   - *MagicCard: The other meld part
+//   - error: The card is not a meld part (melded back faces have no partner), or lookup errors
+func (card *MagicCard) MeldPartner(ctx context.Context, sb *Scryball) (*MagicCard, error) {
+	partner, _, err := card.meldPair(ctx, sb)
+	return partner, err
+}
+
+// MeldResult returns the card this card's meld pair forms, like Brisela,
+// Voice of Nightmares for Bruna, the Fading Light or Gisela, the Broken Blade.
+//
+// Built on RelatedCards, see there for how cards are looked up; a nil sb uses the global Scryball instance.
+//
+// Returns:
+//   - *MagicCard: The melded back face
+//   - error: The card is not a meld part, or lookup errors
+//
+// Example:
+//
+//	gisela, _ := bruna.MeldPartner(ctx, nil)
+//	brisela, _ := bruna.MeldResult(ctx, nil)
+//	fmt.Printf("%s + %s = %s\n", bruna.Name, gisela.Name, brisela.Name)
+func (card *MagicCard) MeldResult(ctx context.Context, sb *Scryball) (*MagicCard, error) {
+	_, result, err := card.meldPair(ctx, sb)
+	return result, err
+}
+
+// meldPair returns the meld partner and meld result of a meld part.
+func (card *MagicCard) meldPair(ctx context.Context, sb *Scryball) (partner, result *MagicCard, err error) {
+	related, err := card.RelatedCards(ctx, sb)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, part := range related {
+		switch part.Component {
+		case ComponentMeldPart:
+			if partner == nil {
+				partner = part.Card
+			}
+		case ComponentMeldResult:
+			if result == nil {
+				result = part.Card
+			}
+		}
+	}
+	// a melded back face lists both parts and no result
+	if partner == nil || result == nil {
+		return nil, nil, fmt.Errorf("%s is not a meld part", card.Name)
+	}
+	return partner, result, nil
+}
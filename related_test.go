@@ -0,0 +1,67 @@
+package scryball
+
+import (
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestRelatedCards(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := t.Context()
+
+	meld := []client.RelatedCard{
+		{ID: "bruna-1", Component: "meld_part", Name: "Bruna, the Fading Light"},
+		{ID: "gisela-1", Component: "meld_part", Name: "Gisela, the Broken Blade"},
+		{ID: "brisela-1", Component: "meld_result", Name: "Brisela, Voice of Nightmares"},
+		{ID: "spirit-1", Component: "token", Name: "Spirit"},
+		{ID: "spirit-2", Component: "token", Name: "Spirit"},
+	}
+	insertTestCard(t, sb, testAPICard("gisela", "gisela-1", "Gisela, the Broken Blade", "Legendary Creature — Angel Horror"))
+	insertTestCard(t, sb, testAPICard("brisela", "brisela-1", "Brisela, Voice of Nightmares", "Legendary Creature — Eldrazi Angel"))
+	insertTestCard(t, sb, testAPICard("spirit", "spirit-1", "Spirit", "Token Creature — Spirit"),
+		testAPICard("spirit", "spirit-2", "Spirit", "Token Creature — Spirit"))
+
+	bruna := testAPICard("bruna", "bruna-1", "Bruna, the Fading Light", "Legendary Creature — Angel Horror")
+	bruna.AllParts = meld
+	card := insertTestCard(t, sb, bruna)
+
+	related, err := card.RelatedCards(ctx, sb)
+	if err != nil {
+		t.Fatalf("RelatedCards failed: %v", err)
+	}
+	want := []RelatedCard{
+		{Component: ComponentMeldPart, Card: &MagicCard{Card: &client.Card{Name: "Gisela, the Broken Blade"}}},
+		{Component: ComponentMeldResult, Card: &MagicCard{Card: &client.Card{Name: "Brisela, Voice of Nightmares"}}},
+		{Component: ComponentToken, Card: &MagicCard{Card: &client.Card{Name: "Spirit"}}},
+	}
+	if len(related) != len(want) {
+		t.Fatalf("Expected %d related cards, got %d", len(want), len(related))
+	}
+	for i, part := range related {
+		if part.Component != want[i].Component || part.Card.Name != want[i].Card.Name {
+			t.Errorf("related[%d] = %s %s, want %s %s", i, part.Component, part.Card.Name, want[i].Component, want[i].Card.Name)
+		}
+	}
+
+	partner, err := card.MeldPartner(ctx, sb)
+	if err != nil || partner.Name != "Gisela, the Broken Blade" {
+		t.Errorf("MeldPartner() = %v, %v, want Gisela", partner, err)
+	}
+	result, err := card.MeldResult(ctx, sb)
+	if err != nil || result.Name != "Brisela, Voice of Nightmares" {
+		t.Errorf("MeldResult() = %v, %v, want Brisela", result, err)
+	}
+
+	bolt := insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+	if _, err := bolt.MeldPartner(ctx, sb); err == nil {
+		t.Error("Expected an error for the meld partner of a card that does not meld")
+	}
+	if _, err := bolt.MeldResult(ctx, sb); err == nil {
+		t.Error("Expected an error for the meld result of a card that does not meld")
+	}
+	if related, err := bolt.RelatedCards(ctx, sb); err != nil || len(related) != 0 {
+		t.Errorf("Expected no related cards, got %v (err %v)", related, err)
+	}
+}
@@ -0,0 +1,260 @@
+package scryball
+
+import (
+	"fmt"
+	"html/template"
+	"slices"
+	"strings"
+)
+
+// ReportFormat is the markup Decklist.Report renders in.
+type ReportFormat int
+
+const (
+	ReportMarkdown ReportFormat = iota // GitHub-flavored Markdown, for forums, chats and READMEs
+	ReportHTML                         // A standalone HTML page
+)
+
+// reportCurrency is the currency deck reports are priced in.
+const reportCurrency = "usd"
+
+// deckReport is the content of a report, shared by every ReportFormat.
+type deckReport struct {
+	Title      string
+	Comments   string
+	Stats      DeckStats
+	Commanders reportGroup
+	Companion  reportGroup
+	Groups     []reportGroup // Maindeck by type, in typeGroups order
+	Sideboard  reportGroup
+	Curve      string
+	Colors     []reportColor
+	Price      Price
+	Unpriced   []string
+	Formats    []reportFormat
+}
+
+type reportLine struct {
+	Quantity int
+	Name     string
+}
+
+type reportGroup struct {
+	Name  string
+	Count int
+	Lines []reportLine
+}
+
+type reportColor struct {
+	Color string
+	Cards int
+	Pips  int
+}
+
+type reportFormat struct {
+	Format string
+	Status string // "Legal", "Not legal" or "Unknown" without legality data
+}
+
+// Report renders a shareable summary of the decklist: the cards by type, the
+// mana curve, the color breakdown, the price and the legality in every format.
+//
+// Behavior:
+//   - Uses the card data already on each MagicCard, never queries API
+//   - Statistics come from Stats, legality from LegalFormats and ValidateFormat
+//   - Priced in USD at cached prices, see MagicCard.Price; unpriced cards are listed
+//   - Formats whose legality some card has no data for are reported as "Unknown"
+//
+// Returns:
+//   - string: The report in format
+//   - error: Unknown format, or template errors
+//
+// Example:
+//
+//	report, _ := deck.Report(scryball.ReportMarkdown)
+//	os.WriteFile("deck.md", []byte(report), 0644)
+func (d *Decklist) Report(format ReportFormat) (string, error) {
+	report := d.report()
+	switch format {
+	case ReportMarkdown:
+		return report.markdown(), nil
+	case ReportHTML:
+		var b strings.Builder
+		if err := reportTemplate.Execute(&b, report); err != nil {
+			return "", fmt.Errorf("failed to render report: %v", err)
+		}
+		return b.String(), nil
+	}
+	return "", fmt.Errorf("unknown report format %d", format)
+}
+
+// report gathers the content of a report.
+func (d *Decklist) report() deckReport {
+	report := deckReport{
+		Title:    d.Name,
+		Comments: d.Comments,
+		Stats:    d.Stats(),
+		Unpriced: []string{},
+
+		Commanders: reportGroup{Name: "Commander"},
+		Companion:  reportGroup{Name: "Companion"},
+		Sideboard:  reportGroup{Name: "Sideboard"},
+	}
+	if report.Title == "" {
+		report.Title = "Decklist"
+	}
+	report.Curve = report.Stats.Curve.RenderASCII(30)
+
+	price := func(card *MagicCard, qty int) {
+		if cardPrice, ok := card.Price(reportCurrency); ok {
+			report.Price += cardPrice * Price(qty)
+		} else if !slices.Contains(report.Unpriced, card.Name) {
+			report.Unpriced = append(report.Unpriced, card.Name)
+		}
+	}
+
+	for _, card := range d.Commanders {
+		report.Commanders.Count++
+		report.Commanders.Lines = append(report.Commanders.Lines, reportLine{1, card.Name})
+		price(card, 1)
+	}
+	if d.Companion != nil {
+		report.Companion.Count = 1
+		report.Companion.Lines = []reportLine{{1, d.Companion.Name}}
+		price(d.Companion, 1)
+	}
+
+	groups := d.GroupByType()
+	for _, name := range append(slices.Clone(typeGroups), "Other") {
+		group := reportGroup{Name: name}
+		for _, card := range sortedCards(groups[name]) {
+			qty := groups[name][card]
+			group.Count += qty
+			group.Lines = append(group.Lines, reportLine{qty, card.Name})
+			price(card, qty)
+		}
+		if group.Count > 0 {
+			report.Groups = append(report.Groups, group)
+		}
+	}
+
+	for _, card := range sortedCards(d.Sideboard) {
+		qty := d.Sideboard[card]
+		report.Sideboard.Count += qty
+		report.Sideboard.Lines = append(report.Sideboard.Lines, reportLine{qty, card.Name})
+		price(card, qty)
+	}
+
+	for _, color := range []string{"W", "U", "B", "R", "G", "C"} {
+		if cards, pips := report.Stats.Colors[color], report.Stats.ColorPips[color]; cards > 0 || pips > 0 {
+			report.Colors = append(report.Colors, reportColor{color, cards, pips})
+		}
+	}
+
+	legal := d.LegalFormats()
+	for _, format := range sortedFormatNames() {
+		status := "Not legal"
+		switch {
+		case slices.Contains(legal, format):
+			status = "Legal"
+		case !d.hasLegalityData(format):
+			status = "Unknown"
+		}
+		report.Formats = append(report.Formats, reportFormat{format, status})
+	}
+	return report
+}
+
+// sortedFormatNames returns the name of every format LegalFormats checks, sorted.
+func sortedFormatNames() []string {
+	names := make([]string, 0, len(formats))
+	for format := range formats {
+		names = append(names, format)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// markdown renders the report as Markdown.
+func (r deckReport) markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", r.Title)
+	if r.Comments != "" {
+		fmt.Fprintf(&b, "%s\n\n", r.Comments)
+	}
+	fmt.Fprintf(&b, "%d cards, %d lands, average mana value %.2f, $%s\n\n",
+		r.Stats.Cards, r.Stats.Lands, r.Stats.AverageManaValue, r.Price)
+
+	groups := append([]reportGroup{r.Commanders, r.Companion}, r.Groups...)
+	for _, group := range append(groups, r.Sideboard) {
+		if len(group.Lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "## %s (%d)\n\n", group.Name, group.Count)
+		for _, line := range group.Lines {
+			fmt.Fprintf(&b, "- %d %s\n", line.Quantity, line.Name)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "## Mana Curve\n\n```\n%s```\n\n", r.Curve)
+
+	if len(r.Colors) > 0 {
+		b.WriteString("## Colors\n\n| Color | Cards | Pips |\n| --- | ---: | ---: |\n")
+		for _, color := range r.Colors {
+			fmt.Fprintf(&b, "| %s | %d | %d |\n", color.Color, color.Cards, color.Pips)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "## Price\n\n$%s", r.Price)
+	if len(r.Unpriced) > 0 {
+		fmt.Fprintf(&b, ", without %s", strings.Join(r.Unpriced, ", "))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("## Legality\n\n| Format | Status |\n| --- | --- |\n")
+	for _, format := range r.Formats {
+		fmt.Fprintf(&b, "| %s | %s |\n", format.Format, format.Status)
+	}
+	return b.String()
+}
+
+var reportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Comments}}<p>{{.Comments}}</p>
+{{end -}}
+<p>{{.Stats.Cards}} cards, {{.Stats.Lands}} lands, average mana value {{printf "%.2f" .Stats.AverageManaValue}}, ${{.Price}}</p>
+{{define "section"}}{{if .Lines}}<h2>{{.Name}} ({{.Count}})</h2>
+<ul>
+{{range .Lines}}<li>{{.Quantity}} {{.Name}}</li>
+{{end}}</ul>
+{{end}}{{end -}}
+{{template "section" .Commanders}}
+{{- template "section" .Companion}}
+{{- range .Groups}}{{template "section" .}}{{end}}
+{{- template "section" .Sideboard -}}
+<h2>Mana Curve</h2>
+<pre>{{.Curve}}</pre>
+{{if .Colors}}<h2>Colors</h2>
+<table>
+<tr><th>Color</th><th>Cards</th><th>Pips</th></tr>
+{{range .Colors}}<tr><td>{{.Color}}</td><td>{{.Cards}}</td><td>{{.Pips}}</td></tr>
+{{end}}</table>
+{{end -}}
+<h2>Price</h2>
+<p>${{.Price}}{{if .Unpriced}}, without {{range $i, $name := .Unpriced}}{{if $i}}, {{end}}{{$name}}{{end}}{{end}}</p>
+<h2>Legality</h2>
+<table>
+<tr><th>Format</th><th>Status</th></tr>
+{{range .Formats}}<tr><td>{{.Format}}</td><td>{{.Status}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,65 @@
+package scryball
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecklistReport(t *testing.T) {
+	price := func(s string) *string { return &s }
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	bolt.CMC, bolt.ManaCost, bolt.Colors = 1, price("{R}"), []string{"R"}
+	bolt.Prices["usd"] = price("1.25")
+	bolt.Legalities = map[string]string{"modern": "legal", "standard": "not_legal"}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	mountain.Legalities = map[string]string{"modern": "legal", "standard": "legal"}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast & <Friends>", "Instant")}
+	pyro.Legalities = map[string]string{"modern": "legal", "standard": "not_legal"}
+
+	deck := NewDecklist()
+	deck.Name = "Burn"
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 56)
+	deck.AddSideboardCard(pyro, 3)
+
+	markdown, err := deck.Report(ReportMarkdown)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"# Burn\n",
+		"60 cards, 56 lands, average mana value 1.00, $5.00",
+		"## Land (56)\n\n- 56 Mountain\n",
+		"## Instant (4)\n\n- 4 Lightning Bolt\n",
+		"## Sideboard (3)\n\n- 3 Pyroblast & <Friends>\n",
+		"1  | #### 4\n",
+		"| R | 4 | 4 |",
+		"$5.00, without Mountain, Pyroblast & <Friends>",
+		"| modern | Legal |",
+		"| standard | Not legal |",
+		"| vintage | Unknown |",
+	} {
+		if !strings.Contains(markdown, expected) {
+			t.Errorf("Expected the Markdown report to contain %q, got:\n%s", expected, markdown)
+		}
+	}
+
+	html, err := deck.Report(ReportHTML)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"<title>Burn</title>",
+		"<h2>Instant (4)</h2>",
+		"<li>3 Pyroblast &amp; &lt;Friends&gt;</li>",
+		"<tr><td>modern</td><td>Legal</td></tr>",
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected the HTML report to contain %q, got:\n%s", expected, html)
+		}
+	}
+
+	if _, err := deck.Report(ReportFormat(99)); err == nil {
+		t.Error("Expected an error for an unknown report format")
+	}
+}
@@ -0,0 +1,97 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// NewPrinting is a printing CheckNewPrintings found for a cached card.
+type NewPrinting struct {
+	Card     *MagicCard // The card, with the new printing loaded
+	Printing Printing   // The new printing
+}
+
+// CheckNewPrintings fetches the printings of every cached card again and
+// caches and reports the ones added since they were cached, for collectors and
+// price tools following reprint announcements.
+//
+// Behavior:
+//   - Makes one API call per cached card, so checking a large cache takes
+//     minutes: 1000 cards take over 100 seconds at Scryfall's rate limit
+//   - Printings already cached are not reported, so each new printing is reported once
+//   - Stops at the first failure or when ctx is done, keeping the printings
+//     found so far cached and returning them with the error
+//
+// Returns:
+//   - []NewPrinting: New printings, by card name (empty if none)
+//   - error: Network errors, API errors, database errors, or the context error
+//
+// Example:
+//
+//	reprints, _ := sb.CheckNewPrintings(ctx)
+//	for _, reprint := range reprints {
+//	    fmt.Printf("%s reprinted in %s\n", reprint.Card.Name, reprint.Printing.SetName)
+//	}
+func (s *Scryball) CheckNewPrintings(ctx context.Context) ([]NewPrinting, error) {
+	oracleIDs, err := s.queries.ListOracleIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached cards: %v", err)
+	}
+
+	found := []NewPrinting{}
+	for _, oracleID := range oracleIDs {
+		if err := ctx.Err(); err != nil {
+			return found, err
+		}
+		reprints, err := s.checkNewPrintings(ctx, oracleID)
+		if err != nil {
+			return found, err
+		}
+		found = append(found, reprints...)
+	}
+	return found, nil
+}
+
+// checkNewPrintings caches and returns the printings of one card that are not cached yet.
+func (s *Scryball) checkNewPrintings(ctx context.Context, oracleID string) ([]NewPrinting, error) {
+	card, err := s.FetchCardByExactOracleID(ctx, oracleID)
+	if err != nil {
+		return nil, err
+	}
+	cached := make(map[string]bool, len(card.Printings))
+	for _, printing := range card.Printings {
+		cached[printing.ID] = true
+	}
+
+	printings, err := s.client.QueryForCards(fmt.Sprintf("oracleid:%s unique:prints", oracleID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch printings of %s: %v", card.Name, err)
+	}
+	var added []*client.Card
+	for i := range printings {
+		if printings[i].OracleID != nil && !cached[printings[i].ID] {
+			added = append(added, &printings[i])
+		}
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	if err := s.upsertAPICards(ctx, added); err != nil {
+		return nil, err
+	}
+	card, err = s.FetchCardByExactOracleID(ctx, oracleID)
+	if err != nil {
+		return nil, err
+	}
+
+	reprints := make([]NewPrinting, 0, len(added))
+	for _, printing := range card.Printings {
+		if !cached[printing.ID] {
+			reprints = append(reprints, NewPrinting{Card: card, Printing: printing})
+		}
+	}
+	return reprints, nil
+}
@@ -0,0 +1,39 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCheckNewPrintings(t *testing.T) {
+	printing := func(id, set string) string {
+		return fmt.Sprintf(`{"object": "card", "id": %q, "oracle_id": "bolt", "name": "Lightning Bolt", "lang": "en",
+			"type_line": "Instant", "set": %q, "set_name": "Set %s", "rarity": "common", "released_at": "2020-01-01"}`, id, set, set)
+	}
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "oracleid:bolt unique:prints" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s, %s]}`, printing("bolt-1", "tst"), printing("bolt-2", "new"))
+	})
+	ctx := context.Background()
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+
+	reprints, err := sb.CheckNewPrintings(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(reprints) != 1 || reprints[0].Printing.ID != "bolt-2" || reprints[0].Printing.SetCode != "new" {
+		t.Fatalf("Expected the printing bolt-2 in new, got %+v", reprints)
+	}
+	if len(reprints[0].Card.Printings) != 2 {
+		t.Errorf("Expected the card with 2 printings, got %d", len(reprints[0].Card.Printings))
+	}
+
+	if reprints, err := sb.CheckNewPrintings(ctx); err != nil || len(reprints) != 0 {
+		t.Errorf("Expected no new printings on the second check, got %+v, %v", reprints, err)
+	}
+}
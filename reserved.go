@@ -0,0 +1,61 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+)
+
+// reservedListQuery is the Scryfall search for every Reserved List card.
+const reservedListQuery = "is:reserved"
+
+// IsReserved reports whether the card is on the Reserved List, the cards
+// Wizards of the Coast has promised never to reprint.
+func (card *MagicCard) IsReserved() bool {
+	return card.Card != nil && card.Reserved
+}
+
+// FilterReserved returns the cards on the Reserved List, in their original order.
+//
+// Example:
+//
+//	collection, _ := scryball.Query("s:leg")
+//	for _, card := range scryball.FilterReserved(collection) {
+//	    fmt.Println(card.Name)
+//	}
+func FilterReserved(cards []*MagicCard) []*MagicCard {
+	return Filter(cards, (*MagicCard).IsReserved)
+}
+
+// ReservedList returns every card on the Reserved List.
+//
+// Behavior:
+//   - Runs the query "is:reserved" like Query, so after the first call the
+//     list is served from the cache with zero API calls
+//   - The first call fetches every Reserved List card, over 500 cards
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func ReservedList() ([]*MagicCard, error) {
+	return ReservedListWithContext(context.Background())
+}
+
+// ReservedListWithContext returns every card on the Reserved List with context support.
+// See ReservedList.
+func ReservedListWithContext(ctx context.Context) ([]*MagicCard, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.ReservedListWithContext(ctx)
+}
+
+// ReservedList returns every card on the Reserved List using this instance's database.
+// See ReservedList.
+func (sb *Scryball) ReservedList() ([]*MagicCard, error) {
+	return sb.ReservedListWithContext(context.Background())
+}
+
+// ReservedListWithContext returns every card on the Reserved List using this
+// instance's database with context support. See ReservedList.
+func (sb *Scryball) ReservedListWithContext(ctx context.Context) ([]*MagicCard, error) {
+	return sb.findQuery(ctx, reservedListQuery)
+}
@@ -0,0 +1,31 @@
+package scryball
+
+import "testing"
+
+func TestReservedList(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	lotus := testAPICard("lotus", "lotus-1", "Black Lotus", "Artifact")
+	lotus.Reserved = true
+	bolt := testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant")
+	cards := []*MagicCard{insertTestCard(t, sb, lotus), insertTestCard(t, sb, bolt)}
+
+	if !cards[0].IsReserved() || cards[1].IsReserved() {
+		t.Error("Expected the Reserved List flag to be loaded from the cache")
+	}
+	if reserved := FilterReserved(cards); len(reserved) != 1 || reserved[0].Name != "Black Lotus" {
+		t.Errorf("Expected only Black Lotus, got %v", reserved)
+	}
+
+	if err := sb.cacheQuery(t.Context(), reservedListQuery, []string{"lotus"}); err != nil {
+		t.Fatalf("cacheQuery failed: %v", err)
+	}
+	list, err := sb.ReservedListWithContext(t.Context())
+	if err != nil {
+		t.Fatalf("ReservedList failed: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != "Black Lotus" {
+		t.Errorf("Expected the cached Reserved List, got %v", list)
+	}
+}
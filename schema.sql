@@ -1,5 +1,8 @@
 -- Normalized schema with Cards (oracle-level) and Printings (printing-level) tables
 
+-- The schema version is schemaVersion in dbinfo.go, stored as PRAGMA user_version
+-- when a database is created or upgraded. Bump it with every change to this file.
+
 -- Cards table: One row per unique card (oracle_id level)
 CREATE TABLE IF NOT EXISTS cards (
     oracle_id TEXT PRIMARY KEY NOT NULL, -- Shared across all printings of the same card
@@ -107,6 +110,7 @@ CREATE TABLE IF NOT EXISTS printings (
 
 -- Indexes for Cards table
 CREATE INDEX IF NOT EXISTS idx_cards_name ON cards(name);
+CREATE INDEX IF NOT EXISTS idx_cards_name_nocase ON cards(name COLLATE NOCASE); -- Case-insensitive name lookups
 
 -- Indexes for Printings table
 CREATE INDEX IF NOT EXISTS idx_printings_oracle_id ON printings(oracle_id);
@@ -128,3 +132,189 @@ CREATE TABLE IF NOT EXISTS query_cache (
 CREATE INDEX IF NOT EXISTS idx_query_cache_query_text ON query_cache(query_text);
 CREATE INDEX IF NOT EXISTS idx_query_cache_cached_at ON query_cache(cached_at);
 CREATE INDEX IF NOT EXISTS idx_query_cache_last_accessed ON query_cache(last_accessed);
+
+-- Price Alerts table: User-registered price thresholds evaluated against cached printing prices
+CREATE TABLE IF NOT EXISTS price_alerts (
+    alert_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    oracle_id TEXT NOT NULL, -- Any printing of this card can trigger the alert
+    currency TEXT NOT NULL, -- Key into printings.prices (usd, usd_foil, eur, tix, ...)
+    threshold REAL NOT NULL,
+    direction TEXT NOT NULL, -- "below" or "above"
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_triggered_at TEXT,
+
+    FOREIGN KEY (oracle_id) REFERENCES cards(oracle_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_alerts_oracle_id ON price_alerts(oracle_id);
+
+-- Printing Faces table: Image URIs of each face of double-sided printings, which have no image_uris of their own
+CREATE TABLE IF NOT EXISTS printing_faces (
+    printing_id TEXT PRIMARY KEY NOT NULL,
+    image_uris TEXT NOT NULL -- JSON array of map[string]string, front face first, as Scryfall lists them with their version query string
+);
+
+-- Card Folded Names table: Names of cards and of their faces folded like foldCardName in names.go,
+-- so names are matched ignoring accents, quotes and punctuation without folding every cached name
+CREATE TABLE IF NOT EXISTS card_folded_names (
+    folded_name TEXT NOT NULL, -- "lim duls vault", "fire // ice", "fire"
+    oracle_id TEXT NOT NULL,
+    is_face BOOLEAN NOT NULL, -- Name of a single face of a split or double-faced card
+
+    PRIMARY KEY (folded_name, oracle_id),
+    FOREIGN KEY (oracle_id) REFERENCES cards(oracle_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_folded_names_oracle_id ON card_folded_names(oracle_id);
+
+-- Image Cache table: Downloaded card images, keyed by their Scryfall image URI
+CREATE TABLE IF NOT EXISTS image_cache (
+    uri TEXT PRIMARY KEY, -- Full image URI, including Scryfall's version query string
+    data BLOB NOT NULL,
+    cached_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Card Text Search: FTS5 index of card names and rules text, kept in sync by the triggers below.
+-- rowid is the rowid of the card, oracle_text includes the text of every card face
+CREATE VIRTUAL TABLE IF NOT EXISTS cards_fts USING fts5(
+    name,
+    oracle_text,
+    tokenize = 'porter unicode61'
+);
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_insert AFTER INSERT ON cards BEGIN
+    INSERT INTO cards_fts (rowid, name, oracle_text) VALUES (
+        new.rowid,
+        new.name,
+        COALESCE(new.oracle_text, '') || COALESCE((
+            SELECT char(10) || group_concat(json_extract(f.value, '$.oracle_text'), char(10))
+            FROM json_each(new.card_faces) f
+        ), '')
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_update AFTER UPDATE ON cards BEGIN
+    DELETE FROM cards_fts WHERE rowid = old.rowid;
+    INSERT INTO cards_fts (rowid, name, oracle_text) VALUES (
+        new.rowid,
+        new.name,
+        COALESCE(new.oracle_text, '') || COALESCE((
+            SELECT char(10) || group_concat(json_extract(f.value, '$.oracle_text'), char(10))
+            FROM json_each(new.card_faces) f
+        ), '')
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS cards_fts_delete AFTER DELETE ON cards BEGIN
+    DELETE FROM cards_fts WHERE rowid = old.rowid;
+END;
+
+-- Fills the index of databases created before it existed
+INSERT INTO cards_fts (rowid, name, oracle_text)
+SELECT
+    c.rowid,
+    c.name,
+    COALESCE(c.oracle_text, '') || COALESCE((
+        SELECT char(10) || group_concat(json_extract(f.value, '$.oracle_text'), char(10))
+        FROM json_each(c.card_faces) f
+    ), '')
+FROM cards c
+WHERE NOT EXISTS (SELECT 1 FROM cards_fts);
+
+-- Card Aliases table: Nicknames resolved to card names by QueryCard and decklist parsing
+CREATE TABLE IF NOT EXISTS card_aliases (
+    alias TEXT PRIMARY KEY COLLATE NOCASE, -- Nickname as written in decklists, "Bob"
+    card_name TEXT NOT NULL, -- Card name the alias stands for, "Dark Confidant"
+    created_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Known Sets table: Sets seen by CheckNewSets, to notice new releases
+CREATE TABLE IF NOT EXISTS known_sets (
+    code TEXT PRIMARY KEY NOT NULL, -- Scryfall set code, lower case ("neo")
+    released INTEGER NOT NULL DEFAULT 0 -- 1 once the set's release date has passed
+);
+
+-- Set Icons table: Icon URI of each set looked up by SetIcon, the SVG itself is in the image cache
+CREATE TABLE IF NOT EXISTS set_icons (
+    code TEXT PRIMARY KEY NOT NULL, -- Scryfall set code, lower case ("neo")
+    icon_uri TEXT NOT NULL -- Set's icon_svg_uri, including Scryfall's version query string
+);
+
+-- Card Errata table: Oracle text and type line changes seen when cards are refreshed
+CREATE TABLE IF NOT EXISTS card_errata (
+    errata_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    oracle_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    old_oracle_text TEXT NOT NULL, -- Oracle text of the card and its faces, one per line
+    new_oracle_text TEXT NOT NULL,
+    old_type_line TEXT NOT NULL,
+    new_type_line TEXT NOT NULL,
+    changed_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_card_errata_changed_at ON card_errata(changed_at);
+
+-- Oracle text includes the text of each face, like cards_fts
+CREATE TRIGGER IF NOT EXISTS cards_errata_update AFTER UPDATE OF oracle_text, type_line, card_faces ON cards
+WHEN old.type_line IS NOT new.type_line
+    OR (COALESCE(old.oracle_text, '') || COALESCE((
+        SELECT char(10) || group_concat(json_extract(f.value, '$.oracle_text'), char(10))
+        FROM json_each(old.card_faces) f
+    ), '')) IS NOT (COALESCE(new.oracle_text, '') || COALESCE((
+        SELECT char(10) || group_concat(json_extract(f.value, '$.oracle_text'), char(10))
+        FROM json_each(new.card_faces) f
+    ), ''))
+BEGIN
+    INSERT INTO card_errata (oracle_id, name, old_oracle_text, new_oracle_text, old_type_line, new_type_line)
+    VALUES (
+        new.oracle_id,
+        new.name,
+        ltrim(COALESCE(old.oracle_text, '') || COALESCE((
+            SELECT char(10) || group_concat(json_extract(f.value, '$.oracle_text'), char(10))
+            FROM json_each(old.card_faces) f
+        ), ''), char(10)),
+        ltrim(COALESCE(new.oracle_text, '') || COALESCE((
+            SELECT char(10) || group_concat(json_extract(f.value, '$.oracle_text'), char(10))
+            FROM json_each(new.card_faces) f
+        ), ''), char(10)),
+        old.type_line,
+        new.type_line
+    );
+END;
+
+-- Price History table: Printing prices replaced when printings are refreshed
+CREATE TABLE IF NOT EXISTS price_history (
+    history_id INTEGER PRIMARY KEY AUTOINCREMENT,
+    printing_id TEXT NOT NULL,
+    prices TEXT NOT NULL, -- JSON object map[string]*string, the prices before the change
+    recorded_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP -- When the prices were replaced
+);
+
+CREATE INDEX IF NOT EXISTS idx_price_history_printing_id ON price_history(printing_id, history_id);
+
+CREATE TRIGGER IF NOT EXISTS printings_price_history AFTER UPDATE OF prices ON printings
+WHEN old.prices IS NOT new.prices
+BEGIN
+    INSERT INTO price_history (printing_id, prices) VALUES (old.id, old.prices);
+END;
+
+-- Decks table: Decklists saved with SaveDeck, by name
+CREATE TABLE IF NOT EXISTS decks (
+    name TEXT PRIMARY KEY NOT NULL,
+    comments TEXT NOT NULL DEFAULT '',
+    saved_at TEXT NOT NULL DEFAULT CURRENT_TIMESTAMP -- When the deck was last saved
+);
+
+-- Deck Cards table: The cards of each saved deck, by Oracle ID so they survive card refreshes
+CREATE TABLE IF NOT EXISTS deck_cards (
+    deck_name TEXT NOT NULL,
+    zone TEXT NOT NULL, -- "maindeck", "sideboard", "maybeboard", "commander" or "companion"
+    position INTEGER NOT NULL, -- Order within the zone
+    oracle_id TEXT NOT NULL,
+    quantity INTEGER NOT NULL,
+    set_code TEXT NOT NULL DEFAULT '', -- Requested printing, empty if none
+    collector_number TEXT NOT NULL DEFAULT '',
+
+    PRIMARY KEY (deck_name, zone, position),
+    FOREIGN KEY (deck_name) REFERENCES decks(name)
+);
@@ -1,13 +1,17 @@
 package scryball
 
 import (
+	"context"
 	"database/sql"
 	_ "embed"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/ninesl/scryball/internal/client"
 	"github.com/ninesl/scryball/internal/scryfall"
@@ -30,6 +34,8 @@ type Scryball struct {
 	db      *ScryballDB
 	client  *client.Client
 	queries *scryfall.Queries
+
+	imageDir string // directory for downloaded images, "" to store them in db
 }
 
 //go:embed schema.sql
@@ -81,16 +87,23 @@ func (s *Scryball) RetrieveDB() *ScryballDB {
 //
 // Behavior:
 //   - Creates a new Scryball instance with provided config
-//   - Replaces the global CurrentScryball instance (protected by mutex)
+//   - Atomically replaces the global CurrentScryball instance, also after
+//     package-level functions have already used the default instance
+//   - Closes the previous global instance's database, so calls still running on
+//     it may fail; instances from NewWithConfig are never closed
 //   - Subsequent calls to Query(), QueryCard(), etc. will use this instance
+//   - On error the global instance is left unchanged
 //
 // Config fields:
 //   - DBPath: File path for cache storage (optional, defaults to memory-only)
 //   - Client: Custom HTTP client for API calls (optional, defaults to http.DefaultClient)
 //   - AppUserAgent: User-Agent header for API calls (optional, defaults to "MTGScryball/1.0")
+//   - APIBaseURL: Scryfall API to send requests to (optional, defaults to "https://api.scryfall.com")
+//   - Accept, Timeout, Headers: Accept header, request timeout and extra headers (optional)
+//   - ImageDir: Directory for downloaded images (optional, defaults to blobs in the database)
 //
 // Returns:
-//   - error: Database creation errors or invalid configuration
+//   - error: Database creation errors, invalid configuration, or errors closing the previous database
 //
 // Note: Call this before using package-level Query functions to customize behavior.
 func SetConfig(config ScryballConfig) error {
@@ -98,12 +111,27 @@ func SetConfig(config ScryballConfig) error {
 	if err != nil {
 		return err
 	}
+	return replaceCurrentScryball(scryball)
+}
 
-	mu.Lock()
-	CurrentScryball = scryball
-	mu.Unlock()
+// ResetToDefaults closes the global Scryball instance, so the next package-level
+// call creates a fresh default one: an in-memory cache with the default HTTP client.
+// It also clears the cards set with SetAnyNumberCards.
+//
+// Returns:
+//   - error: Errors closing the previous database
+func ResetToDefaults() error {
+	SetAnyNumberCards()
+	return replaceCurrentScryball(nil)
+}
 
-	return nil
+// Close closes the instance's database. The instance can't be used afterwards.
+//
+// Note: Use ResetToDefaults or SetConfig to close the global instance.
+func (s *Scryball) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.db.Close()
 }
 
 // ScryballConfig configures a Scryball instance.
@@ -117,20 +145,56 @@ type ScryballConfig struct {
 	// Client is the HTTP client for Scryfall API requests.
 	// Default: &http.Client{} (standard HTTP client with no timeout).
 	// Customize for proxies, timeouts, or rate limiting.
+	// Use NewWithDoer to send requests through something other than an *http.Client.
 	Client *http.Client
 
 	// AppUserAgent is the User-Agent header for API requests.
 	// Default: "MTGScryball/1.0".
 	// Scryfall requests descriptive user agents to identify your app.
 	AppUserAgent string
+
+	// APIBaseURL is the base URL of the Scryfall API, without a trailing slash.
+	// Default: "https://api.scryfall.com".
+	// Set it to target a mirror, a test server, or another process running Serve.
+	APIBaseURL string
+
+	// Accept is the Accept header for API requests.
+	// Default: "application/json;q=0.9,*/*;q=0.8".
+	Accept string
+
+	// Timeout limits each API and image request, including reading the response,
+	// whatever context the caller passes, so a hung connection can't stall a query.
+	// Default: 0, which means 30 seconds. Negative disables the limit.
+	// Bulk data downloads are only limited while looking up the file, not while streaming it.
+	Timeout time.Duration
+
+	// Headers are extra headers sent with every API request, like an API key for a proxy.
+	// Image and bulk data downloads from Scryfall's CDN only get the User-Agent.
+	// Default: nil. They can't override the User-Agent, and Accept is set by the Accept field.
+	Headers http.Header
+
+	// ImageDir is the directory downloaded card images are stored in.
+	// Default: "" (empty string) which stores images as blobs in the database,
+	// so a single DBPath file holds everything needed to render cards offline.
+	// Set to a directory to keep images as files instead and the database small.
+	// The directory will be created if it doesn't exist.
+	ImageDir string
 }
 
+// fileDSNParams are applied to every connection to a database file, so concurrent
+// queries and writes, also from other processes, wait for each other.
+const fileDSNParams = "?_pragma=busy_timeout(10000)&_pragma=journal_mode(WAL)&_txlock=immediate"
+
 // NewSchema creates a new SQLite database with Scryball schema.
 //
 // Behavior:
 //   - Empty string creates in-memory database (not saved to disk)
 //   - For file paths: creates parent directories if they don't exist
-//   - Creates a fresh *sql.DB wrapper *ScryballDB and applies schema
+//   - Safe for concurrent use: an in-memory database is a single connection,
+//     every connection would otherwise open its own empty database, and files
+//     use write-ahead logging and wait on locks instead of failing with SQLITE_BUSY
+//   - Creates a fresh *sql.DB wrapper *ScryballDB and applies schema, creating
+//     the tables of newer versions in existing databases
 //   - Returns wrapped database ready for use in a Scryball (s.OverwriteDB)
 //
 // Parameters:
@@ -147,8 +211,9 @@ func NewSchema(dbPath string) (*ScryballDB, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to create in-memory database: %w", err)
 		}
+		db.SetMaxOpenConns(1)
 
-		if _, err := db.Exec(embeddedSchema); err != nil {
+		if err := applySchema(db); err != nil {
 			db.Close()
 			return nil, fmt.Errorf("failed to apply embedded schema: %w", err)
 		}
@@ -162,12 +227,12 @@ func NewSchema(dbPath string) (*ScryballDB, error) {
 		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	db, err := sql.Open("sqlite", dbPath+fileDSNParams)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if _, err := db.Exec(embeddedSchema); err != nil {
+	if err := applySchema(db); err != nil {
 		db.Close()
 		return nil, fmt.Errorf("failed to execute schema: %w", err)
 	}
@@ -175,6 +240,30 @@ func NewSchema(dbPath string) (*ScryballDB, error) {
 	return &ScryballDB{DB: db}, nil
 }
 
+// applySchema creates the tables a database is missing and records schemaVersion
+// as its PRAGMA user_version, only when the database has an older version.
+// A database created by a newer version of scryball keeps its version.
+//
+// Also fills the data of new tables derived from cards cached by older versions.
+func applySchema(db *sql.DB) error {
+	var version int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if _, err := db.Exec(embeddedSchema); err != nil {
+		return err
+	}
+	if err := fillFoldedNames(context.Background(), scryfall.New(db)); err != nil {
+		return fmt.Errorf("could not fold cached card names: %w", err)
+	}
+	if version < schemaVersion {
+		if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", schemaVersion)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // NewWithConfig creates a new Scryball instance without affecting the global instance.
 //
 // Behavior:
@@ -189,6 +278,9 @@ func NewSchema(dbPath string) (*ScryballDB, error) {
 //   - DBPath: File path for cache storage (optional, defaults to memory-only)
 //   - Client: Custom HTTP client for API calls (optional)
 //   - AppUserAgent: User-Agent header for API calls (optional)
+//   - APIBaseURL: Scryfall API to send requests to (optional)
+//   - Accept, Timeout, Headers: Accept header, request timeout and extra headers (optional)
+//   - ImageDir: Directory for downloaded images (optional, defaults to blobs in the database)
 //
 // Returns:
 //   - *Scryball: New independent Scryball instance
@@ -196,8 +288,43 @@ func NewSchema(dbPath string) (*ScryballDB, error) {
 //
 // Note: Use SetConfig() to update global instance, or use returned instance directly.
 func NewWithConfig(config ScryballConfig) (*Scryball, error) {
-	// DBPath empty means in-memory database
+	if config.Client == nil {
+		config.Client = &http.Client{}
+	}
+	return NewWithDoer(config.Client, config)
+}
+
+// Doer sends HTTP requests. *http.Client implements it.
+//
+// Implement it to answer requests without a network in tests, or wrap an
+// *http.Client to add logging, metrics or retries.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
 
+// NewWithDoer creates a new Scryball instance like NewWithConfig, sending every
+// API and image request through doer instead of config.Client.
+//
+// Returns:
+//   - *Scryball: New independent Scryball instance
+//   - error: A nil doer, database errors or invalid configuration
+//
+// Example:
+//
+//	sb, err := scryball.NewWithDoer(loggingDoer{http.DefaultClient}, scryball.ScryballConfig{})
+func NewWithDoer(doer Doer, config ScryballConfig) (*Scryball, error) {
+	if doer == nil {
+		return nil, fmt.Errorf("doer must not be nil")
+	}
+	if config.APIBaseURL == "" {
+		config.APIBaseURL = baseClientOptions.APIURL
+	}
+	apiURL, err := url.Parse(config.APIBaseURL)
+	if err != nil || (apiURL.Scheme != "http" && apiURL.Scheme != "https") || apiURL.Host == "" {
+		return nil, fmt.Errorf("invalid API base URL '%s': must be an absolute http or https URL", config.APIBaseURL)
+	}
+
+	// DBPath empty means in-memory database
 	db, err := NewSchema(config.DBPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create/open database: %w", err)
@@ -206,25 +333,38 @@ func NewWithConfig(config ScryballConfig) (*Scryball, error) {
 	if config.AppUserAgent == "" {
 		config.AppUserAgent = baseClientOptions.UserAgent
 	}
-	if config.Client == nil {
-		config.Client = &http.Client{}
+	if config.Accept == "" {
+		config.Accept = baseClientOptions.Accept
+	}
+	if config.Timeout == 0 {
+		config.Timeout = baseClientOptions.Timeout
 	}
 
-	cClient, err := client.NewClientWithOptions(client.ClientOptions{
-		APIURL:    baseClientOptions.APIURL,
+	cClient, err := client.NewClientWithDoer(doer, client.ClientOptions{
+		APIURL:    strings.TrimSuffix(config.APIBaseURL, "/"),
 		UserAgent: config.AppUserAgent,
-		Accept:    baseClientOptions.Accept,
-		Client:    config.Client,
+		Accept:    config.Accept,
+		Headers:   config.Headers,
+		Timeout:   config.Timeout,
 	})
 	if err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
+	if config.ImageDir != "" {
+		if err := os.MkdirAll(config.ImageDir, 0755); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create image directory: %w", err)
+		}
+	}
+
 	queries := scryfall.New(db.DB)
 
 	return &Scryball{
-		db:      db,
-		client:  cClient,
-		queries: queries,
+		db:       db,
+		client:   cClient,
+		queries:  queries,
+		imageDir: config.ImageDir,
 	}, nil
 }
@@ -3,10 +3,18 @@ package scryball
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/ninesl/scryball/internal/client"
 	"github.com/ninesl/scryball/internal/scryfall"
 )
 
@@ -30,6 +38,130 @@ func testHelper(t *testing.T) *Scryball {
 	return sb
 }
 
+// insertTestCard stores a card and its printings directly in the database,
+// without any API calls, so cache-only behavior can be tested offline.
+func insertTestCard(t *testing.T, sb *Scryball, card *client.Card, printings ...*client.Card) *MagicCard {
+	t.Helper()
+	ctx := context.Background()
+
+	cardParams, printingParams, err := convertAPICardToDBParams(card)
+	if err != nil {
+		t.Fatalf("Failed to convert test card %s: %v", card.Name, err)
+	}
+	if err := sb.queries.UpsertCard(ctx, cardParams); err != nil {
+		t.Fatalf("Failed to insert test card %s: %v", card.Name, err)
+	}
+	if err := upsertFoldedNames(ctx, sb.queries, cardParams.OracleID, cardParams.Name); err != nil {
+		t.Fatalf("Failed to insert folded names of %s: %v", card.Name, err)
+	}
+	if err := sb.queries.UpsertPrinting(ctx, printingParams); err != nil {
+		t.Fatalf("Failed to insert test printing for %s: %v", card.Name, err)
+	}
+	if err := upsertPrintingFaces(ctx, sb.queries, card); err != nil {
+		t.Fatalf("Failed to insert test face images for %s: %v", card.Name, err)
+	}
+	for _, printing := range printings {
+		_, printingParams, err := convertAPICardToDBParams(printing)
+		if err != nil {
+			t.Fatalf("Failed to convert test printing %s: %v", printing.ID, err)
+		}
+		if err := sb.queries.UpsertPrinting(ctx, printingParams); err != nil {
+			t.Fatalf("Failed to insert test printing %s: %v", printing.ID, err)
+		}
+	}
+
+	magicCard, err := sb.FetchCardByExactOracleID(ctx, cardParams.OracleID)
+	if err != nil {
+		t.Fatalf("Failed to fetch test card %s: %v", card.Name, err)
+	}
+	return magicCard
+}
+
+// testAPICard builds a minimal API card suitable for insertTestCard.
+func testAPICard(oracleID, printingID, name, typeLine string) *client.Card {
+	return &client.Card{
+		ID:         printingID,
+		OracleID:   &oracleID,
+		Name:       name,
+		TypeLine:   typeLine,
+		Lang:       "en",
+		Object:     "card",
+		Rarity:     "common",
+		Set:        "tst",
+		SetName:    "Test Set",
+		ReleasedAt: "2020-01-01",
+		Games:      []string{"paper"},
+		Legalities: map[string]string{},
+		Prices:     map[string]*string{},
+	}
+}
+
+// testCard returns a MagicCard of a testAPICard, with opts setting the fields
+// a test cares about, for tests that don't need the card in the database.
+func testCard(oracleID, name, typeLine string, opts ...func(*client.Card)) *MagicCard {
+	card := testAPICard(oracleID, oracleID+"-1", name, typeLine)
+	for _, opt := range opts {
+		opt(card)
+	}
+	return &MagicCard{Card: card}
+}
+
+// withManaCost sets the mana cost of a testCard, "" leaves it without one.
+func withManaCost(manaCost string) func(*client.Card) {
+	return func(card *client.Card) {
+		if manaCost != "" {
+			card.ManaCost = &manaCost
+		}
+	}
+}
+
+func withCMC(cmc float64) func(*client.Card) {
+	return func(card *client.Card) { card.CMC = cmc }
+}
+
+func withColors(colors ...string) func(*client.Card) {
+	return func(card *client.Card) { card.Colors = colors }
+}
+
+// withLegalities makes a testCard legal in legalIn and not legal in every other format.
+func withLegalities(legalIn ...string) func(*client.Card) {
+	return func(card *client.Card) {
+		for format := range formats {
+			card.Legalities[format] = "not_legal"
+		}
+		for _, format := range legalIn {
+			card.Legalities[format] = "legal"
+		}
+	}
+}
+
+func withReleasedAt(releasedAt string) func(*client.Card) {
+	return func(card *client.Card) { card.ReleasedAt = releasedAt }
+}
+
+func withPrice(currency, price string) func(*client.Card) {
+	return func(card *client.Card) { card.Prices[currency] = &price }
+}
+
+func withOracleText(oracleText string) func(*client.Card) {
+	return func(card *client.Card) { card.OracleText = &oracleText }
+}
+
+func withColorIdentity(colorIdentity ...string) func(*client.Card) {
+	return func(card *client.Card) { card.ColorIdentity = colorIdentity }
+}
+
+func withEDHRecRank(rank int) func(*client.Card) {
+	return func(card *client.Card) { card.EDHRecRank = &rank }
+}
+
+func withGameChanger() func(*client.Card) {
+	return func(card *client.Card) {
+		gameChanger := true
+		card.GameChanger = &gameChanger
+	}
+}
+
 func TestQuery(t *testing.T) {
 	sb := testHelper(t)
 	defer sb.db.Close()
@@ -515,3 +647,288 @@ func TestIntegrationFlow(t *testing.T) {
 		})
 	}
 }
+
+func TestFindQueryKeepsAPIOrder(t *testing.T) {
+	// Enough cards that map iteration order would not match by chance.
+	var expected, data []string
+	for i := 20; i > 0; i-- {
+		name := fmt.Sprintf("Card %02d", i)
+		expected = append(expected, name)
+		data = append(data, fmt.Sprintf(`{"object": "card", "id": "card-%d", "oracle_id": "oracle-%d", "name": %q,
+			"lang": "en", "type_line": "Instant", "set": "tst", "rarity": "common", "released_at": "2020-01-01"}`, i, i, name))
+	}
+	// A second printing of the first card is dropped.
+	data = append(data, strings.Replace(data[0], "card-20", "card-20b", 1))
+
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s]}`, strings.Join(data, ", "))
+	})
+
+	for _, source := range []string{"api", "cache"} {
+		cards, err := sb.findQuery(context.Background(), "t:instant order:name dir:desc")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", source, err)
+		}
+		if names := cardNames(cards); !reflect.DeepEqual(names, expected) {
+			t.Errorf("%s: expected %v, got %v", source, expected, names)
+		}
+	}
+}
+
+func TestCountMatchingCards(t *testing.T) {
+	var requests int
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Query().Get("q") == "t:nothing" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"object": "error", "status": 404, "code": "not_found", "details": "Your query didn't match any cards."}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"object": "list", "total_cards": 1234, "has_more": true,
+			"next_page": "https://api.scryfall.com/cards/search?page=2&q=t%3Adragon",
+			"data": [{"object": "card", "id": "dragon-1", "oracle_id": "dragon", "name": "Shivan Dragon"}]}`)
+	})
+	ctx := context.Background()
+
+	count, err := sb.QueryCountWithContext(ctx, "t:dragon")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1234 || requests != 1 {
+		t.Errorf("Expected 1234 cards from 1 request, got %d from %d", count, requests)
+	}
+	if _, err := sb.FetchCardByExactName(ctx, "Shivan Dragon"); err != sql.ErrNoRows {
+		t.Errorf("Expected counted cards not to be cached, got %v", err)
+	}
+
+	if count, err := sb.QueryCountWithContext(ctx, "t:nothing"); err != nil || count != 0 {
+		t.Errorf("Expected 0 cards for a query without matches, got %d, %v", count, err)
+	}
+
+	if err := sb.cacheQuery(ctx, "t:cached", []string{"a", "b", "c"}); err != nil {
+		t.Fatalf("Failed to cache query: %v", err)
+	}
+	requests = 0
+	if count, err := sb.QueryCountWithContext(ctx, "t:cached"); err != nil || count != 3 || requests != 0 {
+		t.Errorf("Expected 3 cached cards with no requests, got %d, %v after %d requests", count, err, requests)
+	}
+}
+
+func TestSetConfigReplacesGlobalInstance(t *testing.T) {
+	if err := ResetToDefaults(); err != nil {
+		t.Fatalf("Failed to reset: %v", err)
+	}
+	t.Cleanup(func() { ResetToDefaults() })
+
+	defaults, err := ensureCurrentScryball()
+	if err != nil {
+		t.Fatalf("Failed to create default instance: %v", err)
+	}
+
+	if err := SetConfig(ScryballConfig{DBPath: filepath.Join(t.TempDir(), "configured.db")}); err != nil {
+		t.Fatalf("Failed to set config: %v", err)
+	}
+	configured, err := ensureCurrentScryball()
+	if err != nil || configured == defaults {
+		t.Fatalf("Expected SetConfig to replace the default instance, got %v", err)
+	}
+	if err := defaults.db.Ping(); err == nil {
+		t.Error("Expected the replaced instance to be closed")
+	}
+
+	if err := ResetToDefaults(); err != nil {
+		t.Fatalf("Failed to reset: %v", err)
+	}
+	if err := configured.db.Ping(); err == nil {
+		t.Error("Expected the configured instance to be closed")
+	}
+	fresh, err := ensureCurrentScryball()
+	if err != nil || fresh == configured || fresh == defaults {
+		t.Errorf("Expected a fresh default instance, got %v", err)
+	}
+}
+
+func TestConfigHeadersAndTimeout(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		if r.URL.Query().Get("q") == "slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{"object": "list", "data": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	custom := &http.Client{Transport: redirectTransport{server}}
+	sb, err := NewWithConfig(ScryballConfig{
+		Client:       custom,
+		AppUserAgent: "HeaderTest/1.0",
+		Accept:       "application/json",
+		Timeout:      50 * time.Millisecond,
+		Headers:      http.Header{"X-Api-Key": {"secret"}, "User-Agent": {"Override/1.0"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create Scryball: %v", err)
+	}
+	t.Cleanup(func() { sb.Close() })
+
+	sb.Query("fast")
+	if got := header.Get("User-Agent"); got != "HeaderTest/1.0" {
+		t.Errorf("Expected User-Agent HeaderTest/1.0, got %q", got)
+	}
+	if got := header.Get("Accept"); got != "application/json" {
+		t.Errorf("Expected Accept application/json, got %q", got)
+	}
+	if got := header.Get("X-Api-Key"); got != "secret" {
+		t.Errorf("Expected X-Api-Key secret, got %q", got)
+	}
+
+	// image hosts are not the API, they only get the User-Agent
+	sb.fetchImage(context.Background(), "https://cards.scryfall.io/normal/front/a/b/ab.jpg")
+	if got := header.Get("X-Api-Key"); got != "" {
+		t.Errorf("Expected no X-Api-Key on image requests, got %q", got)
+	}
+	if got := header.Get("User-Agent"); got != "HeaderTest/1.0" {
+		t.Errorf("Expected User-Agent HeaderTest/1.0 on image requests, got %q", got)
+	}
+
+	if _, err := sb.Query("slow"); err == nil {
+		t.Error("Expected the slow request to time out")
+	}
+	if custom.Timeout != 0 {
+		t.Errorf("Expected the custom client to be left unmodified, got timeout %v", custom.Timeout)
+	}
+}
+
+func TestConfigAPIBaseURL(t *testing.T) {
+	var requested string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		w.Write([]byte(`{"object": "list", "data": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	sb, err := NewWithConfig(ScryballConfig{APIBaseURL: server.URL + "/mirror/"})
+	if err != nil {
+		t.Fatalf("Failed to create Scryball: %v", err)
+	}
+	t.Cleanup(func() { sb.Close() })
+
+	sb.Query("Lightning Bolt")
+	if requested != "/mirror/cards/search" {
+		t.Errorf("Expected a request to /mirror/cards/search, got %q", requested)
+	}
+
+	for _, invalid := range []string{"api.scryfall.com", "ftp://api.scryfall.com", "://"} {
+		if _, err := NewWithConfig(ScryballConfig{APIBaseURL: invalid}); err == nil {
+			t.Errorf("Expected an error for API base URL %q", invalid)
+		}
+	}
+}
+
+// doerFunc is a Doer answering requests with a function.
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestNewWithDoer(t *testing.T) {
+	var requested []string
+	sb, err := NewWithDoer(doerFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.String())
+		recorder := httptest.NewRecorder()
+		recorder.WriteString(`{"object": "list", "data": []}`)
+		return recorder.Result(), nil
+	}), ScryballConfig{})
+	if err != nil {
+		t.Fatalf("Failed to create Scryball: %v", err)
+	}
+	t.Cleanup(func() { sb.Close() })
+
+	sb.Query("Lightning Bolt")
+	if len(requested) != 1 || requested[0] != "https://api.scryfall.com/cards/search?q=Lightning+Bolt" {
+		t.Errorf("Expected one search request through the doer, got %v", requested)
+	}
+
+	if _, err := NewWithDoer(nil, ScryballConfig{}); err == nil {
+		t.Error("Expected an error for a nil doer")
+	}
+}
+
+func TestQueryMany(t *testing.T) {
+	card := func(id, name string) string {
+		return fmt.Sprintf(`{"object": "card", "id": "%[1]s-1", "oracle_id": %[1]q, "name": %[2]q, "lang": "en",
+			"type_line": "Creature", "set": "tst", "rarity": "common", "released_at": "2020-01-01",
+			"prints_search_uri": "https://api.scryfall.com/cards/search?q=oracleid%%3A%[1]s&unique=prints"}`, id, name)
+	}
+	list := func(cards ...string) string {
+		return fmt.Sprintf(`{"object": "list", "has_more": false, "data": [%s]}`, strings.Join(cards, ", "))
+	}
+	krenko, muxus, bolt := card("krenko", "Krenko, Mob Boss"), card("muxus", "Muxus, Goblin Grandee"), card("bolt", "Lightning Bolt")
+
+	var mu sync.Mutex
+	printFetches := map[string]int{}
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch q := r.URL.Query().Get("q"); q {
+		case "t:goblin":
+			fmt.Fprint(w, list(krenko, muxus))
+		case "c:r":
+			fmt.Fprint(w, list(krenko, bolt))
+		case "oracleid:krenko", "oracleid:muxus", "oracleid:bolt":
+			mu.Lock()
+			printFetches[q]++
+			mu.Unlock()
+			fmt.Fprint(w, list(map[string]string{"oracleid:krenko": krenko, "oracleid:muxus": muxus, "oracleid:bolt": bolt}[q]))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"object": "error", "status": 400, "code": "bad_request", "details": "invalid query"}`)
+		}
+	})
+
+	results, err := sb.QueryMany(context.Background(), []string{"t:goblin", "c:r", "t:goblin", "bad query"})
+	var failed QueryErrors
+	if !errors.As(err, &failed) || len(failed) != 1 || failed["bad query"] == nil {
+		t.Fatalf("Expected only \"bad query\" to fail, got %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected results for 2 queries, got %d", len(results))
+	}
+	if names := cardNames(results["t:goblin"]); !reflect.DeepEqual(names, []string{"Krenko, Mob Boss", "Muxus, Goblin Grandee"}) {
+		t.Errorf("Unexpected goblins %v", names)
+	}
+	if names := cardNames(results["c:r"]); !reflect.DeepEqual(names, []string{"Krenko, Mob Boss", "Lightning Bolt"}) {
+		t.Errorf("Unexpected red cards %v", names)
+	}
+	if results["t:goblin"][0] != results["c:r"][0] {
+		t.Error("Expected both queries to share the same Krenko card")
+	}
+	if printFetches["oracleid:krenko"] != 1 {
+		t.Errorf("Expected Krenko's printings to be fetched once, got %d", printFetches["oracleid:krenko"])
+	}
+}
+
+func TestFetchCardsByExactNamesPartial(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain"))
+
+	cards, missing, err := sb.FetchCardsByExactNamesPartial(ctx, []string{"Mountain", "Goblin Guide", "lightning bolt", "Monastery Swiftspear"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if names := cardNames(cards); !reflect.DeepEqual(names, []string{"Mountain", "Lightning Bolt"}) {
+		t.Errorf("Expected Mountain and Lightning Bolt, got %v", names)
+	}
+	if !reflect.DeepEqual(missing, []string{"Goblin Guide", "Monastery Swiftspear"}) {
+		t.Errorf("Expected Goblin Guide and Monastery Swiftspear missing, got %v", missing)
+	}
+
+	if _, err := sb.FetchCardsByExactNames(ctx, []string{"Mountain", "Goblin Guide"}); err != sql.ErrNoRows {
+		t.Errorf("Expected FetchCardsByExactNames to still fail with sql.ErrNoRows, got %v", err)
+	}
+}
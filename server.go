@@ -0,0 +1,227 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ninesl/scryball/internal/client"
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// Serve runs an HTTP server on addr answering a subset of the Scryfall API
+// from the cache, so several local apps share one cache and one rate limit.
+//
+// Endpoints (see https://scryfall.com/docs/api):
+//   - GET /cards/search?q=... : a list of every matching card, like Query, on a single page
+//   - GET /cards/named?exact=... : a single card, like QueryCard
+//   - GET /cards/named?fuzzy=... : a single card, like exact but a name with a typo
+//     finds the closest cached name (see SimilarCardNames)
+//   - GET /cards/autocomplete?q=... : a catalog of up to 20 card names starting
+//     with q, from the cache, or from Scryfall if no cached name does
+//
+// Behavior:
+//   - Cards are Scryfall card objects with an added "printings" array (see MagicCard.MarshalJSON)
+//   - Requests from several clients are answered concurrently from the same cache
+//   - Cache misses are fetched from Scryfall, one request at a time for all clients
+//   - Errors are Scryfall error objects: 404 for cards that don't exist, 400 for
+//     bad requests, 502 when Scryfall can't be reached
+//   - Blocks until the server fails, like http.ListenAndServe
+//
+// Example:
+//
+//	go scryball.Serve("localhost:8080")
+//	// curl 'localhost:8080/cards/named?exact=Lightning+Bolt'
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func Serve(addr string) error {
+	return ServeWithContext(context.Background(), addr)
+}
+
+// ServeWithContext runs the server of Serve until ctx is done, then shuts it down
+// gracefully and returns nil. See Serve.
+func ServeWithContext(ctx context.Context, addr string) error {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.ServeWithContext(ctx, addr)
+}
+
+// Serve runs the server of the package-level Serve using this instance's database.
+func (s *Scryball) Serve(addr string) error {
+	return s.ServeWithContext(context.Background(), addr)
+}
+
+// ServeWithContext runs the server of the package-level Serve using this
+// instance's database until ctx is done. See Serve.
+func (s *Scryball) ServeWithContext(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:        addr,
+		Handler:     s.Handler(),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			server.Shutdown(shutdownCtx)
+		case <-stopped:
+		}
+	}()
+
+	err := server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// Handler returns the http.Handler of Serve, to mount the endpoints in an
+// existing server or wrap them with middleware.
+//
+// Example:
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("/scryfall/", http.StripPrefix("/scryfall", sb.Handler()))
+func (s *Scryball) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /cards/search", s.serveSearch)
+	mux.HandleFunc("GET /cards/named", s.serveNamed)
+	mux.HandleFunc("GET /cards/autocomplete", s.serveAutocomplete)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeAPIError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s is not served from the cache", r.URL.Path))
+	})
+	return mux
+}
+
+func (s *Scryball) serveSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	if query == "" {
+		writeAPIError(w, http.StatusBadRequest, "bad_request", "You didn't provide a search query with q")
+		return
+	}
+
+	cards, err := s.findQuery(r.Context(), query)
+	if errors.Is(err, client.ErrNotFound) {
+		writeAPIError(w, http.StatusNotFound, "not_found", "Your query didn't match any cards.")
+		return
+	}
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Object     string       `json:"object"`
+		TotalCards int          `json:"total_cards"`
+		HasMore    bool         `json:"has_more"`
+		Data       []*MagicCard `json:"data"`
+	}{"list", len(cards), false, cards})
+}
+
+func (s *Scryball) serveNamed(w http.ResponseWriter, r *http.Request) {
+	name, fuzzy := r.URL.Query().Get("exact"), false
+	if name == "" {
+		name, fuzzy = r.URL.Query().Get("fuzzy"), true
+	}
+	if name == "" {
+		writeAPIError(w, http.StatusBadRequest, "bad_request", "You didn't provide a card name with exact or fuzzy")
+		return
+	}
+
+	if fuzzy {
+		name = s.closestCachedName(r.Context(), name)
+	}
+	card, err := s.findCard(r.Context(), name)
+	var notFound *CardNotFoundError
+	if errors.As(err, &notFound) {
+		writeAPIError(w, http.StatusNotFound, "not_found", notFound.Error())
+		return
+	}
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, card)
+}
+
+// closestCachedName returns the cached name closest to a name with a typo, or
+// name itself if it is cached or no cached name is close.
+func (s *Scryball) closestCachedName(ctx context.Context, name string) string {
+	if _, err := s.FetchCardByExactName(ctx, s.resolveAlias(ctx, name)); err != sql.ErrNoRows {
+		return name
+	}
+	if similar, err := s.SimilarCardNames(ctx, name, 1); err == nil && len(similar) == 1 {
+		return similar[0]
+	}
+	return name
+}
+
+// maxAutocompletions is the number of names /cards/autocomplete returns at most, like Scryfall.
+const maxAutocompletions = 20
+
+func (s *Scryball) serveAutocomplete(w http.ResponseWriter, r *http.Request) {
+	partial := r.URL.Query().Get("q")
+	names, err := s.cachedCompletions(r.Context(), partial)
+	if err == nil && len(names) == 0 {
+		names, err = s.client.AutocompleteCardNames(partial)
+	}
+	if err != nil {
+		writeUpstreamError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, client.Catalog{Object: "catalog", TotalValues: len(names), Data: names})
+}
+
+// cachedCompletions returns the cached names, or names with a face, starting with
+// partial once both are folded with foldCardName, sorted, at most maxAutocompletions.
+// Like Scryfall, partial names under 2 letters complete to nothing.
+func (s *Scryball) cachedCompletions(ctx context.Context, partial string) ([]string, error) {
+	folded := foldCardName(partial)
+	completions := []string{}
+	if len([]rune(folded)) < 2 {
+		return completions, nil
+	}
+	// every folded name starting with folded sorts between it and folded followed by the highest rune
+	names, err := s.queries.ListCardNamesByFoldedRange(ctx, scryfall.ListCardNamesByFoldedRangeParams{
+		FromFoldedName: folded,
+		ToFoldedName:   folded + string(utf8.MaxRune),
+		Limit:          maxAutocompletions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("database error listing card names: %v", err)
+	}
+	return append(completions, names...), nil
+}
+
+// writeUpstreamError writes an error fetching from Scryfall or reading the cache as a 502.
+func writeUpstreamError(w http.ResponseWriter, err error) {
+	writeAPIError(w, http.StatusBadGateway, "bad_gateway", err.Error())
+}
+
+// writeAPIError writes a Scryfall error object, see https://scryfall.com/docs/api/errors.
+func writeAPIError(w http.ResponseWriter, status int, code, details string) {
+	writeJSON(w, status, struct {
+		Object  string `json:"object"`
+		Status  int    `json:"status"`
+		Code    string `json:"code"`
+		Details string `json:"details"`
+	}{"error", status, code, details})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,182 @@
+package scryball
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestServeFromCache(t *testing.T) {
+	// Scryfall knows no cards, so everything served must come from the cache.
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"object": "error", "status": 404, "code": "not_found", "details": "No cards found."}`))
+	})
+	ctx := context.Background()
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+	if err := sb.cacheQuery(ctx, "t:instant", []string{"bolt"}); err != nil {
+		t.Fatalf("Failed to cache query: %v", err)
+	}
+
+	server := httptest.NewServer(sb.Handler())
+	defer server.Close()
+
+	get := func(path string, wantStatus int, v any) {
+		t.Helper()
+		resp, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != wantStatus {
+			t.Errorf("GET %s: expected status %d, got %d", path, wantStatus, resp.StatusCode)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Errorf("GET %s: invalid JSON: %v", path, err)
+		}
+	}
+
+	var card MagicCard
+	get("/cards/named?exact=lightning+bolt", http.StatusOK, &card)
+	if card.Card == nil || card.Name != "Lightning Bolt" || len(card.Printings) != 1 {
+		t.Errorf("Expected Lightning Bolt with its printing, got %+v", card)
+	}
+
+	card = MagicCard{}
+	get("/cards/named?fuzzy=lightnig+bolt", http.StatusOK, &card)
+	if card.Card == nil || card.Name != "Lightning Bolt" {
+		t.Errorf("Expected a fuzzy name to find Lightning Bolt, got %+v", card)
+	}
+
+	var catalog struct {
+		Object string   `json:"object"`
+		Data   []string `json:"data"`
+	}
+	get("/cards/autocomplete?q=light", http.StatusOK, &catalog)
+	if catalog.Object != "catalog" || len(catalog.Data) != 1 || catalog.Data[0] != "Lightning Bolt" {
+		t.Errorf("Expected a catalog of Lightning Bolt from the cache, got %+v", catalog)
+	}
+
+	var list struct {
+		Object     string      `json:"object"`
+		TotalCards int         `json:"total_cards"`
+		Data       []MagicCard `json:"data"`
+	}
+	get("/cards/search?q=t%3Ainstant", http.StatusOK, &list)
+	if list.Object != "list" || list.TotalCards != 1 || len(list.Data) != 1 || list.Data[0].Name != "Lightning Bolt" {
+		t.Errorf("Expected a list of Lightning Bolt, got %+v", list)
+	}
+
+	var apiErr struct {
+		Object string `json:"object"`
+		Status int    `json:"status"`
+	}
+	for path, status := range map[string]int{
+		"/cards/named?exact=Lightning+Bolk": http.StatusNotFound,
+		"/cards/named?fuzzy=Counterspell":   http.StatusNotFound,
+		"/cards/search?q=t%3Anothing":       http.StatusNotFound,
+		"/cards/search":                     http.StatusBadRequest,
+		"/sets":                             http.StatusNotFound,
+	} {
+		apiErr.Object, apiErr.Status = "", 0
+		get(path, status, &apiErr)
+		if apiErr.Object != "error" || apiErr.Status != status {
+			t.Errorf("GET %s: expected a %d error object, got %+v", path, status, apiErr)
+		}
+	}
+}
+
+func TestServeConcurrentRequests(t *testing.T) {
+	card := func(name string) string {
+		id := strings.ReplaceAll(strings.ToLower(name), " ", "-")
+		return fmt.Sprintf(`{"object": "card", "id": "%s-1", "oracle_id": %q, "name": %q, "lang": "en",
+			"type_line": "Instant", "set": "tst", "rarity": "common", "released_at": "2020-01-01"}`, id, id, name)
+	}
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		recorder := httptest.NewRecorder()
+		if q := req.URL.Query().Get("q"); req.URL.Path == "/cards/search" {
+			fmt.Fprintf(recorder, `{"object": "list", "has_more": false, "data": [%s]}`, card(q))
+		} else {
+			fmt.Fprint(recorder, card(req.URL.Query().Get("exact")))
+		}
+		return recorder.Result(), nil
+	})
+
+	var paths []string
+	for i := range 10 {
+		// every card is requested by two clients at once
+		name := url.QueryEscape(fmt.Sprintf("Card %d", i))
+		paths = append(paths, "/cards/named?exact="+name, "/cards/named?exact="+name, "/cards/search?q="+name)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		dbPath string
+	}{
+		{"memory", ""},
+		{"file", filepath.Join(t.TempDir(), "test.db")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			sb, err := NewWithDoer(doer, ScryballConfig{DBPath: tt.dbPath})
+			if err != nil {
+				t.Fatalf("Failed to create Scryball: %v", err)
+			}
+			defer sb.Close()
+			server := httptest.NewServer(sb.Handler())
+			defer server.Close()
+
+			var wg sync.WaitGroup
+			for _, path := range paths {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					resp, err := http.Get(server.URL + path)
+					if err != nil {
+						t.Errorf("GET %s: %v", path, err)
+						return
+					}
+					defer resp.Body.Close()
+					if resp.StatusCode != http.StatusOK {
+						var apiErr struct{ Details string }
+						json.NewDecoder(resp.Body).Decode(&apiErr)
+						t.Errorf("GET %s: expected status 200, got %d: %s", path, resp.StatusCode, apiErr.Details)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestCachedCompletions(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	insertTestCard(t, sb, testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant"))
+	insertTestCard(t, sb, testAPICard("helix", "helix-1", "Lightning Helix", "Instant"))
+	insertTestCard(t, sb, testAPICard("vault", "vault-1", "Lim-Dûl's Vault", "Instant"))
+	insertTestCard(t, sb, testAPICard("fire", "fire-1", "Fire // Ice", "Instant // Instant"))
+
+	for partial, want := range map[string][]string{
+		"lightning": {"Lightning Bolt", "Lightning Helix"},
+		"LIM DUL":   {"Lim-Dûl's Vault"},
+		"ic":        {"Fire // Ice"},
+		"l":         {},
+		"zz":        {},
+	} {
+		got, err := sb.cachedCompletions(context.Background(), partial)
+		if err != nil {
+			t.Fatalf("cachedCompletions(%q) failed: %v", partial, err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("cachedCompletions(%q) = %v, want %v", partial, got, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ninesl/scryball/internal/scryfall"
+)
+
+// SetReleases is the result of CheckNewSets.
+type SetReleases struct {
+	Released    []string // Codes of the sets released since the last check, sorted
+	Invalidated []string // Cached queries removed because their results could change, sorted
+}
+
+// CheckNewSets looks for sets released since the last check and removes the
+// cached queries whose results they could change, so a disk cache doesn't
+// silently go stale after every set release.
+//
+// Behavior:
+//   - Makes one API call to list Scryfall's sets
+//   - A set counts as released once its release date has passed, so sets
+//     announced early are reported on their release day
+//   - The first check only records the sets, since it can't know what changed before
+//   - Queries restricted to sets other than the released ones, like "s:lea t:elf",
+//     are kept; every other cached query is removed and fetched again on next use
+//   - Cached cards are kept: their printings refresh with the queries that return them
+//
+// Call it on demand or periodically, for example daily:
+//
+//	for range time.Tick(24 * time.Hour) {
+//	    if releases, err := sb.CheckNewSets(ctx); err == nil && len(releases.Released) > 0 {
+//	        log.Printf("new sets %v, dropped %d cached queries", releases.Released, len(releases.Invalidated))
+//	    }
+//	}
+//
+// Returns:
+//   - *SetReleases: Released sets and removed queries, both empty if nothing was released
+//   - error: Network errors, API errors, or database errors
+func (s *Scryball) CheckNewSets(ctx context.Context) (*SetReleases, error) {
+	sets, err := s.client.ListSets()
+	if err != nil {
+		return nil, err
+	}
+
+	known, err := s.queries.ListKnownSets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list known sets: %v", err)
+	}
+	wasReleased := make(map[string]bool, len(known))
+	for _, set := range known {
+		wasReleased[set.Code] = set.Released == 1
+	}
+	firstCheck := len(known) == 0
+
+	today := time.Now().UTC().Format(time.DateOnly)
+	releases := &SetReleases{Released: []string{}, Invalidated: []string{}}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin set update: %v", err)
+	}
+	defer tx.Rollback()
+	queries := s.queries.WithTx(tx)
+
+	for _, set := range sets {
+		code := strings.ToLower(set.Code)
+		released := set.ReleasedAt != nil && *set.ReleasedAt <= today
+		if released && !wasReleased[code] && !firstCheck {
+			releases.Released = append(releases.Released, code)
+		}
+		var releasedFlag int64
+		if released {
+			releasedFlag = 1
+		}
+		err := queries.UpsertKnownSet(ctx, scryfall.UpsertKnownSetParams{Code: code, Released: releasedFlag})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record set %s: %v", code, err)
+		}
+	}
+	slices.Sort(releases.Released)
+
+	if len(releases.Released) > 0 {
+		cached, err := queries.ListCachedQueryTexts(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cached queries: %v", err)
+		}
+		for _, query := range cached {
+			if !queryAffectedBySets(query, releases.Released) {
+				continue
+			}
+			if _, err := queries.DeleteCachedQuery(ctx, query); err != nil {
+				return nil, fmt.Errorf("failed to remove cached query %q: %v", query, err)
+			}
+			releases.Invalidated = append(releases.Invalidated, query)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit set update: %v", err)
+	}
+	return releases, nil
+}
+
+// queryAffectedBySets reports whether cards of the given sets could change the
+// results of a Scryfall query. Only queries restricted to other sets by a set
+// term ("s:", "set:", "e:", "edition:") without "or" are unaffected.
+func queryAffectedBySets(query string, setCodes []string) bool {
+	lower := strings.ToLower(query)
+	var pinned []string
+	for _, term := range strings.Fields(lower) {
+		if term == "or" || strings.ContainsAny(term, "()") {
+			return true
+		}
+		for _, prefix := range []string{"s:", "set:", "e:", "edition:", "s=", "set=", "e=", "edition="} {
+			if code, ok := strings.CutPrefix(term, prefix); ok {
+				pinned = append(pinned, strings.Trim(code, `"`))
+			}
+		}
+	}
+	if len(pinned) == 0 {
+		return true
+	}
+	for _, code := range pinned {
+		if slices.Contains(setCodes, code) {
+			return true
+		}
+	}
+	return false
+}
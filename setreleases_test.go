@@ -0,0 +1,55 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCheckNewSets(t *testing.T) {
+	sets := []string{`{"object": "set", "code": "lea", "released_at": "1993-08-05"}`}
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s]}`, strings.Join(sets, ", "))
+	})
+	ctx := context.Background()
+	for _, query := range []string{"t:elf", "s:lea t:elf", "s:new", "set:lea or t:elf"} {
+		if err := sb.cacheQuery(ctx, query, []string{}); err != nil {
+			t.Fatalf("Failed to cache query: %v", err)
+		}
+	}
+
+	releases, err := sb.CheckNewSets(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(releases.Released) != 0 || len(releases.Invalidated) != 0 {
+		t.Errorf("Expected the first check to only record sets, got %+v", releases)
+	}
+
+	sets = append(sets,
+		`{"object": "set", "code": "NEW", "released_at": "2020-01-01"}`,
+		`{"object": "set", "code": "fut", "released_at": "2999-01-01"}`)
+	releases, err = sb.CheckNewSets(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := &SetReleases{
+		Released:    []string{"new"},
+		Invalidated: []string{"s:new", "set:lea or t:elf", "t:elf"},
+	}
+	if !reflect.DeepEqual(releases, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, releases)
+	}
+	if _, err := sb.FetchCardsByQuery(ctx, "s:lea t:elf"); err != nil {
+		t.Errorf("Expected the query pinned to lea to stay cached, got %v", err)
+	}
+
+	// The announced set counts once its release date has passed.
+	sets[2] = `{"object": "set", "code": "fut", "released_at": "2021-01-01"}`
+	if releases, err = sb.CheckNewSets(ctx); err != nil || !reflect.DeepEqual(releases.Released, []string{"fut"}) {
+		t.Errorf("Expected fut to be released, got %+v, %v", releases, err)
+	}
+}
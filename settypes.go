@@ -0,0 +1,75 @@
+package scryball
+
+import "slices"
+
+// SetType is the type of the set a printing is in, as Scryfall names it.
+type SetType string
+
+const (
+	SetTypeCore            SetType = "core"             // A yearly Magic core set
+	SetTypeExpansion       SetType = "expansion"        // A rotational expansion set in a block
+	SetTypeMasters         SetType = "masters"          // A reprint set that contains no new cards
+	SetTypeAlchemy         SetType = "alchemy"          // An Arena set designed for Alchemy
+	SetTypeMasterpiece     SetType = "masterpiece"      // Masterpiece Series premium foil cards
+	SetTypeArsenal         SetType = "arsenal"          // A Commander-oriented gift set
+	SetTypeFromTheVault    SetType = "from_the_vault"   // From the Vault gift sets
+	SetTypeSpellbook       SetType = "spellbook"        // Spellbook series gift sets
+	SetTypePremiumDeck     SetType = "premium_deck"     // Premium Deck Series decks
+	SetTypeDuelDeck        SetType = "duel_deck"        // Duel Decks
+	SetTypeDraftInnovation SetType = "draft_innovation" // Special draft sets, like Conspiracy and Battlebond
+	SetTypeTreasureChest   SetType = "treasure_chest"   // Magic Online treasure chest prize sets
+	SetTypeCommander       SetType = "commander"        // Commander preconstructed decks
+	SetTypePlanechase      SetType = "planechase"       // Planechase sets
+	SetTypeArchenemy       SetType = "archenemy"        // Archenemy sets
+	SetTypeVanguard        SetType = "vanguard"         // Vanguard card sets
+	SetTypeFunny           SetType = "funny"            // Un-sets and sets of funny promos
+	SetTypeStarter         SetType = "starter"          // Starter and introductory sets, like Portal
+	SetTypeBox             SetType = "box"              // A gift box set
+	SetTypePromo           SetType = "promo"            // A set of purely promotional cards
+	SetTypeToken           SetType = "token"            // A set of tokens and emblems
+	SetTypeMemorabilia     SetType = "memorabilia"      // Gold-bordered, oversized or trophy cards that are not legal
+	SetTypeMinigame        SetType = "minigame"         // Minigame card inserts from booster packs
+)
+
+// NonTournamentSetTypes are the set types most tools leave out: funny,
+// memorabilia, token and promo sets.
+//
+// Example:
+//
+//	printings := card.Printings.ExcludeSetTypes(scryball.NonTournamentSetTypes...)
+var NonTournamentSetTypes = []SetType{SetTypeFunny, SetTypeMemorabilia, SetTypeToken, SetTypePromo}
+
+// Printings is the list of printings of a card, most recent first.
+type Printings []Printing
+
+// ExcludeSetTypes returns the printings whose set is none of types, in their original order.
+//
+// Printings without a set type, like ones cached before set types were stored, are kept.
+func (p Printings) ExcludeSetTypes(types ...SetType) Printings {
+	kept := Printings{}
+	for _, printing := range p {
+		if !slices.Contains(types, printing.SetType) {
+			kept = append(kept, printing)
+		}
+	}
+	return kept
+}
+
+// ExcludeSetTypes returns the cards with at least one printing whose set is
+// none of types, in their original order. Use it to drop tokens, un-cards and
+// memorabilia from query results:
+//
+//	cards, _ := scryball.Query("t:goblin")
+//	cards = scryball.ExcludeSetTypes(cards, scryball.NonTournamentSetTypes...)
+//
+// Cards are returned as they are, their Printings still list every printing;
+// see Printings.ExcludeSetTypes to filter those. Cards without printings are
+// judged by the set type of the card itself.
+func ExcludeSetTypes(cards []*MagicCard, types ...SetType) []*MagicCard {
+	return Filter(cards, func(card *MagicCard) bool {
+		if len(card.Printings) == 0 {
+			return card.Card == nil || !slices.Contains(types, SetType(card.SetType))
+		}
+		return len(card.Printings.ExcludeSetTypes(types...)) > 0
+	})
+}
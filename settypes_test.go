@@ -0,0 +1,41 @@
+package scryball
+
+import "testing"
+
+func TestExcludeSetTypes(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	goblin := testAPICard("goblin", "goblin-1", "Goblin Guide", "Creature — Goblin Scout")
+	goblin.SetType = "expansion"
+	promo := testAPICard("goblin", "goblin-2", "Goblin Guide", "Creature — Goblin Scout")
+	promo.SetType = "promo"
+	token := testAPICard("goblin-token", "goblin-token-1", "Goblin", "Token Creature — Goblin")
+	token.SetType = "token"
+
+	guide := insertTestCard(t, sb, goblin, goblin, promo)
+	goblinToken := insertTestCard(t, sb, token)
+
+	cached, err := sb.FetchCardByExactName(t.Context(), "Goblin Guide")
+	if err != nil {
+		t.Fatalf("FetchCardByExactName failed: %v", err)
+	}
+	if len(cached.Printings) != 2 {
+		t.Fatalf("Expected 2 printings, got %d", len(cached.Printings))
+	}
+	printings := cached.Printings.ExcludeSetTypes(NonTournamentSetTypes...)
+	if len(printings) != 1 || printings[0].ID != "goblin-1" || printings[0].SetType != SetTypeExpansion {
+		t.Errorf("Expected only the expansion printing, got %+v", printings)
+	}
+	if kept := cached.Printings.ExcludeSetTypes(); len(kept) != 2 {
+		t.Errorf("Expected no types to keep every printing, got %d", len(kept))
+	}
+
+	cards := ExcludeSetTypes([]*MagicCard{goblinToken, guide}, NonTournamentSetTypes...)
+	if len(cards) != 1 || cards[0] != guide {
+		t.Errorf("Expected only Goblin Guide, got %v", cards)
+	}
+	if len(guide.Printings) != 2 {
+		t.Error("ExcludeSetTypes should not change the printings of the cards it keeps")
+	}
+}
@@ -0,0 +1,76 @@
+package scryball
+
+import "math/rand/v2"
+
+// OpeningHandSize is the number of cards dealt by SampleHand.
+const OpeningHandSize = 7
+
+// Shuffle returns the maindeck as a shuffled library, one entry per copy.
+//
+// A nil rng uses the default random source. Pass a seeded rng for
+// reproducible results:
+//
+//	rng := rand.New(rand.NewPCG(1, 2))
+//	library := deck.Shuffle(rng)
+func (d *Decklist) Shuffle(rng *rand.Rand) []*MagicCard {
+	library := d.GetMaindeck()
+	shuffle := rand.Shuffle
+	if rng != nil {
+		shuffle = rng.Shuffle
+	}
+	shuffle(len(library), func(i, j int) {
+		library[i], library[j] = library[j], library[i]
+	})
+	return library
+}
+
+// SampleHand shuffles the maindeck and deals an opening hand of OpeningHandSize cards.
+//
+// Returns the whole maindeck, shuffled, if it has fewer cards than a hand.
+// A nil rng uses the default random source.
+//
+// Example:
+//
+//	for _, card := range deck.SampleHand(nil) {
+//		fmt.Println(card.Name)
+//	}
+func (d *Decklist) SampleHand(rng *rand.Rand) []*MagicCard {
+	library := d.Shuffle(rng)
+	return library[:min(OpeningHandSize, len(library))]
+}
+
+// Simulate deals n opening hands and returns the fraction for which condition is true.
+//
+// Each hand is dealt from a freshly shuffled maindeck, so this estimates
+// the chance of an opening hand meeting condition, for example keepable
+// hands when evaluating mulligans. Returns 0 if n is not positive.
+//
+// Example:
+//
+//	// chance of 2 to 4 lands in the opening hand
+//	keepable := deck.Simulate(10000, func(hand []*MagicCard) bool {
+//		lands := 0
+//		for _, card := range hand {
+//			if strings.Contains(card.TypeLine, "Land") {
+//				lands++
+//			}
+//		}
+//		return lands >= 2 && lands <= 4
+//	})
+func (d *Decklist) Simulate(n int, condition func(hand []*MagicCard) bool) float64 {
+	if n <= 0 {
+		return 0
+	}
+
+	library := d.GetMaindeck()
+	hits := 0
+	for range n {
+		rand.Shuffle(len(library), func(i, j int) {
+			library[i], library[j] = library[j], library[i]
+		})
+		if condition(library[:min(OpeningHandSize, len(library))]) {
+			hits++
+		}
+	}
+	return float64(hits) / float64(n)
+}
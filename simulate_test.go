@@ -0,0 +1,70 @@
+package scryball
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+)
+
+func TestDecklistSampleHand(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 20)
+
+	hand := deck.SampleHand(rand.New(rand.NewPCG(1, 2)))
+	if len(hand) != OpeningHandSize {
+		t.Fatalf("Expected %d cards in hand, got %d", OpeningHandSize, len(hand))
+	}
+
+	again := deck.SampleHand(rand.New(rand.NewPCG(1, 2)))
+	if !reflect.DeepEqual(hand, again) {
+		t.Error("Expected the same seed to deal the same hand")
+	}
+
+	library := deck.Shuffle(nil)
+	counts := map[*MagicCard]int{}
+	for _, card := range library {
+		counts[card]++
+	}
+	if counts[bolt] != 4 || counts[mountain] != 20 {
+		t.Errorf("Expected shuffled library to keep quantities, got %v", counts)
+	}
+
+	small := NewDecklist()
+	small.AddCard(bolt, 3)
+	if hand := small.SampleHand(nil); len(hand) != 3 {
+		t.Errorf("Expected whole 3 card deck as hand, got %d cards", len(hand))
+	}
+}
+
+func TestDecklistSimulate(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 30)
+	deck.AddCard(mountain, 30)
+
+	hasBolt := func(hand []*MagicCard) bool {
+		for _, card := range hand {
+			if card == bolt {
+				return true
+			}
+		}
+		return false
+	}
+
+	// 1 - C(30,7)/C(60,7) ≈ 0.9950
+	if p := deck.Simulate(2000, hasBolt); p < 0.97 {
+		t.Errorf("Expected almost every hand to have a Lightning Bolt, got %.3f", p)
+	}
+	if p := deck.Simulate(100, func([]*MagicCard) bool { return false }); p != 0 {
+		t.Errorf("Expected 0 for a condition that is never true, got %.3f", p)
+	}
+	if p := deck.Simulate(0, hasBolt); p != 0 {
+		t.Errorf("Expected 0 for no simulations, got %.3f", p)
+	}
+}
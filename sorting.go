@@ -0,0 +1,84 @@
+package scryball
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Sorting functions sort cards in place and are stable: cards that compare
+// equal keep their order, so sorts can be chained. Sort by the least important
+// key first:
+//
+//	scryball.SortByName(cards)
+//	scryball.SortByCMC(cards) // by mana value, then by name
+
+// SortByName sorts cards in place by name, ignoring case.
+func SortByName(cards []*MagicCard) {
+	slices.SortStableFunc(cards, func(a, b *MagicCard) int {
+		return strings.Compare(strings.ToLower(cardName(a)), strings.ToLower(cardName(b)))
+	})
+}
+
+// SortByCMC sorts cards in place by mana value, lowest first.
+func SortByCMC(cards []*MagicCard) {
+	slices.SortStableFunc(cards, func(a, b *MagicCard) int {
+		return cmp.Compare(cardCMC(a), cardCMC(b))
+	})
+}
+
+// SortByReleaseDate sorts cards in place by release date (see MagicCard.ReleaseDate),
+// oldest first. Cards without a release date go last.
+func SortByReleaseDate(cards []*MagicCard) {
+	slices.SortStableFunc(cards, func(a, b *MagicCard) int {
+		dateA, dateB := a.ReleaseDate(), b.ReleaseDate()
+		switch {
+		case !dateA.IsZero() && !dateB.IsZero():
+			return dateA.Compare(dateB)
+		case !dateA.IsZero():
+			return -1
+		case !dateB.IsZero():
+			return 1
+		}
+		return 0
+	})
+}
+
+// SortByPrice sorts cards in place by price in currency (see MagicCard.Price),
+// cheapest first. Cards without a price go last.
+//
+// Example:
+//
+//	scryball.SortByPrice(cards, "usd")
+//	slices.Reverse(cards) // most expensive first, cards without a price first too
+func SortByPrice(cards []*MagicCard, currency string) {
+	slices.SortStableFunc(cards, func(a, b *MagicCard) int {
+		priceA, okA := a.Price(currency)
+		priceB, okB := b.Price(currency)
+		switch {
+		case okA && okB:
+			return cmp.Compare(priceA, priceB)
+		case okA:
+			return -1
+		case okB:
+			return 1
+		}
+		return 0
+	})
+}
+
+// cardName returns the card's name, "" for a card without data.
+func cardName(card *MagicCard) string {
+	if card.Card == nil {
+		return ""
+	}
+	return card.Name
+}
+
+// cardCMC returns the card's mana value, 0 for a card without data.
+func cardCMC(card *MagicCard) float64 {
+	if card.Card == nil {
+		return 0
+	}
+	return card.CMC
+}
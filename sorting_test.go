@@ -0,0 +1,81 @@
+package scryball
+
+import (
+	"reflect"
+	"testing"
+)
+
+func cardNames(cards []*MagicCard) []string {
+	names := []string{}
+	for _, card := range cards {
+		names = append(names, card.Name)
+	}
+	return names
+}
+
+func TestSortCards(t *testing.T) {
+	cards := []*MagicCard{
+		testCard("Opt", "Opt", "Instant", withCMC(1), withReleasedAt("2017-09-29"), withPrice("usd", "0.10")),
+		testCard("counterspell", "counterspell", "Instant", withCMC(2), withReleasedAt("1993-08-05")),
+		testCard("Brainstorm", "Brainstorm", "Instant", withCMC(1), withReleasedAt(""), withPrice("usd", "1.50")),
+		testCard("Ancestral Recall", "Ancestral Recall", "Instant", withCMC(1), withReleasedAt("1993-08-05"), withPrice("usd", "4000.00")),
+	}
+
+	SortByName(cards)
+	if expected := []string{"Ancestral Recall", "Brainstorm", "counterspell", "Opt"}; !reflect.DeepEqual(cardNames(cards), expected) {
+		t.Errorf("SortByName: expected %v, got %v", expected, cardNames(cards))
+	}
+
+	SortByCMC(cards)
+	if expected := []string{"Ancestral Recall", "Brainstorm", "Opt", "counterspell"}; !reflect.DeepEqual(cardNames(cards), expected) {
+		t.Errorf("SortByCMC: expected %v, got %v", expected, cardNames(cards))
+	}
+
+	SortByReleaseDate(cards)
+	if expected := []string{"Ancestral Recall", "counterspell", "Opt", "Brainstorm"}; !reflect.DeepEqual(cardNames(cards), expected) {
+		t.Errorf("SortByReleaseDate: expected %v, got %v", expected, cardNames(cards))
+	}
+
+	SortByPrice(cards, "usd")
+	if expected := []string{"Opt", "Brainstorm", "Ancestral Recall", "counterspell"}; !reflect.DeepEqual(cardNames(cards), expected) {
+		t.Errorf("SortByPrice: expected %v, got %v", expected, cardNames(cards))
+	}
+}
+
+func TestFilterCards(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt", "bolt-1", "Lightning Bolt", "Instant")}
+	bolt.Colors = []string{"R"}
+	bolt.Set = "lea"
+	elf := &MagicCard{Card: testAPICard("elf", "elf-1", "Bloodbraid Elf", "Creature — Elf Berserker")}
+	elf.Colors = []string{"R", "G"}
+	elf.Rarity = "uncommon"
+	ring := &MagicCard{Card: testAPICard("ring", "ring-1", "Sol Ring", "Artifact")}
+	ring.Printings = []Printing{{SetCode: "LEA", Rarity: RarityUncommon}}
+	forest := &MagicCard{Card: testAPICard("forest", "forest-1", "Forest", "Basic Land — Forest")}
+	forest.Colors = []string{}
+	cards := []*MagicCard{bolt, elf, ring, forest}
+
+	tests := []struct {
+		name     string
+		got      []*MagicCard
+		expected []string
+	}{
+		{"red", FilterByColor(cards, ColorRed), []string{"Lightning Bolt", "Bloodbraid Elf"}},
+		{"colorless", FilterByColor(cards, ColorColorless), []string{"Sol Ring", "Forest"}},
+		{"type", FilterByType(cards, "elf"), []string{"Bloodbraid Elf"}},
+		{"supertype and type", FilterByType(cards, "Basic Land"), []string{"Forest"}},
+		{"partial word", FilterByType(cards, "Ber"), []string{}},
+		{"rarity", FilterByRarity(cards, RarityUncommon), []string{"Bloodbraid Elf", "Sol Ring"}},
+		{"set", FilterBySet(cards, "LEA"), []string{"Lightning Bolt", "Sol Ring"}},
+	}
+	for _, tt := range tests {
+		if names := cardNames(tt.got); !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, names)
+		}
+	}
+
+	lands, nonlands := PartitionLands(cards)
+	if !reflect.DeepEqual(cardNames(lands), []string{"Forest"}) || len(nonlands) != 3 {
+		t.Errorf("PartitionLands: got %v and %v", cardNames(lands), cardNames(nonlands))
+	}
+}
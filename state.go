@@ -13,9 +13,9 @@ import (
 )
 
 var (
-	// Global singleton state
+	// Global singleton state, guarded by mu.
+	// Replace it with SetConfig or ResetToDefaults rather than assigning it directly.
 	CurrentScryball *Scryball
-	initOnce        sync.Once
 	mu              sync.RWMutex
 
 	baseClientOptions = client.ClientOptions{
@@ -23,56 +23,46 @@ var (
 		UserAgent: "MTGScryball/1.0",
 		Accept:    "application/json;q=0.9,*/*;q=0.8",
 		Client:    &http.Client{},
+		Timeout:   client.DefaultTimeout,
 	}
 )
 
+// ensureCurrentScryball returns the global instance, creating the default
+// in-memory instance if there is none. A failed creation is retried on the next call.
 func ensureCurrentScryball() (*Scryball, error) {
-	var topError error
-	initOnce.Do(func() {
-		mu.Lock()
-		defer mu.Unlock()
-		if CurrentScryball == nil {
-			newInstance, err := createDefaultInstance()
-			if err != nil {
-				topError = err
-				return
-			}
-			CurrentScryball = newInstance
+	mu.RLock()
+	current := CurrentScryball
+	mu.RUnlock()
+	if current != nil {
+		return current, nil
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if CurrentScryball == nil {
+		newInstance, err := NewWithConfig(ScryballConfig{})
+		if err != nil {
+			return nil, err
 		}
-	})
-	if topError != nil {
-		return nil, topError
+		CurrentScryball = newInstance
 	}
 	return CurrentScryball, nil
 }
 
-func createDefaultInstance() (*Scryball, error) {
-	db, err := sql.Open("sqlite", ":memory:")
-	if err != nil {
-		fmt.Printf("Failed to create in-memory database: %v\n", err)
-		return nil, err
-	}
+// replaceCurrentScryball swaps the global instance for next and closes the previous one.
+func replaceCurrentScryball(next *Scryball) error {
+	mu.Lock()
+	previous := CurrentScryball
+	CurrentScryball = next
+	mu.Unlock()
 
-	if _, err := db.Exec(embeddedSchema); err != nil {
-		db.Close()
-		fmt.Printf("Failed to apply embedded schema: %v\n", err)
-		return nil, err
+	if previous == nil || previous == next {
+		return nil
 	}
-
-	scryballDB := &ScryballDB{DB: db}
-	queries := scryfall.New(db)
-
-	cClient, err := client.NewClientWithOptions(baseClientOptions)
-	if err != nil {
-		fmt.Printf("Failed to create client: %v\n", err)
-		return nil, err
+	if err := previous.Close(); err != nil {
+		return fmt.Errorf("failed to close previous scryball: %v", err)
 	}
-
-	return &Scryball{
-		db:      scryballDB,
-		client:  cClient,
-		queries: queries,
-	}, nil
+	return nil
 }
 
 func convertAPICardToDBParams(card *client.Card) (scryfall.UpsertCardParams, scryfall.UpsertPrintingParams, error) {
@@ -0,0 +1,232 @@
+package scryball
+
+import "strings"
+
+// DeckStats summarizes a decklist's maindeck, see Decklist.Stats.
+//
+// All counts are per copy, so 4 Lightning Bolt adds 4 to each count it touches.
+type DeckStats struct {
+	Cards    int // Total maindeck cards
+	Lands    int // Maindeck cards with Land on their front face
+	Nonlands int // Maindeck cards without Land on their front face
+
+	ManaCurve        map[int]int // Mana value to number of nonland cards
+	Curve            Curve       // ManaCurve bucketed 0 to 7+, for printing with RenderASCII
+	AverageManaValue float64     // Average mana value of nonland cards, 0 if there are none
+
+	// Card type ("Creature", "Instant", "Land", ...) to number of cards with that type.
+	// Cards with several types ("Artifact Creature") count towards each of them.
+	Types map[string]int
+
+	// Color ("W", "U", "B", "R", "G", "C") to number of mana symbols of that
+	// color in nonland mana costs. Hybrid symbols count towards both colors.
+	ColorPips map[string]int
+
+	// Color ("W", "U", "B", "R", "G") to number of nonland cards of that color,
+	// with "C" for colorless cards. Multicolored cards count towards each of their colors.
+	Colors map[string]int
+}
+
+// cardTypes are the card types Stats and GroupByType report, in display order.
+var cardTypes = []string{
+	"Creature", "Planeswalker", "Battle", "Instant", "Sorcery",
+	"Artifact", "Enchantment", "Kindred", "Land",
+}
+
+// Stats computes mana curve, type and color statistics for the maindeck.
+//
+// Behavior:
+//   - Uses the card data already on each MagicCard, never queries API
+//   - Double-faced cards are counted by their front face ("Creature // Land" is a creature)
+//   - Lands are left out of the curve, average mana value, pips and colors
+//   - Commanders, Companion and Sideboard are not included
+//
+// Example:
+//
+//	stats := deck.Stats()
+//	fmt.Printf("%d lands, average mana value %.2f\n", stats.Lands, stats.AverageManaValue)
+//	fmt.Print(stats.Curve.RenderASCII(40))
+func (d *Decklist) Stats() DeckStats {
+	stats := DeckStats{
+		ManaCurve: make(map[int]int),
+		Types:     make(map[string]int),
+		ColorPips: make(map[string]int),
+		Colors:    make(map[string]int),
+	}
+
+	var totalManaValue float64
+	for card, qty := range d.Maindeck {
+		stats.Cards += qty
+
+		types := frontFaceTypes(card)
+		for _, cardType := range types {
+			stats.Types[cardType] += qty
+		}
+
+		if isLand(card) {
+			stats.Lands += qty
+			continue
+		}
+		stats.Nonlands += qty
+
+		stats.ManaCurve[int(card.CMC)] += qty
+		totalManaValue += card.CMC * float64(qty)
+
+		for _, symbol := range manaSymbols(cardManaCost(card)) {
+			for _, color := range symbolColors(symbol) {
+				stats.ColorPips[color] += qty
+			}
+		}
+
+		colors := cardColors(card)
+		if len(colors) == 0 {
+			stats.Colors["C"] += qty
+		}
+		for _, color := range colors {
+			stats.Colors[string(color)] += qty
+		}
+	}
+
+	stats.Curve = CurveOf(stats.ManaCurve)
+
+	if stats.Nonlands > 0 {
+		stats.AverageManaValue = totalManaValue / float64(stats.Nonlands)
+	}
+
+	return stats
+}
+
+// frontFaceTypes returns the card types on the front face's type line.
+func frontFaceTypes(card *MagicCard) []string {
+	typeLine, _, _ := strings.Cut(card.TypeLine, " // ")
+	supertypesAndTypes, _, _ := strings.Cut(typeLine, " — ")
+
+	var types []string
+	for _, word := range strings.Fields(supertypesAndTypes) {
+		if word == "Tribal" {
+			word = "Kindred" // renamed in 2024, older cached data may still say Tribal
+		}
+		for _, cardType := range cardTypes {
+			if word == cardType {
+				types = append(types, cardType)
+			}
+		}
+	}
+	return types
+}
+
+// isLand reports whether the card's front face is a land.
+func isLand(card *MagicCard) bool {
+	for _, cardType := range frontFaceTypes(card) {
+		if cardType == "Land" {
+			return true
+		}
+	}
+	return false
+}
+
+// cardManaCost returns the card's mana cost, falling back to the front face for double-faced cards.
+func cardManaCost(card *MagicCard) string {
+	if card.ManaCost != nil {
+		return *card.ManaCost
+	}
+	if len(card.CardFaces) > 0 {
+		return card.CardFaces[0].ManaCost
+	}
+	return ""
+}
+
+// manaSymbols splits a mana cost like "{2}{W/U}{R}" into its symbols: "2", "W/U", "R".
+func manaSymbols(cost string) []string {
+	var symbols []string
+	for {
+		start := strings.Index(cost, "{")
+		if start == -1 {
+			return symbols
+		}
+		end := strings.Index(cost[start:], "}")
+		if end == -1 {
+			return symbols
+		}
+		symbols = append(symbols, cost[start+1:start+end])
+		cost = cost[start+end+1:]
+	}
+}
+
+// symbolColors returns the colors a mana symbol needs, "C" for colorless mana.
+// Generic, X and snow symbols need no color.
+func symbolColors(symbol string) []string {
+	var colors []string
+	for _, part := range strings.Split(symbol, "/") {
+		switch part {
+		case "W", "U", "B", "R", "G", "C":
+			colors = append(colors, part)
+		}
+	}
+	return colors
+}
+
+// typeGroups is the order GroupByType checks card types in; a card goes in
+// the first group it has the type for, so an Artifact Creature is a Creature.
+var typeGroups = []string{
+	"Land", "Creature", "Planeswalker", "Battle", "Instant", "Sorcery",
+	"Artifact", "Enchantment",
+}
+
+// GroupByType splits the maindeck into one bucket per card type.
+//
+// Behavior:
+//   - Keys are "Land", "Creature", "Planeswalker", "Battle", "Instant", "Sorcery",
+//     "Artifact", "Enchantment" and "Other" for anything else
+//   - Each card is in exactly one bucket, the first of those types it has,
+//     so an Artifact Creature is a Creature and Dryad Arbor is a Land
+//   - Double-faced cards are grouped by their front face
+//   - Empty buckets are left out
+//
+// Example:
+//
+//	groups := deck.GroupByType()
+//	for card, qty := range groups["Creature"] {
+//		fmt.Printf("%d %s\n", qty, card.Name)
+//	}
+func (d *Decklist) GroupByType() map[string]map[*MagicCard]int {
+	groups := make(map[string]map[*MagicCard]int)
+	for card, qty := range d.Maindeck {
+		group := typeGroup(card)
+		if groups[group] == nil {
+			groups[group] = make(map[*MagicCard]int)
+		}
+		groups[group][card] = qty
+	}
+	return groups
+}
+
+func typeGroup(card *MagicCard) string {
+	types := frontFaceTypes(card)
+	for _, group := range typeGroups {
+		for _, cardType := range types {
+			if cardType == group {
+				return group
+			}
+		}
+	}
+	return "Other"
+}
+
+// LandCount returns the number of lands in the maindeck.
+//
+// Double-faced cards count as lands only if their front face is a land.
+func (d *Decklist) LandCount() int {
+	total := 0
+	for card, qty := range d.Maindeck {
+		if isLand(card) {
+			total += qty
+		}
+	}
+	return total
+}
+
+// NonlandCount returns the number of nonland cards in the maindeck.
+func (d *Decklist) NonlandCount() int {
+	return d.NumberOfCards() - d.LandCount()
+}
@@ -0,0 +1,120 @@
+package scryball
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestDecklistStats(t *testing.T) {
+	deck := NewDecklist()
+	deck.AddCard(testCard("bolt", "Lightning Bolt", "Instant", withManaCost("{R}"), withCMC(1), withColors("R")), 4)
+	deck.AddCard(testCard("goyf", "Tarmogoyf", "Creature — Lhurgoyf", withManaCost("{1}{G}"), withCMC(2), withColors("G")), 4)
+	deck.AddCard(testCard("helix", "Lightning Helix", "Instant", withManaCost("{R}{W}"), withCMC(2), withColors("R", "W")), 2)
+	deck.AddCard(testCard("manamorphose", "Manamorphose", "Instant", withManaCost("{1}{R/G}"), withCMC(2), withColors("R", "G")), 1)
+	deck.AddCard(testCard("ornithopter", "Ornithopter", "Artifact Creature — Thopter", withManaCost("{0}")), 1)
+	deck.AddCard(testCard("mountain", "Mountain", "Basic Land — Mountain"), 8)
+
+	dfc := testAPICard("dfc", "dfc-1", "Emeria's Call // Emeria, Shattered Skyclave", "Sorcery // Land")
+	dfc.CMC = 7
+	dfc.Colors = []string{"W"}
+	dfc.CardFaces = []client.CardFace{{ManaCost: "{4}{W}{W}{W}"}, {ManaCost: ""}}
+	deck.AddCard(&MagicCard{Card: dfc}, 1)
+
+	// transform cards have no colors of their own, only their faces do
+	delver := testAPICard("delver", "delver-1", "Delver of Secrets // Insectile Aberration", "Creature — Human Wizard // Creature — Human Insect")
+	delver.CMC = 1
+	delver.CardFaces = []client.CardFace{{ManaCost: "{U}", Colors: []string{"U"}}, {Colors: []string{"U"}}}
+	deck.AddCard(&MagicCard{Card: delver}, 2)
+
+	deck.AddSideboardCard(testCard("pyro", "Pyroblast", "Instant", withManaCost("{R}"), withCMC(1), withColors("R")), 3)
+
+	stats := deck.Stats()
+
+	if stats.Cards != 23 || stats.Lands != 8 || stats.Nonlands != 15 {
+		t.Errorf("Expected 23 cards, 8 lands, 15 nonlands, got %d, %d, %d", stats.Cards, stats.Lands, stats.Nonlands)
+	}
+
+	expectedCurve := map[int]int{0: 1, 1: 6, 2: 7, 7: 1}
+	if !reflect.DeepEqual(stats.ManaCurve, expectedCurve) {
+		t.Errorf("Expected curve %v, got %v", expectedCurve, stats.ManaCurve)
+	}
+
+	expectedAverage := (6*1 + 7*2 + 7.0) / 15
+	if math.Abs(stats.AverageManaValue-expectedAverage) > 1e-9 {
+		t.Errorf("Expected average mana value %.3f, got %.3f", expectedAverage, stats.AverageManaValue)
+	}
+
+	expectedTypes := map[string]int{"Instant": 7, "Creature": 7, "Artifact": 1, "Land": 8, "Sorcery": 1}
+	if !reflect.DeepEqual(stats.Types, expectedTypes) {
+		t.Errorf("Expected types %v, got %v", expectedTypes, stats.Types)
+	}
+
+	expectedPips := map[string]int{"R": 4 + 2 + 1, "G": 4 + 1, "W": 2 + 3, "U": 2}
+	if !reflect.DeepEqual(stats.ColorPips, expectedPips) {
+		t.Errorf("Expected pips %v, got %v", expectedPips, stats.ColorPips)
+	}
+
+	expectedColors := map[string]int{"R": 4 + 2 + 1, "G": 4 + 1, "W": 2 + 1, "U": 2, "C": 1}
+	if !reflect.DeepEqual(stats.Colors, expectedColors) {
+		t.Errorf("Expected colors %v, got %v", expectedColors, stats.Colors)
+	}
+}
+
+func TestManaSymbols(t *testing.T) {
+	tests := []struct {
+		cost     string
+		expected []string
+	}{
+		{"{2}{W/U}{R}", []string{"2", "W/U", "R"}},
+		{"{X}{G/P}", []string{"X", "G/P"}},
+		{"{1}{R} // {1}{U}", []string{"1", "R", "1", "U"}},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		if got := manaSymbols(tt.cost); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("manaSymbols(%q) = %v, expected %v", tt.cost, got, tt.expected)
+		}
+	}
+}
+
+func TestDecklistGroupByType(t *testing.T) {
+	bolt := testCard("bolt", "Lightning Bolt", "Instant", withManaCost("{R}"), withCMC(1), withColors("R"))
+	ornithopter := testCard("ornithopter", "Ornithopter", "Artifact Creature — Thopter", withManaCost("{0}"))
+	arbor := testCard("arbor", "Dryad Arbor", "Land Creature — Forest Dryad", withColors("G"))
+	mountain := testCard("mountain", "Mountain", "Basic Land — Mountain")
+	bauble := testCard("bauble", "Mishra's Bauble", "Artifact", withManaCost("{0}"))
+	kindred := testCard("crib", "Crib Swap", "Kindred Instant — Shapeshifter", withManaCost("{2}{W}"), withCMC(3), withColors("W"))
+	conspiracy := testCard("conspiracy", "Backup Plan", "Conspiracy")
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(ornithopter, 2)
+	deck.AddCard(arbor, 1)
+	deck.AddCard(mountain, 16)
+	deck.AddCard(bauble, 4)
+	deck.AddCard(kindred, 1)
+	deck.AddCard(conspiracy, 1)
+
+	groups := deck.GroupByType()
+	expected := map[string]map[*MagicCard]int{
+		"Instant":  {bolt: 4, kindred: 1},
+		"Creature": {ornithopter: 2},
+		"Land":     {arbor: 1, mountain: 16},
+		"Artifact": {bauble: 4},
+		"Other":    {conspiracy: 1},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Unexpected groups: %v", groups)
+	}
+
+	if deck.LandCount() != 17 {
+		t.Errorf("Expected 17 lands, got %d", deck.LandCount())
+	}
+	if deck.NonlandCount() != 12 {
+		t.Errorf("Expected 12 nonland cards, got %d", deck.NonlandCount())
+	}
+}
@@ -0,0 +1,63 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SearchText finds cached cards whose name or rules text contains text, using
+// the database's full-text index instead of scanning every card.
+//
+// Behavior:
+//   - Searches every card in the database, including bulk imported ones
+//   - text is matched as a phrase: "draw a card" needs those words in that order
+//   - Words match their other forms: "draw" also finds "draws" and "drawing"
+//   - Case and accents are ignored
+//   - The text of every face of double-faced and split cards is searched
+//   - Results are sorted by relevance, best matches first
+//
+// Returns:
+//   - []*MagicCard: Matching cards (empty array if no matches)
+//   - error: Empty text or database errors
+//
+// Example:
+//
+//	cards, err := scryball.SearchText("draw a card")
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func SearchText(text string) ([]*MagicCard, error) {
+	return SearchTextWithContext(context.Background(), text)
+}
+
+// SearchTextWithContext finds cached cards by name or rules text with context support.
+// See SearchText.
+func SearchTextWithContext(ctx context.Context, text string) ([]*MagicCard, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.SearchTextWithContext(ctx, text)
+}
+
+// SearchText finds this instance's cached cards by name or rules text. See SearchText.
+func (sb *Scryball) SearchText(text string) ([]*MagicCard, error) {
+	return sb.SearchTextWithContext(context.Background(), text)
+}
+
+// SearchTextWithContext finds this instance's cached cards by name or rules
+// text with context support. See SearchText.
+func (sb *Scryball) SearchTextWithContext(ctx context.Context, text string) ([]*MagicCard, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("search text cannot be empty")
+	}
+	return sb.cardsFromQuery(ctx, `SELECT c.oracle_id FROM cards_fts
+		JOIN cards c ON c.rowid = cards_fts.rowid
+		WHERE cards_fts MATCH ?
+		ORDER BY cards_fts.rank, c.name`, ftsPhrase(text))
+}
+
+// ftsPhrase quotes text as an FTS5 phrase, so its punctuation is not read as query syntax.
+func ftsPhrase(text string) string {
+	return `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+}
@@ -0,0 +1,73 @@
+package scryball
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSearchText(t *testing.T) {
+	sb := testLocalQueryDB(t)
+
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"deals 3 damage", []string{"Lightning Bolt"}},
+		{"DEAL 3 DAMAGE", []string{"Lightning Bolt"}},
+		{"tarmogoyf", []string{"Tarmogoyf"}},
+		{"top card of your library", []string{"Delver of Secrets // Insectile Aberration"}},
+		{"flying", []string{"Serra Angel", "Delver of Secrets // Insectile Aberration"}},
+		{"3 damage any target", []string{}},
+		{`"{T}: Add`, []string{"Sol Ring"}},
+		{"counter target spell", []string{}},
+	}
+	for _, tt := range tests {
+		cards, err := sb.SearchText(tt.text)
+		if err != nil {
+			t.Errorf("SearchText(%q) error: %v", tt.text, err)
+			continue
+		}
+		names := []string{}
+		for _, card := range cards {
+			names = append(names, card.Name)
+		}
+		if !reflect.DeepEqual(names, tt.expected) {
+			t.Errorf("SearchText(%q) = %v, want %v", tt.text, names, tt.expected)
+		}
+	}
+
+	if _, err := sb.SearchText("  "); err == nil {
+		t.Error("SearchText with empty text should fail")
+	}
+}
+
+func TestSearchTextIndexSync(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+
+	card := testAPICard("oracle-1", "print-1", "Opt", "Instant")
+	text := "Scry 1. Draw a card."
+	card.OracleText = &text
+	insertTestCard(t, sb, card)
+
+	// updating a card replaces its indexed text
+	text = "Scry 1. Draw two cards."
+	insertTestCard(t, sb, card)
+	if cards, _ := sb.SearchText("draw a card"); len(cards) != 0 {
+		t.Errorf("expected the old text to be gone from the index, found %d cards", len(cards))
+	}
+	if cards, _ := sb.SearchText("draw two cards"); len(cards) != 1 {
+		t.Errorf("expected the new text to be indexed, found %d cards", len(cards))
+	}
+
+	// databases from before the index are filled when the schema is applied
+	if _, err := sb.db.Exec("DELETE FROM cards_fts"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sb.db.Exec(embeddedSchema); err != nil {
+		t.Fatalf("reapplying schema: %v", err)
+	}
+	if cards, _ := sb.SearchText("draw two cards"); len(cards) != 1 {
+		t.Errorf("expected the index to be rebuilt, found %d cards", len(cards))
+	}
+}
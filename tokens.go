@@ -0,0 +1,227 @@
+package scryball
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+// RequiredTokens returns the tokens and emblems the cards in the decklist can create.
+//
+// Behavior:
+//   - Uses each card's related cards (Scryfall's all_parts) with the "token" component
+//   - Covers Maindeck, Sideboard, Commanders and Companion
+//   - Token cards are looked up in the cache first, then the API, and cached like any card
+//   - A nil sb uses the global Scryball instance
+//
+// Returns:
+//   - []*MagicCard: Token and emblem cards without duplicates, sorted by name
+//   - error: Network errors, API errors, or database errors
+//
+// Example:
+//
+//	tokens, err := deck.RequiredTokens(ctx, nil)
+//	for _, token := range tokens {
+//		fmt.Println(token.Name, "-", token.TypeLine) // Treasure - Token Artifact — Treasure
+//	}
+func (d *Decklist) RequiredTokens(ctx context.Context, sb *Scryball) ([]*MagicCard, error) {
+	if sb == nil {
+		var err error
+		sb, err = ensureCurrentScryball()
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize scryball %v", err)
+		}
+	}
+
+	cards := append(sortedCards(d.Maindeck), sortedCards(d.Sideboard)...)
+	cards = append(cards, d.Commanders...)
+	if d.Companion != nil {
+		cards = append(cards, d.Companion)
+	}
+
+	seenParts := make(map[string]bool)
+	seenTokens := make(map[string]bool)
+	var tokens []*MagicCard
+	for _, card := range cards {
+		for _, part := range card.AllParts {
+			if part.Component != "token" || seenParts[part.ID] {
+				continue
+			}
+			seenParts[part.ID] = true
+
+			token, err := sb.findCardByPrintingID(ctx, part.ID)
+			if err != nil {
+				return nil, fmt.Errorf("could not get token %s for %s: %v", part.Name, card.Name, err)
+			}
+
+			// different printings of the same token share an oracle ID
+			key := token.Name
+			if token.OracleID != nil {
+				key = *token.OracleID
+			}
+			if seenTokens[key] {
+				continue
+			}
+			seenTokens[key] = true
+			tokens = append(tokens, token)
+		}
+	}
+
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].Name < tokens[j].Name })
+	return tokens, nil
+}
+
+// findCardByPrintingID looks for a card within the database by the Scryfall ID of one of its printings,
+// if not found will fetch from the scryfall API
+func (sb *Scryball) findCardByPrintingID(ctx context.Context, printingID string) (*MagicCard, error) {
+	oracleID, err := sb.queries.GetOracleIDByPrintingID(ctx, printingID)
+	if err == nil {
+		return sb.FetchCardByExactOracleID(ctx, oracleID)
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("database error searching for printing %s: %v", printingID, err)
+	}
+	// printing does not exist, fetch from API
+
+	apiCard, err := sb.client.GetCard(printingID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sb.InsertCardFromAPI(ctx, apiCard)
+}
+
+// tokenQuery restricts a Scryfall query to tokens and emblems. include:extras
+// is Scryfall's include_extras in query syntax, without it tokens never match.
+func tokenQuery(query string) string {
+	return fmt.Sprintf("(t:token or t:emblem) %s include:extras", query)
+}
+
+// QueryToken fetches a token or emblem by exact name, like QueryCard does for cards.
+//
+// Behavior:
+//   - Searches Scryfall's extras, which QueryCard and Query leave out
+//   - Cache hits return the token with all printings and zero API calls
+//   - Emblems are named after their planeswalker: "Elspeth, Sun's Champion Emblem"
+//   - Several tokens share a name, like the Goblin tokens with and without haste;
+//     the first one Scryfall lists is returned, see QueryTokensForSet for a set's own tokens
+//
+// Returns:
+//   - *MagicCard: The token or emblem
+//   - error: No token with that name, network errors, or database errors
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+//
+// Example:
+//
+//	treasure, err := scryball.QueryToken("Treasure")
+//	fmt.Println(treasure.TypeLine) // Token Artifact — Treasure
+func QueryToken(name string) (*MagicCard, error) {
+	return QueryTokenWithContext(context.Background(), name)
+}
+
+// QueryTokenWithContext is QueryToken with context support.
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func QueryTokenWithContext(ctx context.Context, name string) (*MagicCard, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.findToken(ctx, name)
+}
+
+// QueryToken fetches a token or emblem by exact name using this Scryball instance.
+//
+// See the package-level QueryToken for details.
+func (sb *Scryball) QueryToken(name string) (*MagicCard, error) {
+	return sb.findToken(context.Background(), name)
+}
+
+// QueryTokenWithContext fetches a token or emblem by exact name using this Scryball instance with context support.
+func (sb *Scryball) QueryTokenWithContext(ctx context.Context, name string) (*MagicCard, error) {
+	return sb.findToken(ctx, name)
+}
+
+func (sb *Scryball) findToken(ctx context.Context, name string) (*MagicCard, error) {
+	name = strings.TrimSpace(strings.ReplaceAll(name, `"`, ""))
+	if name == "" {
+		return nil, errors.New("token name must not be empty")
+	}
+
+	tokens, err := sb.findQuery(ctx, tokenQuery(fmt.Sprintf(`!"%s"`, name)))
+	if errors.Is(err, client.ErrNotFound) || (err == nil && len(tokens) == 0) {
+		return nil, fmt.Errorf("no token or emblem named %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tokens[0], nil
+}
+
+// QueryTokensForSet returns the tokens and emblems printed for a set.
+//
+// Behavior:
+//   - Scryfall keeps a set's tokens in their own set, "tneo" for "neo";
+//     both are searched, so either code works
+//   - Results are cached like Query results
+//   - Each token includes all its printings, not only the ones of the set
+//
+// Returns:
+//   - []*MagicCard: The set's tokens and emblems, empty if it has none
+//   - error: Network errors, API errors, or database errors
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+//
+// Example:
+//
+//	tokens, err := scryball.QueryTokensForSet("neo")
+func QueryTokensForSet(setCode string) ([]*MagicCard, error) {
+	return QueryTokensForSetWithContext(context.Background(), setCode)
+}
+
+// QueryTokensForSetWithContext is QueryTokensForSet with context support.
+//
+// Note: Uses global Scryball instance. Initialize with SetConfig() or defaults to in-memory DB.
+func QueryTokensForSetWithContext(ctx context.Context, setCode string) ([]*MagicCard, error) {
+	sb, err := ensureCurrentScryball()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize scryball %v", err)
+	}
+	return sb.findSetTokens(ctx, setCode)
+}
+
+// QueryTokensForSet returns the tokens and emblems printed for a set using this Scryball instance.
+//
+// See the package-level QueryTokensForSet for details.
+func (sb *Scryball) QueryTokensForSet(setCode string) ([]*MagicCard, error) {
+	return sb.findSetTokens(context.Background(), setCode)
+}
+
+// QueryTokensForSetWithContext returns the tokens and emblems printed for a set using this Scryball instance with context support.
+func (sb *Scryball) QueryTokensForSetWithContext(ctx context.Context, setCode string) ([]*MagicCard, error) {
+	return sb.findSetTokens(ctx, setCode)
+}
+
+func (sb *Scryball) findSetTokens(ctx context.Context, setCode string) ([]*MagicCard, error) {
+	code := strings.ToLower(strings.TrimSpace(setCode))
+	if code == "" {
+		return nil, errors.New("set code must not be empty")
+	}
+
+	if len(code) == 4 && code[0] == 't' {
+		// a token set, search from the set it belongs to
+		code = code[1:]
+	}
+
+	tokens, err := sb.findQuery(ctx, tokenQuery(fmt.Sprintf("(e:%s or e:t%s)", code, code)))
+	if errors.Is(err, client.ErrNotFound) {
+		return []*MagicCard{}, nil
+	}
+	return tokens, err
+}
@@ -0,0 +1,134 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ninesl/scryball/internal/client"
+)
+
+func TestRequiredTokens(t *testing.T) {
+	sb := testHelper(t)
+	defer sb.db.Close()
+	ctx := context.Background()
+
+	insertTestCard(t, sb, testAPICard("treasure-oracle", "treasure-1", "Treasure", "Token Artifact — Treasure"),
+		testAPICard("treasure-oracle", "treasure-2", "Treasure", "Token Artifact — Treasure"))
+	insertTestCard(t, sb, testAPICard("soldier-oracle", "soldier-1", "Soldier", "Token Creature — Soldier"))
+
+	ragavan := testAPICard("ragavan-oracle", "ragavan-1", "Ragavan, Nimble Pilferer", "Legendary Creature — Monkey Pirate")
+	ragavan.AllParts = []client.RelatedCard{
+		{ID: "ragavan-1", Component: "combo_piece", Name: "Ragavan, Nimble Pilferer"},
+		{ID: "treasure-1", Component: "token", Name: "Treasure"},
+	}
+	insertTestCard(t, sb, ragavan)
+
+	// a different printing of the same token
+	smothering := testAPICard("tithe-oracle", "tithe-1", "Smothering Tithe", "Enchantment")
+	smothering.AllParts = []client.RelatedCard{{ID: "treasure-2", Component: "token", Name: "Treasure"}}
+	insertTestCard(t, sb, smothering)
+
+	captain := testAPICard("captain-oracle", "captain-1", "Captain of the Watch", "Creature — Human Soldier")
+	captain.AllParts = []client.RelatedCard{{ID: "soldier-1", Component: "token", Name: "Soldier"}}
+	insertTestCard(t, sb, captain)
+
+	insertTestCard(t, sb, testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant"))
+
+	deck, err := sb.ParseDecklist(`4 Ragavan, Nimble Pilferer
+4 Lightning Bolt
+
+Sideboard
+1 Smothering Tithe
+2 Captain of the Watch
+`)
+	if err != nil {
+		t.Fatalf("Failed to parse decklist: %v", err)
+	}
+
+	tokens, err := deck.RequiredTokens(ctx, sb)
+	if err != nil {
+		t.Fatalf("RequiredTokens failed: %v", err)
+	}
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 tokens, got %d", len(tokens))
+	}
+	if tokens[0].Name != "Soldier" || tokens[1].Name != "Treasure" {
+		t.Errorf("Expected Soldier and Treasure, got %s and %s", tokens[0].Name, tokens[1].Name)
+	}
+
+	noTokens := NewDecklist()
+	noTokens.AddCard(tokens[1], 1)
+	if tokens, err := noTokens.RequiredTokens(ctx, sb); err != nil || len(tokens) != 0 {
+		t.Errorf("Expected no tokens, got %d (err %v)", len(tokens), err)
+	}
+}
+
+func TestQueryTokens(t *testing.T) {
+	token := func(id, name, set string) string {
+		return fmt.Sprintf(`{"object": "card", "id": "%[1]s-%[3]s", "oracle_id": %[1]q, "name": %[2]q, "lang": "en",
+			"layout": "token", "type_line": "Token Artifact — %[2]s", "set": %[3]q, "set_type": "token", "rarity": "common",
+			"released_at": "2022-02-18", "prints_search_uri": "https://api.scryfall.com/cards/search?q=oracleid%%3A%[1]s&unique=prints"}`, id, name, set)
+	}
+	list := func(cards ...string) string {
+		return fmt.Sprintf(`{"object": "list", "has_more": false, "data": [%s]}`, strings.Join(cards, ", "))
+	}
+
+	var mu sync.Mutex
+	searches := map[string]int{}
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query().Get("q")
+		mu.Lock()
+		searches[q]++
+		mu.Unlock()
+		switch strings.ToLower(q) {
+		case `(t:token or t:emblem) !"treasure" include:extras`:
+			fmt.Fprint(w, list(token("treasure", "Treasure", "tneo")))
+		case "(t:token or t:emblem) (e:neo or e:tneo) include:extras":
+			fmt.Fprint(w, list(token("treasure", "Treasure", "tneo"), token("clue", "Clue", "tneo")))
+		case "oracleid:treasure", "oracleid:clue":
+			if r.URL.Query().Get("include_extras") != "true" {
+				http.NotFound(w, r)
+				return
+			}
+			id := strings.TrimPrefix(q, "oracleid:")
+			name := map[string]string{"treasure": "Treasure", "clue": "Clue"}[id]
+			fmt.Fprint(w, list(token(id, name, "tneo"), token(id, name, "tmh2")))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	for range 2 {
+		treasure, err := sb.QueryToken(" treasure ")
+		if err != nil {
+			t.Fatalf("QueryToken failed: %v", err)
+		}
+		if treasure.Name != "Treasure" || len(treasure.Printings) != 2 {
+			t.Errorf("Expected Treasure with both printings, got %s with %d", treasure.Name, len(treasure.Printings))
+		}
+	}
+	if searches[`(t:token or t:emblem) !"treasure" include:extras`] != 1 {
+		t.Errorf("Expected the token search to be cached, got %v", searches)
+	}
+	if _, err := sb.QueryToken("Goblin"); err == nil {
+		t.Error("Expected an error for a token Scryfall does not have")
+	}
+
+	for _, code := range []string{"neo", "TNEO"} {
+		tokens, err := sb.QueryTokensForSet(code)
+		if err != nil {
+			t.Fatalf("QueryTokensForSet(%s) failed: %v", code, err)
+		}
+		if len(tokens) != 2 || tokens[0].Name != "Treasure" || tokens[1].Name != "Clue" {
+			t.Errorf("QueryTokensForSet(%s) = %v, want Treasure and Clue", code, tokens)
+		}
+	}
+	if tokens, err := sb.QueryTokensForSet("lea"); err != nil || len(tokens) != 0 {
+		t.Errorf("Expected no tokens for a set without any, got %v %v", tokens, err)
+	}
+}
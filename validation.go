@@ -0,0 +1,316 @@
+package scryball
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ValidationRule is a single deck construction rule, like a deck size or a copy limit.
+//
+// Formats are built by combining rules into ValidationRules. Custom formats
+// can mix the built-in rules with their own:
+//
+//	highlander := scryball.ValidationRules{
+//		scryball.DeckSizeRule(100, 0),
+//		scryball.CopyLimitRule(1),
+//		scryball.ValidationRuleFunc(func(d *scryball.Decklist) error {
+//			if d.Companion != nil {
+//				return fmt.Errorf("companions are not allowed")
+//			}
+//			return nil
+//		}),
+//	}
+//	err := deck.Validate(highlander...)
+type ValidationRule interface {
+	// Validate returns nil if the deck follows the rule. Rules report several
+	// problems at once by returning a *ValidationError.
+	Validate(d *Decklist) error
+}
+
+// ValidationRuleFunc adapts a function to a ValidationRule.
+type ValidationRuleFunc func(d *Decklist) error
+
+// Validate calls f(d).
+func (f ValidationRuleFunc) Validate(d *Decklist) error {
+	return f(d)
+}
+
+// RuleID identifies the rule a Violation breaks, so programs can localize
+// messages or annotate cards without parsing Message.
+type RuleID string
+
+const (
+	RuleDeckMinimum        RuleID = "deck_minimum"        // Too few cards, Quantity cards for a Limit minimum
+	RuleDeckMaximum        RuleID = "deck_maximum"        // Too many cards, Quantity cards for a Limit maximum
+	RuleSideboardMaximum   RuleID = "sideboard_maximum"   // Too many sideboard cards
+	RuleCopyLimit          RuleID = "copy_limit"          // Quantity copies of Card, Limit allowed
+	RuleCommonPrinting     RuleID = "common_printing"     // Card was never printed at common
+	RuleBanned             RuleID = "banned"              // Card is banned or not legal in the format
+	RuleRestricted         RuleID = "restricted"          // Quantity copies of a restricted Card, Limit 1
+	RuleCommander          RuleID = "commander"           // Missing, extra or invalid commanders
+	RuleColorIdentity      RuleID = "color_identity"      // Card is outside the commanders' color identity
+	RuleCommanderSideboard RuleID = "commander_sideboard" // Card is in a Commander deck's sideboard
+	RuleCustom             RuleID = "custom"              // Plain error returned by a custom ValidationRule
+)
+
+// Violation is a single problem found while validating a deck.
+type Violation struct {
+	Rule     RuleID     // The rule broken
+	Card     *MagicCard // Offending card, nil for deck-wide problems like the deck size
+	Quantity int        // Cards or copies counted, 0 if the rule doesn't count
+	Limit    int        // Minimum or maximum the rule allows, 0 if the rule has none
+	Message  string     // Human-readable description of the problem, in English
+}
+
+func (v Violation) Error() string {
+	return v.Message
+}
+
+// ValidationError holds every violation found while validating a deck.
+//
+// Validation methods return a *ValidationError rather than stopping at the
+// first problem, so every issue can be shown at once:
+//
+//	var verr *scryball.ValidationError
+//	if errors.As(deck.ValidateConstructed(), &verr) {
+//		for _, v := range verr.Violations {
+//			fmt.Println(v.Message)
+//		}
+//	}
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Violations) == 1 {
+		return e.Violations[0].Message
+	}
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Message
+	}
+	return fmt.Sprintf("%d violations: %s", len(e.Violations), strings.Join(messages, "; "))
+}
+
+// Unwrap returns each violation as an error, for errors.Is and errors.As.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, v := range e.Violations {
+		errs[i] = v
+	}
+	return errs
+}
+
+// violations collects the problems a rule finds.
+type violations []Violation
+
+// add appends violation with its Message formatted from format and args.
+func (v *violations) add(violation Violation, format string, args ...any) {
+	violation.Message = fmt.Sprintf(format, args...)
+	*v = append(*v, violation)
+}
+
+// err returns the collected violations as a *ValidationError, nil if there are none.
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &ValidationError{Violations: v}
+}
+
+// ValidationRules is a chain of rules that make up a format.
+type ValidationRules []ValidationRule
+
+// Validate runs every rule and returns all of their violations as a *ValidationError, nil if legal.
+//
+// Errors from rules that are not a *ValidationError, like custom rules
+// returning fmt.Errorf, become a single Violation without a card.
+func (rules ValidationRules) Validate(d *Decklist) error {
+	var found violations
+	for _, rule := range rules {
+		err := rule.Validate(d)
+		if err == nil {
+			continue
+		}
+		var verr *ValidationError
+		if errors.As(err, &verr) {
+			found = append(found, verr.Violations...)
+		} else {
+			found.add(Violation{Rule: RuleCustom}, "%s", err.Error())
+		}
+	}
+	return found.err()
+}
+
+// Validate checks the deck against every rule, returns nil if legal.
+//
+// Every violation of every rule is returned in a *ValidationError.
+// See ValidationRule for building custom formats.
+func (d *Decklist) Validate(rules ...ValidationRule) error {
+	return ValidationRules(rules).Validate(d)
+}
+
+var (
+	// ConstructedRules are the rules of ValidateConstructed: 60+ cards, 15 card sideboard, 4 copies.
+	ConstructedRules = ValidationRules{DeckSizeRule(60, 0), SideboardSizeRule(15), CopyLimitRule(4)}
+
+	// LimitedRules are the rules of ValidateLimited: 40+ cards.
+	LimitedRules = ValidationRules{DeckSizeRule(40, 0)}
+)
+
+// DeckSizeRule requires at least min and at most max cards in the deck, 0 max for no limit.
+//
+// Commanders are part of the deck and are counted along with the maindeck.
+func DeckSizeRule(min, max int) ValidationRule {
+	return ValidationRuleFunc(func(d *Decklist) error {
+		var found violations
+		total := d.NumberOfCards() + len(d.Commanders)
+		if total < min {
+			found.add(Violation{Rule: RuleDeckMinimum, Quantity: total, Limit: min}, "maindeck has %d cards, minimum is %d", total, min)
+		}
+		if max > 0 && total > max {
+			found.add(Violation{Rule: RuleDeckMaximum, Quantity: total, Limit: max}, "maindeck has %d cards, maximum is %d", total, max)
+		}
+		return found.err()
+	})
+}
+
+// SideboardSizeRule allows at most max sideboard cards.
+func SideboardSizeRule(max int) ValidationRule {
+	return ValidationRuleFunc(func(d *Decklist) error {
+		var found violations
+		if total := d.NumberOfSideboardCards(); total > max {
+			found.add(Violation{Rule: RuleSideboardMaximum, Quantity: total, Limit: max}, "sideboard has %d cards, maximum is %d", total, max)
+		}
+		return found.err()
+	})
+}
+
+// CopyLimitRule allows at most max copies of each card between every zone of the deck.
+//
+// Basic lands are exempt, and cards whose oracle text allows more copies, ie.
+// Relentless Rats or Seven Dwarves, are held to that instead. See SetAnyNumberCards.
+func CopyLimitRule(max int) ValidationRule {
+	return ValidationRuleFunc(func(d *Decklist) error {
+		var found violations
+		copies := d.totalCopies()
+		reported := make(map[string]bool)
+		for _, card := range d.allCards() {
+			if reported[card.Name] {
+				continue
+			}
+			if exceeds, limit := exceedsCopyLimit(card, copies[card.Name], max); exceeds {
+				found.add(Violation{Rule: RuleCopyLimit, Card: card, Quantity: copies[card.Name], Limit: limit}, "total of %d copies of %s between maindeck and sideboard, maximum is %d", copies[card.Name], card.Name, limit)
+				reported[card.Name] = true
+			}
+		}
+		return found.err()
+	})
+}
+
+// CommonPrintingRule requires every card to have at least one common printing among its cached Printings.
+func CommonPrintingRule() ValidationRule {
+	return ValidationRuleFunc(func(d *Decklist) error {
+		var found violations
+		for _, card := range d.allCards() {
+			if !hasCommonPrinting(card) {
+				found.add(Violation{Rule: RuleCommonPrinting, Card: card}, "%s has no common printing", card.Name)
+			}
+		}
+		return found.err()
+	})
+}
+
+// LegalityRule rejects cards that are banned or not legal in format, and allows
+// a single copy of restricted cards, using each card's Legalities.
+//
+// Cards without legality data for format are not checked.
+func LegalityRule(format string) ValidationRule {
+	return ValidationRuleFunc(func(d *Decklist) error {
+		var found violations
+		copies := d.totalCopies()
+		for _, card := range d.allCards() {
+			switch card.Legalities[format] {
+			case "banned", "not_legal":
+				found.add(Violation{Rule: RuleBanned, Card: card}, "%s is %s in %s", card.Name, card.Legalities[format], format)
+			case "restricted":
+				if copies[card.Name] > 1 {
+					found.add(Violation{Rule: RuleRestricted, Card: card, Quantity: copies[card.Name], Limit: 1}, "%s is restricted in %s, has %d copies between maindeck and sideboard", card.Name, format, copies[card.Name])
+				}
+			}
+		}
+		return found.err()
+	})
+}
+
+// CommanderRule requires one commander or a valid pair (see ValidateCommander)
+// that can be commanders (see CanBeCommander), every maindeck card within their color identity, and no sideboard other than the companion.
+func CommanderRule() ValidationRule {
+	return commanderRule((*MagicCard).CanBeCommander)
+}
+
+// commanderRule is CommanderRule with canLead deciding which cards can be commanders.
+func commanderRule(canLead func(card *MagicCard) bool) ValidationRule {
+	return ValidationRuleFunc(func(d *Decklist) error {
+		var found violations
+		if err := d.validateCommanders(canLead); err != nil {
+			found.add(Violation{Rule: RuleCommander}, "%s", err.Error())
+		}
+		found = append(found, d.colorIdentityViolations()...)
+		for _, card := range sortedCards(d.Sideboard) {
+			if d.Companion == nil || card.Name != d.Companion.Name {
+				found.add(Violation{Rule: RuleCommanderSideboard, Card: card}, "%s is in the sideboard, Commander decks only have a companion outside the deck", card.Name)
+			}
+		}
+		return found.err()
+	})
+}
+
+// totalCopies counts copies by name across maindeck, sideboard, commanders and companion.
+//
+// Arena lists the companion in the sideboard as well, it is only counted once.
+func (d *Decklist) totalCopies() map[string]int {
+	copies := make(map[string]int)
+	for card, qty := range d.Maindeck {
+		copies[card.Name] += qty
+	}
+	for card, qty := range d.Sideboard {
+		copies[card.Name] += qty
+	}
+	for _, card := range d.Commanders {
+		copies[card.Name]++
+	}
+	if d.Companion != nil && copies[d.Companion.Name] == 0 {
+		copies[d.Companion.Name]++
+	}
+	return copies
+}
+
+// allCards returns every card in the deck once, sorted by name.
+func (d *Decklist) allCards() []*MagicCard {
+	cards := make(map[*MagicCard]int)
+	for card := range d.Maindeck {
+		addCardToMap(card, 1, cards)
+	}
+	for card := range d.Sideboard {
+		addCardToMap(card, 1, cards)
+	}
+	for _, card := range d.Commanders {
+		addCardToMap(card, 1, cards)
+	}
+	if d.Companion != nil {
+		addCardToMap(d.Companion, 1, cards)
+	}
+	return sortedCards(cards)
+}
+
+func sortedNames(copies map[string]int) []string {
+	names := make([]string, 0, len(copies))
+	for name := range copies {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
@@ -0,0 +1,186 @@
+package scryball
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecklistValidateRules(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	lurrus := &MagicCard{Card: testAPICard("lurrus-oracle", "lurrus-1", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 4)
+	deck.AddCard(mountain, 96)
+	deck.Companion = lurrus
+
+	noCompanion := ValidationRuleFunc(func(d *Decklist) error {
+		if d.Companion != nil {
+			return fmt.Errorf("companions are not allowed")
+		}
+		return nil
+	})
+	highlander := ValidationRules{DeckSizeRule(100, 0), CopyLimitRule(1), noCompanion}
+
+	err := deck.Validate(highlander...)
+	if err == nil || !strings.Contains(err.Error(), "Lightning Bolt") {
+		t.Errorf("Expected copy limit to fail first, got %v", err)
+	}
+
+	deck.SetQuantity(bolt, 1)
+	deck.AddCard(mountain, 3)
+	if err := highlander.Validate(deck); err == nil || !strings.Contains(err.Error(), "companions") {
+		t.Errorf("Expected custom rule to fail, got %v", err)
+	}
+
+	deck.Companion = nil
+	if err := highlander.Validate(deck); err != nil {
+		t.Errorf("Expected deck to pass custom format, got %v", err)
+	}
+
+	if err := deck.Validate(); err != nil {
+		t.Errorf("Expected no rules to pass, got %v", err)
+	}
+}
+
+func TestValidateLimitedSideboard(t *testing.T) {
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+
+	deck := NewDecklist()
+	deck.AddCard(mountain, 17)
+	deck.AddCard(bolt, 23)
+	deck.AddSideboardCard(bolt, 5)
+
+	// the rest of the pool is the sideboard, and drafts can have any number of a card
+	if err := deck.ValidateLimited(); err != nil {
+		t.Errorf("Expected Limited deck with a sideboard and many copies to be valid, got %v", err)
+	}
+}
+
+func TestCommanderRuleSideboard(t *testing.T) {
+	atraxa := testCard("atraxa", "Atraxa, Praetors' Voice", "Legendary Creature — Phyrexian Angel Horror", withOracleText("Flying"), withColorIdentity("W", "U", "B", "G"))
+	lurrus := testCard("lurrus", "Lurrus of the Dream-Den", "Legendary Creature — Cat Nightmare", withOracleText("Lifelink"), withColorIdentity("W", "B"))
+	swords := testCard("swords", "Swords to Plowshares", "Instant", withOracleText("Exile target creature."), withColorIdentity("W"))
+	forest := testCard("forest", "Forest", "Basic Land — Forest", withOracleText("({T}: Add {G}.)"))
+
+	deck := NewDecklist()
+	deck.Commanders = []*MagicCard{atraxa}
+	deck.AddCard(forest, 99)
+	deck.Companion = lurrus
+	deck.AddSideboardCard(lurrus, 1)
+
+	if err := deck.ValidateCommander(); err != nil {
+		t.Errorf("Expected companion in sideboard to be allowed, got %v", err)
+	}
+
+	deck.AddSideboardCard(swords, 1)
+	if err := deck.ValidateCommander(); err == nil || !strings.Contains(err.Error(), "Swords to Plowshares") {
+		t.Errorf("Expected other sideboard cards to be rejected, got %v", err)
+	}
+}
+
+func TestValidationErrorCollectsEveryViolation(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	chain := &MagicCard{Card: testAPICard("chain-oracle", "chain-1", "Chain Lightning", "Sorcery")}
+	mountain := &MagicCard{Card: testAPICard("mountain-oracle", "mountain-1", "Mountain", "Basic Land — Mountain")}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 5)
+	deck.AddCard(chain, 6)
+	deck.AddCard(mountain, 20)
+	deck.AddSideboardCard(pyro, 16)
+
+	err := deck.ValidateConstructed()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a *ValidationError, got %T: %v", err, err)
+	}
+	if len(verr.Violations) != 5 {
+		t.Fatalf("Expected 5 violations (size, sideboard, 3 copy limits), got %d: %v", len(verr.Violations), err)
+	}
+
+	var cards []string
+	for _, v := range verr.Violations {
+		if v.Card != nil {
+			cards = append(cards, v.Card.Name)
+		}
+	}
+	expected := []string{"Chain Lightning", "Lightning Bolt", "Pyroblast"}
+	if !reflect.DeepEqual(cards, expected) {
+		t.Errorf("Expected violations for %v, got %v", expected, cards)
+	}
+	if !strings.HasPrefix(err.Error(), "5 violations: ") {
+		t.Errorf("Expected combined message, got %q", err.Error())
+	}
+
+	// custom rule errors become violations too
+	custom := ValidationRuleFunc(func(d *Decklist) error { return fmt.Errorf("no burn allowed") })
+	err = deck.Validate(SideboardSizeRule(15), custom)
+	if !errors.As(err, &verr) || len(verr.Violations) != 2 || verr.Violations[1].Message != "no burn allowed" {
+		t.Errorf("Expected sideboard and custom violations, got %v", err)
+	}
+
+	if err := deck.ValidateFourOfs(); !errors.As(err, &verr) || len(verr.Violations) != 2 {
+		t.Errorf("Expected ValidateFourOfs to report both cards, got %v", err)
+	}
+}
+
+func TestViolationFields(t *testing.T) {
+	bolt := &MagicCard{Card: testAPICard("bolt-oracle", "bolt-1", "Lightning Bolt", "Instant")}
+	bolt.Legalities = map[string]string{"vintage": "legal"}
+	lotus := &MagicCard{Card: testAPICard("lotus-oracle", "lotus-1", "Black Lotus", "Artifact")}
+	lotus.Legalities = map[string]string{"vintage": "restricted"}
+	pyro := &MagicCard{Card: testAPICard("pyro-oracle", "pyro-1", "Pyroblast", "Instant")}
+	pyro.Legalities = map[string]string{"vintage": "legal"}
+
+	deck := NewDecklist()
+	deck.AddCard(bolt, 6)
+	deck.AddCard(lotus, 2)
+	deck.AddSideboardCard(pyro, 16)
+
+	err := deck.ValidateVintage()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a *ValidationError, got %T: %v", err, err)
+	}
+
+	got := make([]Violation, len(verr.Violations))
+	for i, v := range verr.Violations {
+		v.Message = ""
+		got[i] = v
+	}
+	expected := []Violation{
+		{Rule: RuleDeckMinimum, Quantity: 8, Limit: 60},
+		{Rule: RuleSideboardMaximum, Quantity: 16, Limit: 15},
+		{Rule: RuleCopyLimit, Card: bolt, Quantity: 6, Limit: 4},
+		{Rule: RuleCopyLimit, Card: pyro, Quantity: 16, Limit: 4},
+		{Rule: RuleRestricted, Card: lotus, Quantity: 2, Limit: 1},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected violations\n%s\ngot\n%s", describeViolations(expected), describeViolations(got))
+	}
+
+	custom := ValidationRuleFunc(func(d *Decklist) error { return fmt.Errorf("no burn allowed") })
+	if err := deck.Validate(custom); !errors.As(err, &verr) || verr.Violations[0].Rule != RuleCustom {
+		t.Errorf("Expected a custom violation, got %v", err)
+	}
+}
+
+// describeViolations formats violations without their messages, which Violation.Error would print.
+func describeViolations(violations []Violation) string {
+	var lines []string
+	for _, v := range violations {
+		name := "-"
+		if v.Card != nil {
+			name = v.Card.Name
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %d/%d", v.Rule, name, v.Quantity, v.Limit))
+	}
+	return strings.Join(lines, "\n")
+}
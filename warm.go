@@ -0,0 +1,93 @@
+package scryball
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// warmCacheWorkers is how many queries and names WarmCache fetches at once.
+// The client spaces out requests anyway, so more workers only help while
+// others are writing to the database.
+const warmCacheWorkers = 4
+
+// WarmCache fetches queries and card names into the cache ahead of time,
+// for app startup or nightly jobs, so later calls are served without API calls.
+//
+// Behavior:
+//   - Runs each query like Query and each name like QueryCard, several at once
+//   - Requests still respect Scryfall's rate limit, shared with every other call
+//   - Cached queries and names are skipped with zero API calls
+//   - A failed query or name doesn't stop the others
+//   - Stops starting new fetches when ctx is done
+//
+// Returns:
+//   - error: nil if everything was cached, otherwise every failure joined
+//     with errors.Join, or the context error
+//
+// Example:
+//
+//	err := sb.WarmCache(ctx,
+//	    []string{"f:modern t:creature r:mythic", "is:commander id:simic"},
+//	    []string{"Lightning Bolt", "Sol Ring"})
+func (s *Scryball) WarmCache(ctx context.Context, queries []string, names []string) error {
+	jobs := make(chan func() error)
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		failed []error
+	)
+	for range warmCacheWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if err := job(); err != nil {
+					mu.Lock()
+					failed = append(failed, err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	send := func(job func() error) bool {
+		select {
+		case jobs <- job:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	sent := true
+	for _, query := range queries {
+		if sent = send(func() error {
+			if _, err := s.findQuery(ctx, query); err != nil {
+				return fmt.Errorf("query %q: %v", query, err)
+			}
+			return nil
+		}); !sent {
+			break
+		}
+	}
+	for _, name := range names {
+		if !sent {
+			break
+		}
+		sent = send(func() error {
+			if _, err := s.findCard(ctx, name); err != nil {
+				return fmt.Errorf("card %q: %v", name, err)
+			}
+			return nil
+		})
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.Join(failed...)
+}
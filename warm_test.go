@@ -0,0 +1,95 @@
+package scryball
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWarmCache(t *testing.T) {
+	card := func(name string) string {
+		id := strings.ToLower(strings.ReplaceAll(name, " ", "-"))
+		return fmt.Sprintf(`{"object": "card", "id": "%s-1", "oracle_id": %q, "name": %q, "lang": "en",
+			"type_line": "Instant", "set": "tst", "rarity": "common", "released_at": "2020-01-01"}`, id, id, name)
+	}
+	sb := testAPIServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/cards/named" && r.URL.Query().Get("exact") != "Nonexistent Card":
+			fmt.Fprint(w, card(r.URL.Query().Get("exact")))
+		case r.URL.Path == "/cards/search" && r.URL.Query().Get("q") == "t:instant":
+			fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [%s, %s]}`, card("Opt"), card("Shock"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"object": "error", "status": 404}`)
+		}
+	})
+	ctx := context.Background()
+
+	err := sb.WarmCache(ctx, []string{"t:instant"}, []string{"Lightning Bolt", "Nonexistent Card", "Sol Ring"})
+	if err == nil || !strings.Contains(err.Error(), "Nonexistent Card") {
+		t.Errorf("Expected an error for the card that doesn't exist, got %v", err)
+	}
+
+	if cards, err := sb.FetchCardsByQuery(ctx, "t:instant"); err != nil || len(cards) != 2 {
+		t.Errorf("Expected the query to be cached with 2 cards, got %d, %v", len(cards), err)
+	}
+	for _, name := range []string{"Lightning Bolt", "Sol Ring", "Opt"} {
+		if _, err := sb.FetchCardByExactName(ctx, name); err != nil {
+			t.Errorf("Expected %s to be cached, got %v", name, err)
+		}
+	}
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := sb.WarmCache(canceled, nil, []string{"Counterspell"}); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestWarmCacheConcurrentWrites(t *testing.T) {
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = fmt.Sprintf("Card %d", i)
+	}
+	doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+		name := req.URL.Query().Get("exact")
+		id := strings.ReplaceAll(strings.ToLower(name), " ", "-")
+		recorder := httptest.NewRecorder()
+		fmt.Fprintf(recorder, `{"object": "card", "id": "%s-1", "oracle_id": %q, "name": %q, "lang": "en",
+			"type_line": "Instant", "set": "tst", "rarity": "common", "released_at": "2020-01-01"}`, id, id, name)
+		return recorder.Result(), nil
+	})
+
+	for _, tt := range []struct {
+		name   string
+		dbPath string
+	}{
+		{"memory", ""},
+		{"file", filepath.Join(t.TempDir(), "test.db")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sb, err := NewWithDoer(doer, ScryballConfig{DBPath: tt.dbPath})
+			if err != nil {
+				t.Fatalf("Failed to create Scryball: %v", err)
+			}
+			defer sb.Close()
+
+			ctx := context.Background()
+			if err := sb.WarmCache(ctx, nil, names); err != nil {
+				t.Fatalf("WarmCache failed: %v", err)
+			}
+			info, err := sb.DBInfo(ctx)
+			if err != nil {
+				t.Fatalf("DBInfo failed: %v", err)
+			}
+			if info.Cards != len(names) {
+				t.Errorf("Expected %d cached cards, got %d", len(names), info.Cards)
+			}
+		})
+	}
+}